@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/config"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+)
+
+// devcode opens the sealed one-time code of a WithdrawalChallengeIssued event with the
+// orchestrator's code key, standing in for the notification sender.
+func main() {
+	withdrawalID := flag.String("withdrawal", "", "withdrawal_id of the event")
+	sealed := flag.String("sealed", "", "sealed_code of the event")
+	flag.Parse()
+
+	if *withdrawalID == "" || *sealed == "" {
+		fmt.Fprintln(os.Stderr, "-withdrawal and -sealed are required")
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config load failed:", err)
+		os.Exit(1)
+	}
+
+	code, err := orchestrator.OpenChallengeCode(cfg.ChallengeCodeKey, *withdrawalID, *sealed)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(code)
+}
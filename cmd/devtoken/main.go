@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/config"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	pconfig "github.com/cicconee/cbsaga/internal/platform/config"
+)
+
+// devtoken mints an HS256 token accepted by the dev authenticators. Tokens are for the
+// orchestrator unless -aud names another service's audience, e.g. cbsaga-identity.
+func main() {
+	sub := flag.String("sub", "", "subject (user_id) of the token")
+	roles := flag.String("roles", "", "comma separated roles, e.g. admin,support")
+	kid := flag.String("kid", "dev", "key id to sign with")
+	ttl := flag.Duration("ttl", time.Hour, "token lifetime")
+	aud := flag.String("aud", "", "audience, defaults to the orchestrator's")
+	flag.Parse()
+
+	if *sub == "" {
+		fmt.Fprintln(os.Stderr, "-sub is required")
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config load failed:", err)
+		os.Exit(1)
+	}
+	if *aud == "" {
+		*aud = cfg.AuthAudience
+	}
+
+	keys, err := auth.ParseHMACKeys(cfg.AuthHMACKeys)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "auth keys invalid:", err)
+		os.Exit(1)
+	}
+	authn, err := auth.NewHMACAuthenticator(auth.HMACConfig{
+		Keys:     keys,
+		Issuer:   cfg.AuthIssuer,
+		Audience: *aud,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "auth init failed:", err)
+		os.Exit(1)
+	}
+
+	token, err := authn.Sign(*kid, *sub, pconfig.SplitCSV(*roles), *ttl)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sign failed:", err)
+		os.Exit(1)
+	}
+	fmt.Println(token)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/execution/chain/sim"
+	"github.com/cicconee/cbsaga/internal/execution/config"
+	"github.com/cicconee/cbsaga/internal/execution/consumer"
+	"github.com/cicconee/cbsaga/internal/execution/signer"
+	"github.com/cicconee/cbsaga/internal/execution/tracker"
+	"github.com/cicconee/cbsaga/internal/execution/wallet"
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/platform/health"
+	"github.com/cicconee/cbsaga/internal/platform/httpserver"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+)
+
+func main() {
+	log := logging.New("execution-srv", logging.Config{})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Error("config load failed", "err", err)
+		os.Exit(1)
+	}
+
+	log = logging.New("execution-srv", logging.Config{Format: cfg.LogFormat, Level: cfg.LogLevel})
+
+	// TODO: start up time out define in configuration.
+	startupCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	shutdownTracing, err := tracing.Setup(startupCtx, "execution", tracing.Config{
+		Exporter:     cfg.TraceExporter,
+		OTLPEndpoint: cfg.OTLPEndpoint,
+		OTLPInsecure: cfg.OTLPInsecure,
+		FilePath:     cfg.TraceFile,
+	})
+	if err != nil {
+		log.Error("tracing init failed", "err", err)
+		os.Exit(1)
+	}
+	defer func() {
+		sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer scancel()
+		_ = shutdownTracing(sctx)
+	}()
+
+	pool, err := postgres.NewPool(startupCtx, cfg.PostgresDSN, log)
+	if err != nil {
+		log.Error("execution postgres init failed", "err", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	key, err := signer.Load(cfg.KeyFile)
+	if err != nil {
+		log.Error("signing key load failed", "err", err, "file", cfg.KeyFile)
+		os.Exit(1)
+	}
+
+	client := sim.New(sim.Config{Balances: cfg.SimBalances})
+	go client.Run(ctx, cfg.SimBlockInterval)
+
+	w := wallet.New(pool, log, key, client, wallet.Config{
+		Interval:     cfg.WalletInterval,
+		ReleaseAfter: cfg.ReleaseAfter,
+	})
+	go w.Run(ctx)
+
+	c := consumer.New(
+		pool,
+		log,
+		w,
+		client,
+		cfg.KafkaBrokers,
+		cfg.ExecutionConsumerGroupID,
+		cfg.ExecutionCmdTopic,
+	)
+	defer func() { _ = c.Close() }()
+
+	tr := tracker.New(pool, log, client, tracker.Config{
+		Interval:  cfg.TrackInterval,
+		BatchSize: cfg.TrackBatchSize,
+	})
+	go tr.Run(ctx)
+
+	checker := health.NewChecker(health.Options{Interval: cfg.HealthInterval}, log)
+	checker.Add("postgres", health.Readiness, health.PostgresCheck(pool))
+	checker.Add("kafka", health.Readiness, health.KafkaCheck(cfg.KafkaBrokers))
+	checker.Add("execution-consumer", health.Liveness, c.Liveness().Check(cfg.ConsumerStall))
+	go checker.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	health.Register(mux, checker)
+	if cfg.SimControl {
+		mux.Handle("/sim/", client.Handler())
+	}
+
+	ops, err := httpserver.New("ops", cfg.OpsAddr, mux, log)
+	if err != nil {
+		log.Error("ops http server init failed", "err", err)
+		os.Exit(1)
+	}
+	go func() {
+		if err := ops.Serve(log); err != nil {
+			log.Error("ops http server crashed", "err", err)
+		}
+	}()
+	defer func() {
+		sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer scancel()
+		ops.Shutdown(sctx, log)
+	}()
+
+	log.Info("execution-svc running",
+		"topic", cfg.ExecutionCmdTopic,
+		"group", cfg.ExecutionConsumerGroupID,
+		"chain", cfg.Chain,
+		"sim_control", cfg.SimControl,
+		"from_addr", key.Address(),
+		"brokers", cfg.KafkaBrokers,
+		"ops", cfg.OpsAddr,
+	)
+
+	if err := c.Run(ctx); err != nil {
+		log.Error("execution consumer failed", "err", err)
+		os.Exit(1)
+	}
+}
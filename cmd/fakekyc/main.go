@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/identity/provider/fake"
+	"github.com/cicconee/cbsaga/internal/platform/config"
+	"github.com/cicconee/cbsaga/internal/platform/httpserver"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+)
+
+// fakekyc is a local KYC provider for exercising the identity service's asynchronous path
+// (CBSAGA_IDENTITY_PROVIDER=http) offline.
+func main() {
+	log := logging.New("fakekyc", logging.Config{
+		Format: config.GetEnv("CBSAGA_LOG_FORMAT", "json"),
+		Level:  config.GetEnv("CBSAGA_LOG_LEVEL", "info"),
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg := fake.Config{
+		Delay:         config.GetEnvDuration("CBSAGA_FAKEKYC_DELAY", 3*time.Second),
+		RejectPercent: config.GetEnvInt("CBSAGA_FAKEKYC_REJECT_PERCENT", 0),
+		RejectUsers:   config.SplitCSV(config.GetEnv("CBSAGA_FAKEKYC_REJECT_USERS", "")),
+		Secret: config.GetEnv(
+			"CBSAGA_IDENTITY_WEBHOOK_SECRET",
+			"dev-webhook-secret-change-me",
+		),
+	}
+	addr := config.GetEnv("CBSAGA_FAKEKYC_ADDR", ":9300")
+
+	p := fake.New(ctx, cfg, log)
+	srv, err := httpserver.New("fakekyc", addr, p.Handler(), log)
+	if err != nil {
+		log.Error("fakekyc http server init failed", "err", err)
+		os.Exit(1)
+	}
+	go func() {
+		if err := srv.Serve(log); err != nil {
+			log.Error("fakekyc http server crashed", "err", err)
+			stop()
+		}
+	}()
+
+	log.Info("fakekyc running",
+		"addr", addr,
+		"delay", cfg.Delay,
+		"reject_percent", cfg.RejectPercent,
+		"reject_users", len(cfg.RejectUsers),
+	)
+
+	<-ctx.Done()
+	sctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv.Shutdown(sctx, log)
+}
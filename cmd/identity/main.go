@@ -2,19 +2,30 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/cicconee/cbsaga/internal/identity/api"
+	"github.com/cicconee/cbsaga/internal/identity/app"
 	"github.com/cicconee/cbsaga/internal/identity/config"
 	"github.com/cicconee/cbsaga/internal/identity/consumer"
+	"github.com/cicconee/cbsaga/internal/identity/provider"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
 	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/platform/grpcserver"
+	"github.com/cicconee/cbsaga/internal/platform/health"
+	"github.com/cicconee/cbsaga/internal/platform/httpserver"
 	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"google.golang.org/grpc"
 )
 
 func main() {
-	log := logging.New("identity-srv")
+	log := logging.New("identity-srv", logging.Config{})
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -25,10 +36,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	log = logging.New("identity-srv", logging.Config{Format: cfg.LogFormat, Level: cfg.LogLevel})
+
 	// TODO: start up time out define in configuration.
 	startupCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	shutdownTracing, err := tracing.Setup(startupCtx, "identity", tracing.Config{
+		Exporter:     cfg.TraceExporter,
+		OTLPEndpoint: cfg.OTLPEndpoint,
+		OTLPInsecure: cfg.OTLPInsecure,
+		FilePath:     cfg.TraceFile,
+	})
+	if err != nil {
+		log.Error("tracing init failed", "err", err)
+		os.Exit(1)
+	}
+	defer func() {
+		sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer scancel()
+		_ = shutdownTracing(sctx)
+	}()
+
 	pool, err := postgres.NewPool(startupCtx, cfg.PostgresDSN, log)
 	if err != nil {
 		log.Error("identity postgres init failed", "err", err)
@@ -36,23 +65,146 @@ func main() {
 	}
 	defer pool.Close()
 
+	svc := app.NewService(pool, log, app.Config{
+		VerificationTTL: cfg.VerificationTTL,
+		PendingTimeout:  cfg.PendingTimeout,
+	})
+
+	var kyc provider.Provider
+	if cfg.Provider == config.ProviderHTTP {
+		kyc, err = provider.NewHTTP(provider.HTTPConfig{
+			Name:        "http",
+			BaseURL:     cfg.ProviderURL,
+			CallbackURL: cfg.WebhookURL,
+		})
+		if err != nil {
+			log.Error("kyc provider init failed", "err", err)
+			os.Exit(1)
+		}
+
+		hooks := http.NewServeMux()
+		hooks.Handle("/webhooks/kyc",
+			provider.WebhookHandler(cfg.WebhookSecret, svc.CompleteVerification, log),
+		)
+		wh, err := httpserver.New("webhook", cfg.WebhookAddr, hooks, log)
+		if err != nil {
+			log.Error("webhook http server init failed", "err", err)
+			os.Exit(1)
+		}
+		go func() {
+			if err := wh.Serve(log); err != nil {
+				log.Error("webhook http server crashed", "err", err)
+			}
+		}()
+		defer func() {
+			sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+			defer scancel()
+			wh.Shutdown(sctx, log)
+		}()
+	}
+
 	c := consumer.New(
 		pool,
 		log,
+		kyc,
+		cfg.VerificationTTL,
 		cfg.KafkaBrokers,
 		cfg.IdentityConsumerGroupID,
 		cfg.IdentityCmdTopic,
 	)
 	defer func() { _ = c.Close() }()
 
+	checker := health.NewChecker(health.Options{Interval: cfg.HealthInterval}, log)
+	checker.Add("postgres", health.Readiness, health.PostgresCheck(pool))
+	checker.Add("kafka", health.Readiness, health.KafkaCheck(cfg.KafkaBrokers))
+	checker.Add("identity-consumer", health.Liveness, c.Liveness().Check(cfg.ConsumerStall))
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	health.Register(mux, checker)
+
+	ops, err := httpserver.New("ops", cfg.OpsAddr, mux, log)
+	if err != nil {
+		log.Error("ops http server init failed", "err", err)
+		os.Exit(1)
+	}
+	go func() {
+		if err := ops.Serve(log); err != nil {
+			log.Error("ops http server crashed", "err", err)
+		}
+	}()
+	defer func() {
+		sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer scancel()
+		ops.Shutdown(sctx, log)
+	}()
+
+	keys, err := auth.ParseHMACKeys(cfg.AuthHMACKeys)
+	if err != nil {
+		log.Error("auth keys invalid", "err", err)
+		os.Exit(1)
+	}
+	authn, err := auth.NewHMACAuthenticator(auth.HMACConfig{
+		Keys:     keys,
+		Issuer:   cfg.AuthIssuer,
+		Audience: cfg.AuthAudience,
+		Leeway:   30 * time.Second,
+	})
+	if err != nil {
+		log.Error("auth init failed", "err", err)
+		os.Exit(1)
+	}
+
+	srv, err := grpcserver.New(
+		grpcserver.Options{
+			Addr:       cfg.GRPCAddr,
+			Reflection: cfg.GRPCReflection,
+			UnaryInterceptors: []grpc.UnaryServerInterceptor{
+				auth.UnaryServerInterceptor(authn, auth.PublicPrefixes),
+			},
+			StreamInterceptors: []grpc.StreamServerInterceptor{
+				auth.StreamServerInterceptor(authn, auth.PublicPrefixes),
+			},
+		},
+		log,
+		func(gs *grpc.Server) {
+			api.Register(gs, svc, log)
+		},
+	)
+	if err != nil {
+		log.Error("grpc server init failed", "err", err)
+		os.Exit(1)
+	}
+
+	checker.OnChange(srv.SetServing)
+	go checker.Run(ctx)
+	go svc.RunPendingSweeper(ctx, cfg.PendingSweepInterval)
+
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- srv.Serve(log)
+	}()
+	go func() {
+		if err := c.Run(ctx); err != nil {
+			errCh <- err
+		}
+	}()
+
 	log.Info("identity-svc running",
 		"topic", cfg.IdentityCmdTopic,
 		"group", cfg.IdentityConsumerGroupID,
 		"brokers", cfg.KafkaBrokers,
+		"grpc", cfg.GRPCAddr,
+		"provider", cfg.Provider,
+		"ops", cfg.OpsAddr,
 	)
 
-	if err := c.Run(ctx); err != nil {
-		log.Error("identity consumer failed", "err", err)
+	select {
+	case <-ctx.Done():
+		log.Info("shutdown signal received")
+		srv.GracefulStop(log)
+	case err := <-errCh:
+		log.Error("identity-svc exited", "err", err)
 		os.Exit(1)
 	}
 }
@@ -2,23 +2,33 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/cicconee/cbsaga/internal/orchestrator/address"
 	"github.com/cicconee/cbsaga/internal/orchestrator/api"
 	"github.com/cicconee/cbsaga/internal/orchestrator/app"
+	"github.com/cicconee/cbsaga/internal/orchestrator/challenge"
 	"github.com/cicconee/cbsaga/internal/orchestrator/config"
 	"github.com/cicconee/cbsaga/internal/orchestrator/consumer"
+	"github.com/cicconee/cbsaga/internal/orchestrator/gateway"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
 	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
 	"github.com/cicconee/cbsaga/internal/platform/grpcserver"
+	"github.com/cicconee/cbsaga/internal/platform/health"
+	"github.com/cicconee/cbsaga/internal/platform/httpserver"
 	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/cicconee/cbsaga/internal/platform/ratelimit"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
 	"google.golang.org/grpc"
 )
 
 func main() {
-	log := logging.New("orchestrator")
+	log := logging.New("orchestrator", logging.Config{})
 
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
@@ -29,10 +39,28 @@ func main() {
 		os.Exit(1)
 	}
 
+	log = logging.New("orchestrator", logging.Config{Format: cfg.LogFormat, Level: cfg.LogLevel})
+
 	// TODO: start up time out define in configuration.
 	startupCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	shutdownTracing, err := tracing.Setup(startupCtx, "orchestrator", tracing.Config{
+		Exporter:     cfg.TraceExporter,
+		OTLPEndpoint: cfg.OTLPEndpoint,
+		OTLPInsecure: cfg.OTLPInsecure,
+		FilePath:     cfg.TraceFile,
+	})
+	if err != nil {
+		log.Error("tracing init failed", "err", err)
+		os.Exit(1)
+	}
+	defer func() {
+		sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer scancel()
+		_ = shutdownTracing(sctx)
+	}()
+
 	pool, err := postgres.NewPool(startupCtx, cfg.PostgresDSN, log)
 	if err != nil {
 		log.Error("postgres init failed", "err", err)
@@ -55,11 +83,151 @@ func main() {
 		}
 	}()
 
-	svc := app.NewService(pool, log)
+	challenges := challenge.Policy{
+		TTL:         cfg.ChallengeTTL,
+		MaxAttempts: cfg.ChallengeMaxAttempts,
+		CodeKey:     cfg.ChallengeCodeKey,
+	}
+	rkc := consumer.NewRisk(
+		pool,
+		log,
+		cfg.KafkaBrokers,
+		cfg.RiskGroupID,
+		cfg.RiskEvtTopic,
+		cfg.ReviewSLA,
+		challenges,
+	)
+	defer func() { _ = rkc.Close() }()
+
+	go func() {
+		if err := rkc.Run(ctx); err != nil {
+			log.Error("risk consumer crashed", "err", err)
+		}
+	}()
+
+	exc := consumer.NewExecution(
+		pool,
+		log,
+		cfg.KafkaBrokers,
+		cfg.ExecutionGroupID,
+		cfg.ExecutionEvtTopic,
+		cfg.MaxRebroadcasts,
+	)
+	defer func() { _ = exc.Close() }()
+
+	go func() {
+		if err := exc.Run(ctx); err != nil {
+			log.Error("execution consumer crashed", "err", err)
+		}
+	}()
+
+	addresses, err := address.NewDefaultRegistry(address.Options{
+		BitcoinChain:    cfg.BitcoinChain,
+		FallbackPattern: cfg.AddressPattern,
+	})
+	if err != nil {
+		log.Error("address validators init failed", "err", err)
+		os.Exit(1)
+	}
+
+	svc := app.NewService(pool, log, app.Config{
+		Addresses:           addresses,
+		AllowlistMode:       cfg.AllowlistMode,
+		CoolingOff:          cfg.AddressCoolingOff,
+		VelocityDefaultTier: cfg.VelocityDefaultTier,
+		ReviewClaimTTL:      cfg.ReviewClaimTTL,
+		Challenges:          challenges,
+	})
+	go svc.RunReviewSweeper(ctx, cfg.ReviewSweepInterval)
+	go svc.RunChallengeSweeper(ctx, cfg.ChallengeSweepInterval)
+
+	checker := health.NewChecker(health.Options{Interval: cfg.HealthInterval}, log)
+	checker.Add("postgres", health.Readiness, health.PostgresCheck(pool))
+	checker.Add("kafka", health.Readiness, health.KafkaCheck(cfg.KafkaBrokers))
+	checker.Add("identity-consumer", health.Liveness, idc.Liveness().Check(cfg.ConsumerStall))
+	checker.Add("risk-consumer", health.Liveness, rkc.Liveness().Check(cfg.ConsumerStall))
+	checker.Add("execution-consumer", health.Liveness, exc.Liveness().Check(cfg.ConsumerStall))
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	health.Register(mux, checker)
+
+	ops, err := httpserver.New("ops", cfg.OpsAddr, mux, log)
+	if err != nil {
+		log.Error("ops http server init failed", "err", err)
+		os.Exit(1)
+	}
+	go func() {
+		if err := ops.Serve(log); err != nil {
+			log.Error("ops http server crashed", "err", err)
+		}
+	}()
+	defer func() {
+		sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer scancel()
+		ops.Shutdown(sctx, log)
+	}()
+
+	keys, err := auth.ParseHMACKeys(cfg.AuthHMACKeys)
+	if err != nil {
+		log.Error("auth keys invalid", "err", err)
+		os.Exit(1)
+	}
+	authn, err := auth.NewHMACAuthenticator(auth.HMACConfig{
+		Keys:     keys,
+		Issuer:   cfg.AuthIssuer,
+		Audience: cfg.AuthAudience,
+		Leeway:   30 * time.Second,
+	})
+	if err != nil {
+		log.Error("auth init failed", "err", err)
+		os.Exit(1)
+	}
+
+	unary := []grpc.UnaryServerInterceptor{
+		auth.UnaryServerInterceptor(authn, auth.PublicPrefixes),
+	}
+
+	var limiter ratelimit.Store
+	switch cfg.RateLimitBackend {
+	case config.RateLimitPostgres:
+		pg := ratelimit.NewPostgresStore(pool, "orchestrator.rate_limit_buckets")
+		go pg.RunSweeper(ctx, time.Minute, 10*time.Minute, log)
+		limiter = pg
+	case config.RateLimitMemory:
+		mem := ratelimit.NewMemoryStore()
+		go mem.RunSweeper(ctx, time.Minute, 10*time.Minute)
+		limiter = mem
+	}
+	if limiter != nil {
+		policies := api.RateLimitPolicies(
+			ratelimit.Limit{Burst: cfg.CreateUserBurst, Every: cfg.CreateUserEvery},
+			ratelimit.Limit{Burst: cfg.CreateMethodBurst, Every: cfg.CreateMethodEvery},
+			cfg.CreateMethodShards,
+			ratelimit.Limit{Burst: cfg.WriteUserBurst, Every: cfg.WriteUserEvery},
+		)
+		unary = append(unary, ratelimit.UnaryServerInterceptor(limiter, policies, log))
+	}
+
+	var tlsOpts *grpcserver.TLSOptions
+	if cfg.TLSEnabled() {
+		tlsOpts = &grpcserver.TLSOptions{
+			CertFile:       cfg.TLSCertFile,
+			KeyFile:        cfg.TLSKeyFile,
+			ClientCAFile:   cfg.TLSClientCAFile,
+			ReloadInterval: cfg.TLSReloadInterval,
+		}
+	}
 
 	srv, err := grpcserver.New(
 		grpcserver.Options{
-			Addr: cfg.GRPCAddr,
+			Addr:              cfg.GRPCAddr,
+			TLS:               tlsOpts,
+			Reflection:        cfg.GRPCReflection,
+			UnaryInterceptors: unary,
+			StreamInterceptors: []grpc.StreamServerInterceptor{
+				auth.StreamServerInterceptor(authn, auth.PublicPrefixes),
+			},
 		},
 		log,
 		func(gs *grpc.Server) {
@@ -71,12 +239,58 @@ func main() {
 		os.Exit(1)
 	}
 
+	if cfg.GatewayEnabled() {
+		var gwTLS *gateway.ClientTLS
+		if cfg.TLSEnabled() {
+			gwTLS = &gateway.ClientTLS{
+				CAFile:     cfg.GatewayCAFile,
+				CertFile:   cfg.GatewayCertFile,
+				KeyFile:    cfg.GatewayKeyFile,
+				ServerName: cfg.GatewayServerName,
+			}
+		}
+		dialOpts, err := gateway.DialOptions(gwTLS)
+		if err != nil {
+			log.Error("gateway dial options invalid", "err", err)
+			os.Exit(1)
+		}
+		gw, err := gateway.New(ctx, cfg.GatewayTarget, dialOpts)
+		if err != nil {
+			log.Error("gateway init failed", "err", err)
+			os.Exit(1)
+		}
+
+		hs, err := httpserver.New("gateway", cfg.HTTPAddr, gw, log)
+		if err != nil {
+			log.Error("gateway http server init failed", "err", err)
+			os.Exit(1)
+		}
+		go func() {
+			if err := hs.Serve(log); err != nil {
+				log.Error("gateway http server crashed", "err", err)
+			}
+		}()
+		defer func() {
+			sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+			defer scancel()
+			hs.Shutdown(sctx, log)
+		}()
+	}
+
+	checker.OnChange(srv.SetServing)
+	go checker.Run(ctx)
+
 	errCh := make(chan error, 1)
 	go func() {
 		errCh <- srv.Serve(log)
 	}()
 
-	log.Info("orchestrator running", "env", cfg.Env, "grpc", cfg.GRPCAddr)
+	log.Info("orchestrator running",
+		"env", cfg.Env,
+		"grpc", cfg.GRPCAddr,
+		"ops", cfg.OpsAddr,
+		"http", cfg.HTTPAddr,
+	)
 
 	select {
 	case <-ctx.Done():
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/platform/health"
+	"github.com/cicconee/cbsaga/internal/platform/httpserver"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/risk/config"
+	"github.com/cicconee/cbsaga/internal/risk/consumer"
+	"github.com/cicconee/cbsaga/internal/risk/engine"
+	"github.com/cicconee/cbsaga/internal/risk/screening"
+)
+
+func main() {
+	log := logging.New("risk-srv", logging.Config{})
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Error("config load failed", "err", err)
+		os.Exit(1)
+	}
+
+	log = logging.New("risk-srv", logging.Config{Format: cfg.LogFormat, Level: cfg.LogLevel})
+
+	// TODO: start up time out define in configuration.
+	startupCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	shutdownTracing, err := tracing.Setup(startupCtx, "risk", tracing.Config{
+		Exporter:     cfg.TraceExporter,
+		OTLPEndpoint: cfg.OTLPEndpoint,
+		OTLPInsecure: cfg.OTLPInsecure,
+		FilePath:     cfg.TraceFile,
+	})
+	if err != nil {
+		log.Error("tracing init failed", "err", err)
+		os.Exit(1)
+	}
+	defer func() {
+		sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer scancel()
+		_ = shutdownTracing(sctx)
+	}()
+
+	pool, err := postgres.NewPool(startupCtx, cfg.PostgresDSN, log)
+	if err != nil {
+		log.Error("risk postgres init failed", "err", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	rules, err := engine.Load(cfg.RulesFile)
+	if err != nil {
+		log.Error("risk rules load failed", "err", err, "file", cfg.RulesFile)
+		os.Exit(1)
+	}
+
+	screener, err := screening.New(screening.Options{
+		AddressesFile:  cfg.SanctionsAddresses,
+		UsersFile:      cfg.SanctionsUsers,
+		ReloadInterval: cfg.SanctionsReload,
+	}, log)
+	if err != nil {
+		log.Error("sanctions lists load failed", "err", err)
+		os.Exit(1)
+	}
+	go screener.Run(ctx)
+
+	c := consumer.New(
+		pool,
+		log,
+		rules,
+		screener,
+		cfg.KafkaBrokers,
+		cfg.RiskConsumerGroupID,
+		cfg.RiskCmdTopic,
+	)
+	defer func() { _ = c.Close() }()
+
+	checker := health.NewChecker(health.Options{Interval: cfg.HealthInterval}, log)
+	checker.Add("postgres", health.Readiness, health.PostgresCheck(pool))
+	checker.Add("kafka", health.Readiness, health.KafkaCheck(cfg.KafkaBrokers))
+	checker.Add("risk-consumer", health.Liveness, c.Liveness().Check(cfg.ConsumerStall))
+	go checker.Run(ctx)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	health.Register(mux, checker)
+
+	ops, err := httpserver.New("ops", cfg.OpsAddr, mux, log)
+	if err != nil {
+		log.Error("ops http server init failed", "err", err)
+		os.Exit(1)
+	}
+	go func() {
+		if err := ops.Serve(log); err != nil {
+			log.Error("ops http server crashed", "err", err)
+		}
+	}()
+	defer func() {
+		sctx, scancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer scancel()
+		ops.Shutdown(sctx, log)
+	}()
+
+	log.Info("risk-svc running",
+		"topic", cfg.RiskCmdTopic,
+		"group", cfg.RiskConsumerGroupID,
+		"rules_version", rules.Version(),
+		"sanctions_version", screener.Lists().Version,
+		"brokers", cfg.KafkaBrokers,
+		"ops", cfg.OpsAddr,
+	)
+
+	if err := c.Run(ctx); err != nil {
+		log.Error("risk consumer failed", "err", err)
+		os.Exit(1)
+	}
+}
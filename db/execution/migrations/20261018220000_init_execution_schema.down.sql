@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP SCHEMA IF EXISTS execution CASCADE;
+
+COMMIT;
@@ -0,0 +1,56 @@
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE SCHEMA IF NOT EXISTS execution;
+
+-- One signed transaction per withdrawal. The row is written before the transaction is
+-- submitted, so a redelivered command resubmits the same bytes instead of signing a second
+-- transfer.
+CREATE TABLE IF NOT EXISTS execution.transactions (
+  withdrawal_id    UUID PRIMARY KEY,
+  user_id          UUID NOT NULL,
+  asset            TEXT NOT NULL,
+  network          TEXT NOT NULL,
+  amount_minor     BIGINT NOT NULL CHECK (amount_minor > 0),
+  from_addr        TEXT NOT NULL,
+  destination_addr TEXT NOT NULL,
+  tx_hash          TEXT NOT NULL,
+  raw_tx           BYTEA NOT NULL,
+  status           TEXT NOT NULL,  -- SIGNED | BROADCAST | FAILED
+  failure_reason   TEXT NULL,
+  broadcast_at     TIMESTAMPTZ NULL,
+  created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT ck_execution_status CHECK (status IN ('SIGNED', 'BROADCAST', 'FAILED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_execution_tx_hash
+  ON execution.transactions (tx_hash);
+
+CREATE INDEX IF NOT EXISTS idx_execution_status_updated
+  ON execution.transactions (status, updated_at ASC);
+
+CREATE TABLE IF NOT EXISTS execution.outbox_events (
+  event_id       UUID PRIMARY KEY,
+  aggregate_type TEXT NOT NULL,  -- "execution"
+  aggregate_id   UUID NOT NULL,  -- withdrawal_id
+  event_type     TEXT NOT NULL,  -- WithdrawalBroadcast | WithdrawalExecutionFailed
+  payload_json   TEXT NOT NULL,
+  trace_id       TEXT NULL,
+  route_key      TEXT NOT NULL DEFAULT 'evt.execution',
+  traceparent    TEXT NULL,
+  created_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_execution_outbox_created
+  ON execution.outbox_events (created_at ASC);
+
+CREATE INDEX IF NOT EXISTS idx_execution_outbox_aggregate
+  ON execution.outbox_events (aggregate_type, aggregate_id);
+
+CREATE INDEX IF NOT EXISTS idx_execution_outbox_route_created
+  ON execution.outbox_events (route_key, created_at ASC);
+
+COMMIT;
@@ -0,0 +1,19 @@
+BEGIN;
+
+UPDATE execution.transactions
+SET status = 'BROADCAST'
+WHERE status IN ('CONFIRMED', 'REORGED');
+
+ALTER TABLE execution.transactions
+  DROP CONSTRAINT IF EXISTS ck_execution_required_confirmations,
+  DROP CONSTRAINT IF EXISTS ck_execution_status,
+  ADD CONSTRAINT ck_execution_status CHECK (status IN ('SIGNED', 'BROADCAST', 'FAILED')),
+  DROP COLUMN IF EXISTS trace_id,
+  DROP COLUMN IF EXISTS reorgs,
+  DROP COLUMN IF EXISTS confirmed_at,
+  DROP COLUMN IF EXISTS block_hash,
+  DROP COLUMN IF EXISTS block_height,
+  DROP COLUMN IF EXISTS confirmations,
+  DROP COLUMN IF EXISTS required_confirmations;
+
+COMMIT;
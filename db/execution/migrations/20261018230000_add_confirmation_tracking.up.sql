@@ -0,0 +1,18 @@
+BEGIN;
+
+-- block_height / block_hash are where the transaction was last seen included. A later poll that
+-- finds it in another block, back in the mempool or gone means it was reorged out or dropped.
+ALTER TABLE execution.transactions
+  ADD COLUMN IF NOT EXISTS required_confirmations BIGINT NOT NULL DEFAULT 1,
+  ADD COLUMN IF NOT EXISTS confirmations BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS block_height BIGINT NULL,
+  ADD COLUMN IF NOT EXISTS block_hash TEXT NULL,
+  ADD COLUMN IF NOT EXISTS confirmed_at TIMESTAMPTZ NULL,
+  ADD COLUMN IF NOT EXISTS reorgs INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS trace_id TEXT NULL,
+  DROP CONSTRAINT IF EXISTS ck_execution_status,
+  ADD CONSTRAINT ck_execution_status
+    CHECK (status IN ('SIGNED', 'BROADCAST', 'CONFIRMED', 'REORGED', 'FAILED')),
+  ADD CONSTRAINT ck_execution_required_confirmations CHECK (required_confirmations > 0);
+
+COMMIT;
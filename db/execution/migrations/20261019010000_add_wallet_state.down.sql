@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE IF EXISTS execution.utxo_reservations;
+DROP TABLE IF EXISTS execution.wallet_nonces;
+DROP INDEX IF EXISTS execution.idx_execution_sender_nonce;
+
+ALTER TABLE execution.transactions
+  DROP COLUMN IF EXISTS nonce;
+
+COMMIT;
@@ -0,0 +1,47 @@
+BEGIN;
+
+-- nonce is set on account networks. A failed transaction keeps its nonce for the record; the
+-- nonce is handed out again if nothing was allocated after it, otherwise the wallet fills the gap
+-- with a zero self-transfer once later transactions queue behind it.
+--
+-- tx_hash and raw_tx are empty on a transaction that failed before it could be signed, e.g. when
+-- no combination of unreserved outputs covers the amount.
+ALTER TABLE execution.transactions
+  ADD COLUMN IF NOT EXISTS nonce BIGINT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_execution_sender_nonce
+  ON execution.transactions (network, from_addr, nonce)
+  WHERE nonce IS NOT NULL;
+
+-- Next nonce to hand out per sending address. Allocating locks the row, so concurrent withdrawals
+-- from one address never share a nonce.
+CREATE TABLE IF NOT EXISTS execution.wallet_nonces (
+  network    TEXT NOT NULL,
+  address    TEXT NOT NULL,
+  next_nonce BIGINT NOT NULL CHECK (next_nonce >= 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (network, address)
+);
+
+-- Outputs selected as inputs of a withdrawal on a UTXO network. They stay reserved until the
+-- transaction confirms or is given up, so a reorged transaction can go out again with the same
+-- inputs and no other withdrawal selects them meanwhile.
+CREATE TABLE IF NOT EXISTS execution.utxo_reservations (
+  network       TEXT NOT NULL,
+  tx_hash       TEXT NOT NULL,
+  output_index  INT NOT NULL,
+  asset         TEXT NOT NULL,
+  address       TEXT NOT NULL,
+  amount_minor  BIGINT NOT NULL CHECK (amount_minor > 0),
+  withdrawal_id UUID NOT NULL
+    REFERENCES execution.transactions (withdrawal_id) ON DELETE CASCADE,
+  reserved_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (network, tx_hash, output_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_execution_utxo_reservations_withdrawal
+  ON execution.utxo_reservations (withdrawal_id);
+
+COMMIT;
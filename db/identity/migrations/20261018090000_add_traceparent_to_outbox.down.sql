@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE identity.outbox_events
+  DROP COLUMN IF EXISTS traceparent;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE identity.outbox_events
+  ADD COLUMN IF NOT EXISTS traceparent TEXT NULL;
+
+COMMIT;
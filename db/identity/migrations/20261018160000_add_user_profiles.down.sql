@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS identity.kyc_tier_limits;
+DROP TABLE IF EXISTS identity.user_profiles;
+
+COMMIT;
@@ -0,0 +1,44 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS identity.user_profiles (
+  user_id             UUID PRIMARY KEY,
+  kyc_tier            INT NOT NULL DEFAULT 0,
+  verification_status TEXT NOT NULL DEFAULT 'UNVERIFIED',
+  document_expires_at TIMESTAMPTZ NULL,
+  frozen              BOOLEAN NOT NULL DEFAULT false,
+  frozen_reason       TEXT NULL,
+  updated_by          TEXT NOT NULL,
+  created_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at          TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT ck_user_profiles_tier CHECK (kyc_tier BETWEEN 0 AND 3),
+  CONSTRAINT ck_user_profiles_status
+    CHECK (verification_status IN ('UNVERIFIED', 'PENDING', 'VERIFIED', 'REJECTED')),
+  CONSTRAINT ck_user_profiles_frozen_reason CHECK (NOT frozen OR frozen_reason IS NOT NULL)
+);
+
+-- Largest single withdrawal each KYC tier may make per asset. A tier with no row for an asset may
+-- not withdraw it at all.
+CREATE TABLE IF NOT EXISTS identity.kyc_tier_limits (
+  kyc_tier         INT NOT NULL,
+  asset            TEXT NOT NULL,
+  max_amount_minor BIGINT NOT NULL,
+  PRIMARY KEY (kyc_tier, asset),
+
+  CONSTRAINT ck_kyc_tier_limits_amount CHECK (max_amount_minor > 0)
+);
+
+INSERT INTO identity.kyc_tier_limits (kyc_tier, asset, max_amount_minor)
+VALUES
+  (1, 'BTC',  10000000),
+  (1, 'ETH',  1000000000000000000),
+  (1, 'USDC', 1000000000),
+  (2, 'BTC',  100000000),
+  (2, 'ETH',  5000000000000000000),
+  (2, 'USDC', 100000000000),
+  (3, 'BTC',  10000000000),
+  (3, 'ETH',  5000000000000000000),
+  (3, 'USDC', 1000000000000)
+ON CONFLICT (kyc_tier, asset) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP INDEX IF EXISTS identity.idx_identity_pending_created;
+
+ALTER TABLE identity.verifications
+  DROP COLUMN IF EXISTS decided_at,
+  DROP COLUMN IF EXISTS traceparent,
+  DROP COLUMN IF EXISTS trace_id,
+  DROP COLUMN IF EXISTS provider;
+
+COMMIT;
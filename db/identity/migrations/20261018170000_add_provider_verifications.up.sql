@@ -0,0 +1,19 @@
+BEGIN;
+
+-- Verifications handed to an external KYC provider stay PENDING until its callback arrives.
+-- trace_id and traceparent are kept so the completion event joins the original trace.
+ALTER TABLE identity.verifications
+  ADD COLUMN IF NOT EXISTS provider    TEXT NULL,
+  ADD COLUMN IF NOT EXISTS trace_id    TEXT NULL,
+  ADD COLUMN IF NOT EXISTS traceparent TEXT NULL,
+  ADD COLUMN IF NOT EXISTS decided_at  TIMESTAMPTZ NULL;
+
+UPDATE identity.verifications
+SET decided_at = created_at
+WHERE decided_at IS NULL AND status IN ('VERIFIED', 'REJECTED');
+
+CREATE INDEX IF NOT EXISTS idx_identity_pending_created
+  ON identity.verifications (created_at ASC)
+  WHERE status = 'PENDING';
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS identity.verification_cache;
+
+ALTER TABLE identity.verifications
+  DROP COLUMN IF EXISTS reused_from,
+  DROP COLUMN IF EXISTS profile_updated_at;
+
+COMMIT;
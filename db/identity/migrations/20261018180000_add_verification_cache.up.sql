@@ -0,0 +1,20 @@
+BEGIN;
+
+-- profile_updated_at is the profile version a provider check was made against; reused_from
+-- points at the verification whose result a cached decision reused.
+ALTER TABLE identity.verifications
+  ADD COLUMN IF NOT EXISTS profile_updated_at TIMESTAMPTZ NULL,
+  ADD COLUMN IF NOT EXISTS reused_from        UUID NULL;
+
+-- The latest successful provider verification per user. It is reused only while it has not
+-- expired and the profile is still at profile_updated_at.
+CREATE TABLE IF NOT EXISTS identity.verification_cache (
+  user_id            UUID PRIMARY KEY,
+  verification_id    UUID NOT NULL REFERENCES identity.verifications (verification_id),
+  provider           TEXT NOT NULL,
+  profile_updated_at TIMESTAMPTZ NOT NULL,
+  verified_at        TIMESTAMPTZ NOT NULL,
+  expires_at         TIMESTAMPTZ NOT NULL
+);
+
+COMMIT;
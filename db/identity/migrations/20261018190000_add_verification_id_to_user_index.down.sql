@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP INDEX IF EXISTS identity.idx_identity_user_created;
+
+CREATE INDEX IF NOT EXISTS idx_identity_user_created
+  ON identity.verifications (user_id, created_at DESC);
+
+COMMIT;
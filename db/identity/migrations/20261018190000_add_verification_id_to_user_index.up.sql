@@ -0,0 +1,10 @@
+BEGIN;
+
+-- ListUserVerifications pages by (created_at, verification_id); the id breaks ties so a page
+-- boundary never skips or repeats a row.
+DROP INDEX IF EXISTS identity.idx_identity_user_created;
+
+CREATE INDEX IF NOT EXISTS idx_identity_user_created
+  ON identity.verifications (user_id, created_at DESC, verification_id DESC);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE orchestrator.outbox_events
+  DROP COLUMN IF EXISTS traceparent;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE orchestrator.withdrawals
+  DROP COLUMN IF EXISTS requested_by;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE orchestrator.withdrawals
+  ADD COLUMN IF NOT EXISTS requested_by TEXT NULL;
+
+COMMIT;
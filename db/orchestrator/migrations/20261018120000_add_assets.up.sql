@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS orchestrator.assets (
+  asset            TEXT PRIMARY KEY,
+  network          TEXT NOT NULL,
+  decimals         INT NOT NULL,
+  min_amount_minor BIGINT NOT NULL,
+  max_amount_minor BIGINT NOT NULL,
+  status           TEXT NOT NULL DEFAULT 'PAUSED',
+  created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT ck_assets_decimals CHECK (decimals BETWEEN 0 AND 18),
+  CONSTRAINT ck_assets_amount_range
+    CHECK (min_amount_minor > 0 AND max_amount_minor >= min_amount_minor),
+  CONSTRAINT ck_assets_status CHECK (status IN ('ENABLED', 'PAUSED'))
+);
+
+INSERT INTO orchestrator.assets (
+  asset,
+  network,
+  decimals,
+  min_amount_minor,
+  max_amount_minor,
+  status
+)
+VALUES
+  ('BTC',  'bitcoin',  8,  10000,            10000000000,         'ENABLED'),
+  ('ETH',  'ethereum', 18, 1000000000000000, 5000000000000000000, 'ENABLED'),
+  ('USDC', 'ethereum', 6,  1000000,          1000000000000,       'ENABLED')
+ON CONFLICT (asset) DO NOTHING;
+
+COMMIT;
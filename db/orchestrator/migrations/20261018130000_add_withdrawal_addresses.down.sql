@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS orchestrator.withdrawal_addresses;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS orchestrator.withdrawal_addresses (
+  id         UUID PRIMARY KEY,
+  user_id    UUID NOT NULL,
+  network    TEXT NOT NULL,
+  address    TEXT NOT NULL, -- canonical form
+  label      TEXT NOT NULL DEFAULT '',
+  usable_at  TIMESTAMPTZ NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  removed_at TIMESTAMPTZ NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_withdrawal_addresses_active
+  ON orchestrator.withdrawal_addresses (user_id, network, address)
+  WHERE removed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_withdrawal_addresses_user
+  ON orchestrator.withdrawal_addresses (user_id, created_at DESC);
+
+COMMIT;
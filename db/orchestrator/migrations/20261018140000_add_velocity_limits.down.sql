@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS orchestrator.user_velocity_tiers;
+DROP TABLE IF EXISTS orchestrator.velocity_limits;
+
+COMMIT;
@@ -0,0 +1,37 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS orchestrator.velocity_limits (
+  tier             TEXT NOT NULL,
+  asset            TEXT NOT NULL,
+  window_seconds   INT NOT NULL CHECK (window_seconds > 0),
+  max_amount_minor BIGINT NOT NULL CHECK (max_amount_minor > 0),
+  created_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at       TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (tier, asset, window_seconds)
+);
+
+-- Users without a row use the configured default tier.
+CREATE TABLE IF NOT EXISTS orchestrator.user_velocity_tiers (
+  user_id    UUID PRIMARY KEY,
+  tier       TEXT NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO orchestrator.velocity_limits (tier, asset, window_seconds, max_amount_minor)
+VALUES
+  ('standard', 'BTC',  86400,  100000000),
+  ('standard', 'BTC',  604800, 500000000),
+  ('standard', 'ETH',  86400,  2000000000000000000),
+  ('standard', 'ETH',  604800, 5000000000000000000),
+  ('standard', 'USDC', 86400,  100000000000),
+  ('standard', 'USDC', 604800, 500000000000),
+  ('elevated', 'BTC',  86400,  1000000000),
+  ('elevated', 'BTC',  604800, 5000000000),
+  ('elevated', 'ETH',  86400,  5000000000000000000),
+  ('elevated', 'ETH',  604800, 9000000000000000000),
+  ('elevated', 'USDC', 86400,  1000000000000),
+  ('elevated', 'USDC', 604800, 5000000000000)
+ON CONFLICT (tier, asset, window_seconds) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,29 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS orchestrator.manual_reviews (
+  withdrawal_id   UUID PRIMARY KEY
+                   REFERENCES orchestrator.withdrawals(id)
+                   ON DELETE CASCADE,
+  status          TEXT NOT NULL, -- PENDING | CLAIMED | APPROVED | REJECTED | EXPIRED | STALE
+  risk_score      INT NOT NULL,
+  risk_reason     TEXT NULL,
+  triggered_rules JSONB NOT NULL DEFAULT '[]'::jsonb,
+  rules_version   TEXT NOT NULL,
+  claimed_by      TEXT NULL,
+  claimed_at      TIMESTAMPTZ NULL,
+  decided_by      TEXT NULL,
+  decided_at      TIMESTAMPTZ NULL,
+  notes           TEXT NULL,
+  due_at          TIMESTAMPTZ NOT NULL,
+  created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_manual_reviews_open_due
+  ON orchestrator.manual_reviews (due_at ASC, withdrawal_id)
+  WHERE status IN ('PENDING', 'CLAIMED');
+
+CREATE INDEX IF NOT EXISTS idx_manual_reviews_status_created
+  ON orchestrator.manual_reviews (status, created_at DESC);
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS orchestrator.auth_challenges;
+
+ALTER TABLE orchestrator.assets
+  DROP CONSTRAINT IF EXISTS ck_assets_challenge_threshold,
+  DROP COLUMN IF EXISTS challenge_threshold_minor;
+
+COMMIT;
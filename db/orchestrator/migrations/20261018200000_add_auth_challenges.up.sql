@@ -0,0 +1,39 @@
+BEGIN;
+
+-- Withdrawals of at least challenge_threshold_minor must be confirmed by the user with a
+-- one-time code before execution. 0 never challenges.
+ALTER TABLE orchestrator.assets
+  ADD COLUMN IF NOT EXISTS challenge_threshold_minor BIGINT NOT NULL DEFAULT 0,
+  ADD CONSTRAINT ck_assets_challenge_threshold CHECK (challenge_threshold_minor >= 0);
+
+UPDATE orchestrator.assets
+SET challenge_threshold_minor = CASE asset
+  WHEN 'BTC'  THEN 100000000
+  WHEN 'ETH'  THEN 1000000000000000000
+  WHEN 'USDC' THEN 10000000000
+END
+WHERE asset IN ('BTC', 'ETH', 'USDC') AND challenge_threshold_minor = 0;
+
+CREATE TABLE IF NOT EXISTS orchestrator.auth_challenges (
+  withdrawal_id UUID PRIMARY KEY
+                REFERENCES orchestrator.withdrawals(id)
+                ON DELETE CASCADE,
+  code_hash     TEXT NOT NULL, -- <salt hex>:<sha256(salt || code) hex>
+  status        TEXT NOT NULL, -- PENDING | CONFIRMED | FAILED | EXPIRED
+  attempts      INT NOT NULL DEFAULT 0,
+  max_attempts  INT NOT NULL,
+  expires_at    TIMESTAMPTZ NOT NULL,
+  decided_at    TIMESTAMPTZ NULL,
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT ck_auth_challenges_status
+    CHECK (status IN ('PENDING', 'CONFIRMED', 'FAILED', 'EXPIRED')),
+  CONSTRAINT ck_auth_challenges_attempts CHECK (max_attempts > 0 AND attempts >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_challenges_pending_expires
+  ON orchestrator.auth_challenges (expires_at ASC, withdrawal_id)
+  WHERE status = 'PENDING';
+
+COMMIT;
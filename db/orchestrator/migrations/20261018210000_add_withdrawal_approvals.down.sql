@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS orchestrator.approval_votes;
+DROP TABLE IF EXISTS orchestrator.approval_requests;
+DROP TABLE IF EXISTS orchestrator.approval_policies;
+
+COMMIT;
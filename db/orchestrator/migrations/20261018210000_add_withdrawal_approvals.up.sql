@@ -0,0 +1,71 @@
+BEGIN;
+
+-- A withdrawal of at least min_amount_minor of asset needs required_approvals distinct
+-- operators holding approver_role. The policy with the highest matching threshold applies.
+CREATE TABLE IF NOT EXISTS orchestrator.approval_policies (
+  policy_id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  asset              TEXT NOT NULL
+                     REFERENCES orchestrator.assets(asset)
+                     ON DELETE CASCADE,
+  min_amount_minor   BIGINT NOT NULL,
+  required_approvals INT NOT NULL,
+  approver_role      TEXT NOT NULL,
+  updated_by         TEXT NOT NULL,
+  created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT ux_approval_policies_asset_amount UNIQUE (asset, min_amount_minor),
+  CONSTRAINT ck_approval_policies_amount CHECK (min_amount_minor > 0),
+  CONSTRAINT ck_approval_policies_required CHECK (required_approvals BETWEEN 1 AND 10)
+);
+
+INSERT INTO orchestrator.approval_policies (
+  asset,
+  min_amount_minor,
+  required_approvals,
+  approver_role,
+  updated_by
+)
+VALUES
+  ('BTC',  1000000000,          2, 'treasury_approver', 'migration'),
+  ('ETH',  2000000000000000000, 2, 'treasury_approver', 'migration'),
+  ('USDC', 100000000000,        2, 'treasury_approver', 'migration')
+ON CONFLICT (asset, min_amount_minor) DO NOTHING;
+
+-- One row per withdrawal in APPROVAL. The policy is copied so later policy edits do not change
+-- what an open request needs.
+CREATE TABLE IF NOT EXISTS orchestrator.approval_requests (
+  withdrawal_id      UUID PRIMARY KEY
+                     REFERENCES orchestrator.withdrawals(id)
+                     ON DELETE CASCADE,
+  policy_id          UUID NOT NULL,
+  required_approvals INT NOT NULL,
+  approver_role      TEXT NOT NULL,
+  status             TEXT NOT NULL, -- PENDING | APPROVED | REJECTED
+  decided_at         TIMESTAMPTZ NULL,
+  created_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  CONSTRAINT ck_approval_requests_status CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_approval_requests_pending_created
+  ON orchestrator.approval_requests (created_at ASC, withdrawal_id)
+  WHERE status = 'PENDING';
+
+-- The audit trail: every operator's vote, with the roles they held when they cast it.
+CREATE TABLE IF NOT EXISTS orchestrator.approval_votes (
+  withdrawal_id UUID NOT NULL
+                REFERENCES orchestrator.approval_requests(withdrawal_id)
+                ON DELETE CASCADE,
+  approver      TEXT NOT NULL,
+  decision      TEXT NOT NULL, -- APPROVE | REJECT
+  roles         TEXT[] NOT NULL,
+  notes         TEXT NULL,
+  created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+  PRIMARY KEY (withdrawal_id, approver),
+  CONSTRAINT ck_approval_votes_decision CHECK (decision IN ('APPROVE', 'REJECT'))
+);
+
+COMMIT;
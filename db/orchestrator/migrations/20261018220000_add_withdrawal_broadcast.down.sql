@@ -0,0 +1,8 @@
+BEGIN;
+
+ALTER TABLE orchestrator.withdrawals
+  DROP COLUMN IF EXISTS broadcast_at,
+  DROP COLUMN IF EXISTS from_addr,
+  DROP COLUMN IF EXISTS tx_hash;
+
+COMMIT;
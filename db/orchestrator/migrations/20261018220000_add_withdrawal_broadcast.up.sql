@@ -0,0 +1,9 @@
+BEGIN;
+
+-- Set when the execution service reports the withdrawal's transaction as broadcast.
+ALTER TABLE orchestrator.withdrawals
+  ADD COLUMN IF NOT EXISTS tx_hash TEXT NULL,
+  ADD COLUMN IF NOT EXISTS from_addr TEXT NULL,
+  ADD COLUMN IF NOT EXISTS broadcast_at TIMESTAMPTZ NULL;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE orchestrator.withdrawals
+  DROP COLUMN IF EXISTS confirmations,
+  DROP COLUMN IF EXISTS block_hash,
+  DROP COLUMN IF EXISTS block_height,
+  DROP COLUMN IF EXISTS rebroadcasts;
+
+ALTER TABLE orchestrator.assets
+  DROP CONSTRAINT IF EXISTS ck_assets_confirmations,
+  DROP COLUMN IF EXISTS confirmations;
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+-- A withdrawal's transaction is final once it is this many blocks deep.
+ALTER TABLE orchestrator.assets
+  ADD COLUMN IF NOT EXISTS confirmations BIGINT NOT NULL DEFAULT 1,
+  ADD CONSTRAINT ck_assets_confirmations CHECK (confirmations > 0);
+
+UPDATE orchestrator.assets
+SET confirmations = CASE asset
+  WHEN 'BTC'  THEN 6
+  WHEN 'ETH'  THEN 12
+  WHEN 'USDC' THEN 12
+END
+WHERE asset IN ('BTC', 'ETH', 'USDC') AND confirmations = 1;
+
+-- rebroadcasts counts how often the transaction was sent again after leaving the chain. The
+-- block columns are set once the execution service reports it confirmed.
+ALTER TABLE orchestrator.withdrawals
+  ADD COLUMN IF NOT EXISTS rebroadcasts INT NOT NULL DEFAULT 0,
+  ADD COLUMN IF NOT EXISTS block_height BIGINT NULL,
+  ADD COLUMN IF NOT EXISTS block_hash TEXT NULL,
+  ADD COLUMN IF NOT EXISTS confirmations BIGINT NULL;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE orchestrator.withdrawals
+  DROP COLUMN IF EXISTS completed_at;
+
+COMMIT;
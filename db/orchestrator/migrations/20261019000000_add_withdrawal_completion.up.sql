@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Set when the withdrawal's transaction reaches its confirmation depth and the withdrawal
+-- becomes COMPLETED.
+ALTER TABLE orchestrator.withdrawals
+  ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ NULL;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+UPDATE orchestrator.auth_challenges
+SET status = 'EXPIRED'
+WHERE status = 'STALE';
+
+ALTER TABLE orchestrator.auth_challenges
+  DROP CONSTRAINT IF EXISTS ck_auth_challenges_status;
+
+ALTER TABLE orchestrator.auth_challenges
+  ADD CONSTRAINT ck_auth_challenges_status
+    CHECK (status IN ('PENDING', 'CONFIRMED', 'FAILED', 'EXPIRED'));
+
+COMMIT;
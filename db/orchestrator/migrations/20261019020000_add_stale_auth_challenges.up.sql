@@ -0,0 +1,11 @@
+BEGIN;
+
+-- STALE closes a challenge whose saga left AUTH_CHALLENGE without it.
+ALTER TABLE orchestrator.auth_challenges
+  DROP CONSTRAINT IF EXISTS ck_auth_challenges_status;
+
+ALTER TABLE orchestrator.auth_challenges
+  ADD CONSTRAINT ck_auth_challenges_status
+    CHECK (status IN ('PENDING', 'CONFIRMED', 'FAILED', 'EXPIRED', 'STALE'));
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+-- The scrubbed codes cannot be restored.
+
+COMMIT;
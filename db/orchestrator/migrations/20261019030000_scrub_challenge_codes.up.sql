@@ -0,0 +1,11 @@
+BEGIN;
+
+-- Challenge events used to carry the one-time code in plain text; outbox rows are kept, so
+-- drop it from the ones already written. New events carry it sealed.
+UPDATE orchestrator.outbox_events
+SET payload_json = (payload_json::jsonb - 'code')::text
+WHERE
+  event_type = 'WithdrawalChallengeIssued'
+  AND payload_json::jsonb ? 'code';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE orchestrator.manual_reviews
+  DROP CONSTRAINT IF EXISTS ck_manual_reviews_status;
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+-- STALE closes a review whose saga left MANUAL_REVIEW without it.
+ALTER TABLE orchestrator.manual_reviews
+  DROP CONSTRAINT IF EXISTS ck_manual_reviews_status;
+
+ALTER TABLE orchestrator.manual_reviews
+  ADD CONSTRAINT ck_manual_reviews_status
+    CHECK (status IN ('PENDING', 'CLAIMED', 'APPROVED', 'REJECTED', 'EXPIRED', 'STALE'));
+
+COMMIT;
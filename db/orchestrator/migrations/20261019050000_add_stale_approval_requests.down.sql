@@ -0,0 +1,14 @@
+BEGIN;
+
+UPDATE orchestrator.approval_requests
+SET status = 'REJECTED'
+WHERE status = 'STALE';
+
+ALTER TABLE orchestrator.approval_requests
+  DROP CONSTRAINT IF EXISTS ck_approval_requests_status;
+
+ALTER TABLE orchestrator.approval_requests
+  ADD CONSTRAINT ck_approval_requests_status
+    CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED'));
+
+COMMIT;
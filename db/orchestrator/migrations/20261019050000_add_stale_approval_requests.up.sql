@@ -0,0 +1,11 @@
+BEGIN;
+
+-- STALE closes an approval request whose saga left APPROVAL without it.
+ALTER TABLE orchestrator.approval_requests
+  DROP CONSTRAINT IF EXISTS ck_approval_requests_status;
+
+ALTER TABLE orchestrator.approval_requests
+  ADD CONSTRAINT ck_approval_requests_status
+    CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'STALE'));
+
+COMMIT;
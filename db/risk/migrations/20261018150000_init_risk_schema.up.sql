@@ -0,0 +1,53 @@
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE SCHEMA IF NOT EXISTS risk;
+
+CREATE TABLE IF NOT EXISTS risk.decisions (
+  decision_id      UUID PRIMARY KEY,
+  withdrawal_id    UUID NOT NULL,
+  user_id          UUID NOT NULL,
+  asset            TEXT NOT NULL,
+  amount_minor     BIGINT NOT NULL,
+  destination_addr TEXT NOT NULL,
+  score            INT NOT NULL,
+  decision         TEXT NOT NULL,  -- APPROVED | REVIEW | REJECTED
+  triggered_rules  JSONB NOT NULL DEFAULT '[]'::jsonb,
+  rules_version    TEXT NOT NULL,
+  reason           TEXT NULL,
+  created_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_risk_withdrawal
+  ON risk.decisions (withdrawal_id);
+
+CREATE INDEX IF NOT EXISTS idx_risk_user_created
+  ON risk.decisions (user_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_risk_user_destination
+  ON risk.decisions (user_id, destination_addr)
+  WHERE decision = 'APPROVED';
+
+CREATE TABLE IF NOT EXISTS risk.outbox_events (
+  event_id       UUID PRIMARY KEY,
+  aggregate_type TEXT NOT NULL,  -- "risk"
+  aggregate_id   UUID NOT NULL,  -- withdrawal_id
+  event_type     TEXT NOT NULL,  -- RiskCheckApproved | RiskCheckReview | RiskCheckRejected
+  payload_json   TEXT NOT NULL,
+  trace_id       TEXT NULL,
+  route_key      TEXT NOT NULL DEFAULT 'evt.risk',
+  traceparent    TEXT NULL,
+  created_at     TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_risk_outbox_created
+  ON risk.outbox_events (created_at ASC);
+
+CREATE INDEX IF NOT EXISTS idx_risk_outbox_aggregate
+  ON risk.outbox_events (aggregate_type, aggregate_id);
+
+CREATE INDEX IF NOT EXISTS idx_risk_outbox_route_created
+  ON risk.outbox_events (route_key, created_at ASC);
+
+COMMIT;
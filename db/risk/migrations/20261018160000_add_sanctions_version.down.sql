@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE risk.decisions
+  DROP COLUMN IF EXISTS sanctions_version;
+
+COMMIT;
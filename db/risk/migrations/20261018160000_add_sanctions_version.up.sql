@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Digest of the sanctions denylists a request was screened against. Empty for decisions made
+-- before screening existed.
+ALTER TABLE risk.decisions
+  ADD COLUMN IF NOT EXISTS sanctions_version TEXT NOT NULL DEFAULT '';
+
+COMMIT;
@@ -0,0 +1,504 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: orchestrator/v1/address_book.proto
+
+package orchestratorv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WithdrawalAddress struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AddressId string                 `protobuf:"bytes,1,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	UserId    string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Network   string                 `protobuf:"bytes,3,opt,name=network,proto3" json:"network,omitempty"`
+	// Canonical form of the address.
+	Address string `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Label   string `protobuf:"bytes,5,opt,name=label,proto3" json:"label,omitempty"`
+	// Withdrawals to this address are accepted from this time on.
+	UsableAt      string `protobuf:"bytes,6,opt,name=usable_at,json=usableAt,proto3" json:"usable_at,omitempty"`
+	CreatedAt     string `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WithdrawalAddress) Reset() {
+	*x = WithdrawalAddress{}
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WithdrawalAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WithdrawalAddress) ProtoMessage() {}
+
+func (x *WithdrawalAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WithdrawalAddress.ProtoReflect.Descriptor instead.
+func (*WithdrawalAddress) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_address_book_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WithdrawalAddress) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+func (x *WithdrawalAddress) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *WithdrawalAddress) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *WithdrawalAddress) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *WithdrawalAddress) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *WithdrawalAddress) GetUsableAt() string {
+	if x != nil {
+		return x.UsableAt
+	}
+	return ""
+}
+
+func (x *WithdrawalAddress) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type AddWithdrawalAddressRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Selects the network the address is validated for.
+	Asset         string `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	Address       string `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Label         string `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWithdrawalAddressRequest) Reset() {
+	*x = AddWithdrawalAddressRequest{}
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWithdrawalAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWithdrawalAddressRequest) ProtoMessage() {}
+
+func (x *AddWithdrawalAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWithdrawalAddressRequest.ProtoReflect.Descriptor instead.
+func (*AddWithdrawalAddressRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_address_book_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AddWithdrawalAddressRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddWithdrawalAddressRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *AddWithdrawalAddressRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AddWithdrawalAddressRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type AddWithdrawalAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       *WithdrawalAddress     `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddWithdrawalAddressResponse) Reset() {
+	*x = AddWithdrawalAddressResponse{}
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddWithdrawalAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddWithdrawalAddressResponse) ProtoMessage() {}
+
+func (x *AddWithdrawalAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddWithdrawalAddressResponse.ProtoReflect.Descriptor instead.
+func (*AddWithdrawalAddressResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_address_book_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AddWithdrawalAddressResponse) GetAddress() *WithdrawalAddress {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type ListWithdrawalAddressesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWithdrawalAddressesRequest) Reset() {
+	*x = ListWithdrawalAddressesRequest{}
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWithdrawalAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWithdrawalAddressesRequest) ProtoMessage() {}
+
+func (x *ListWithdrawalAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWithdrawalAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ListWithdrawalAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_address_book_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListWithdrawalAddressesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListWithdrawalAddressesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Addresses     []*WithdrawalAddress   `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWithdrawalAddressesResponse) Reset() {
+	*x = ListWithdrawalAddressesResponse{}
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWithdrawalAddressesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWithdrawalAddressesResponse) ProtoMessage() {}
+
+func (x *ListWithdrawalAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWithdrawalAddressesResponse.ProtoReflect.Descriptor instead.
+func (*ListWithdrawalAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_address_book_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListWithdrawalAddressesResponse) GetAddresses() []*WithdrawalAddress {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type RemoveWithdrawalAddressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AddressId     string                 `protobuf:"bytes,2,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWithdrawalAddressRequest) Reset() {
+	*x = RemoveWithdrawalAddressRequest{}
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWithdrawalAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWithdrawalAddressRequest) ProtoMessage() {}
+
+func (x *RemoveWithdrawalAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWithdrawalAddressRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWithdrawalAddressRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_address_book_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RemoveWithdrawalAddressRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveWithdrawalAddressRequest) GetAddressId() string {
+	if x != nil {
+		return x.AddressId
+	}
+	return ""
+}
+
+type RemoveWithdrawalAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveWithdrawalAddressResponse) Reset() {
+	*x = RemoveWithdrawalAddressResponse{}
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveWithdrawalAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveWithdrawalAddressResponse) ProtoMessage() {}
+
+func (x *RemoveWithdrawalAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_address_book_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveWithdrawalAddressResponse.ProtoReflect.Descriptor instead.
+func (*RemoveWithdrawalAddressResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_address_book_proto_rawDescGZIP(), []int{6}
+}
+
+var File_orchestrator_v1_address_book_proto protoreflect.FileDescriptor
+
+const file_orchestrator_v1_address_book_proto_rawDesc = "" +
+	"\n" +
+	"\"orchestrator/v1/address_book.proto\x12\x16cbsaga.orchestrator.v1\x1a\x1cgoogle/api/annotations.proto\"\xd1\x01\n" +
+	"\x11WithdrawalAddress\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x01 \x01(\tR\taddressId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\anetwork\x18\x03 \x01(\tR\anetwork\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05label\x18\x05 \x01(\tR\x05label\x12\x1b\n" +
+	"\tusable_at\x18\x06 \x01(\tR\busableAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"|\n" +
+	"\x1bAddWithdrawalAddressRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05asset\x18\x02 \x01(\tR\x05asset\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05label\x18\x04 \x01(\tR\x05label\"c\n" +
+	"\x1cAddWithdrawalAddressResponse\x12C\n" +
+	"\aaddress\x18\x01 \x01(\v2).cbsaga.orchestrator.v1.WithdrawalAddressR\aaddress\"9\n" +
+	"\x1eListWithdrawalAddressesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"j\n" +
+	"\x1fListWithdrawalAddressesResponse\x12G\n" +
+	"\taddresses\x18\x01 \x03(\v2).cbsaga.orchestrator.v1.WithdrawalAddressR\taddresses\"X\n" +
+	"\x1eRemoveWithdrawalAddressRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x02 \x01(\tR\taddressId\"!\n" +
+	"\x1fRemoveWithdrawalAddressResponse2\xd8\x04\n" +
+	"\x12AddressBookService\x12\xb6\x01\n" +
+	"\x14AddWithdrawalAddress\x123.cbsaga.orchestrator.v1.AddWithdrawalAddressRequest\x1a4.cbsaga.orchestrator.v1.AddWithdrawalAddressResponse\"3\x82\xd3\xe4\x93\x02-:\x01*\"(/v1/users/{user_id}/withdrawal-addresses\x12\xbc\x01\n" +
+	"\x17ListWithdrawalAddresses\x126.cbsaga.orchestrator.v1.ListWithdrawalAddressesRequest\x1a7.cbsaga.orchestrator.v1.ListWithdrawalAddressesResponse\"0\x82\xd3\xe4\x93\x02*\x12(/v1/users/{user_id}/withdrawal-addresses\x12\xc9\x01\n" +
+	"\x17RemoveWithdrawalAddress\x126.cbsaga.orchestrator.v1.RemoveWithdrawalAddressRequest\x1a7.cbsaga.orchestrator.v1.RemoveWithdrawalAddressResponse\"=\x82\xd3\xe4\x93\x027*5/v1/users/{user_id}/withdrawal-addresses/{address_id}B?Z=github.com/cicconee/cbsaga/gen/orchestrator/v1;orchestratorv1b\x06proto3"
+
+var (
+	file_orchestrator_v1_address_book_proto_rawDescOnce sync.Once
+	file_orchestrator_v1_address_book_proto_rawDescData []byte
+)
+
+func file_orchestrator_v1_address_book_proto_rawDescGZIP() []byte {
+	file_orchestrator_v1_address_book_proto_rawDescOnce.Do(func() {
+		file_orchestrator_v1_address_book_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_orchestrator_v1_address_book_proto_rawDesc), len(file_orchestrator_v1_address_book_proto_rawDesc)))
+	})
+	return file_orchestrator_v1_address_book_proto_rawDescData
+}
+
+var file_orchestrator_v1_address_book_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_orchestrator_v1_address_book_proto_goTypes = []any{
+	(*WithdrawalAddress)(nil),               // 0: cbsaga.orchestrator.v1.WithdrawalAddress
+	(*AddWithdrawalAddressRequest)(nil),     // 1: cbsaga.orchestrator.v1.AddWithdrawalAddressRequest
+	(*AddWithdrawalAddressResponse)(nil),    // 2: cbsaga.orchestrator.v1.AddWithdrawalAddressResponse
+	(*ListWithdrawalAddressesRequest)(nil),  // 3: cbsaga.orchestrator.v1.ListWithdrawalAddressesRequest
+	(*ListWithdrawalAddressesResponse)(nil), // 4: cbsaga.orchestrator.v1.ListWithdrawalAddressesResponse
+	(*RemoveWithdrawalAddressRequest)(nil),  // 5: cbsaga.orchestrator.v1.RemoveWithdrawalAddressRequest
+	(*RemoveWithdrawalAddressResponse)(nil), // 6: cbsaga.orchestrator.v1.RemoveWithdrawalAddressResponse
+}
+var file_orchestrator_v1_address_book_proto_depIdxs = []int32{
+	0, // 0: cbsaga.orchestrator.v1.AddWithdrawalAddressResponse.address:type_name -> cbsaga.orchestrator.v1.WithdrawalAddress
+	0, // 1: cbsaga.orchestrator.v1.ListWithdrawalAddressesResponse.addresses:type_name -> cbsaga.orchestrator.v1.WithdrawalAddress
+	1, // 2: cbsaga.orchestrator.v1.AddressBookService.AddWithdrawalAddress:input_type -> cbsaga.orchestrator.v1.AddWithdrawalAddressRequest
+	3, // 3: cbsaga.orchestrator.v1.AddressBookService.ListWithdrawalAddresses:input_type -> cbsaga.orchestrator.v1.ListWithdrawalAddressesRequest
+	5, // 4: cbsaga.orchestrator.v1.AddressBookService.RemoveWithdrawalAddress:input_type -> cbsaga.orchestrator.v1.RemoveWithdrawalAddressRequest
+	2, // 5: cbsaga.orchestrator.v1.AddressBookService.AddWithdrawalAddress:output_type -> cbsaga.orchestrator.v1.AddWithdrawalAddressResponse
+	4, // 6: cbsaga.orchestrator.v1.AddressBookService.ListWithdrawalAddresses:output_type -> cbsaga.orchestrator.v1.ListWithdrawalAddressesResponse
+	6, // 7: cbsaga.orchestrator.v1.AddressBookService.RemoveWithdrawalAddress:output_type -> cbsaga.orchestrator.v1.RemoveWithdrawalAddressResponse
+	5, // [5:8] is the sub-list for method output_type
+	2, // [2:5] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_orchestrator_v1_address_book_proto_init() }
+func file_orchestrator_v1_address_book_proto_init() {
+	if File_orchestrator_v1_address_book_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orchestrator_v1_address_book_proto_rawDesc), len(file_orchestrator_v1_address_book_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_orchestrator_v1_address_book_proto_goTypes,
+		DependencyIndexes: file_orchestrator_v1_address_book_proto_depIdxs,
+		MessageInfos:      file_orchestrator_v1_address_book_proto_msgTypes,
+	}.Build()
+	File_orchestrator_v1_address_book_proto = out.File
+	file_orchestrator_v1_address_book_proto_goTypes = nil
+	file_orchestrator_v1_address_book_proto_depIdxs = nil
+}
@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.2
+// source: orchestrator/v1/address_book.proto
+
+package orchestratorv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AddressBookService_AddWithdrawalAddress_FullMethodName    = "/cbsaga.orchestrator.v1.AddressBookService/AddWithdrawalAddress"
+	AddressBookService_ListWithdrawalAddresses_FullMethodName = "/cbsaga.orchestrator.v1.AddressBookService/ListWithdrawalAddresses"
+	AddressBookService_RemoveWithdrawalAddress_FullMethodName = "/cbsaga.orchestrator.v1.AddressBookService/RemoveWithdrawalAddress"
+)
+
+// AddressBookServiceClient is the client API for AddressBookService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AddressBookService manages the destinations a user may withdraw to. Users manage their own
+// book; admin and support may list and remove entries of any user.
+type AddressBookServiceClient interface {
+	AddWithdrawalAddress(ctx context.Context, in *AddWithdrawalAddressRequest, opts ...grpc.CallOption) (*AddWithdrawalAddressResponse, error)
+	ListWithdrawalAddresses(ctx context.Context, in *ListWithdrawalAddressesRequest, opts ...grpc.CallOption) (*ListWithdrawalAddressesResponse, error)
+	RemoveWithdrawalAddress(ctx context.Context, in *RemoveWithdrawalAddressRequest, opts ...grpc.CallOption) (*RemoveWithdrawalAddressResponse, error)
+}
+
+type addressBookServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAddressBookServiceClient(cc grpc.ClientConnInterface) AddressBookServiceClient {
+	return &addressBookServiceClient{cc}
+}
+
+func (c *addressBookServiceClient) AddWithdrawalAddress(ctx context.Context, in *AddWithdrawalAddressRequest, opts ...grpc.CallOption) (*AddWithdrawalAddressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddWithdrawalAddressResponse)
+	err := c.cc.Invoke(ctx, AddressBookService_AddWithdrawalAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressBookServiceClient) ListWithdrawalAddresses(ctx context.Context, in *ListWithdrawalAddressesRequest, opts ...grpc.CallOption) (*ListWithdrawalAddressesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWithdrawalAddressesResponse)
+	err := c.cc.Invoke(ctx, AddressBookService_ListWithdrawalAddresses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *addressBookServiceClient) RemoveWithdrawalAddress(ctx context.Context, in *RemoveWithdrawalAddressRequest, opts ...grpc.CallOption) (*RemoveWithdrawalAddressResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveWithdrawalAddressResponse)
+	err := c.cc.Invoke(ctx, AddressBookService_RemoveWithdrawalAddress_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AddressBookServiceServer is the server API for AddressBookService service.
+// All implementations must embed UnimplementedAddressBookServiceServer
+// for forward compatibility.
+//
+// AddressBookService manages the destinations a user may withdraw to. Users manage their own
+// book; admin and support may list and remove entries of any user.
+type AddressBookServiceServer interface {
+	AddWithdrawalAddress(context.Context, *AddWithdrawalAddressRequest) (*AddWithdrawalAddressResponse, error)
+	ListWithdrawalAddresses(context.Context, *ListWithdrawalAddressesRequest) (*ListWithdrawalAddressesResponse, error)
+	RemoveWithdrawalAddress(context.Context, *RemoveWithdrawalAddressRequest) (*RemoveWithdrawalAddressResponse, error)
+	mustEmbedUnimplementedAddressBookServiceServer()
+}
+
+// UnimplementedAddressBookServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAddressBookServiceServer struct{}
+
+func (UnimplementedAddressBookServiceServer) AddWithdrawalAddress(context.Context, *AddWithdrawalAddressRequest) (*AddWithdrawalAddressResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddWithdrawalAddress not implemented")
+}
+func (UnimplementedAddressBookServiceServer) ListWithdrawalAddresses(context.Context, *ListWithdrawalAddressesRequest) (*ListWithdrawalAddressesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWithdrawalAddresses not implemented")
+}
+func (UnimplementedAddressBookServiceServer) RemoveWithdrawalAddress(context.Context, *RemoveWithdrawalAddressRequest) (*RemoveWithdrawalAddressResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveWithdrawalAddress not implemented")
+}
+func (UnimplementedAddressBookServiceServer) mustEmbedUnimplementedAddressBookServiceServer() {}
+func (UnimplementedAddressBookServiceServer) testEmbeddedByValue()                            {}
+
+// UnsafeAddressBookServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AddressBookServiceServer will
+// result in compilation errors.
+type UnsafeAddressBookServiceServer interface {
+	mustEmbedUnimplementedAddressBookServiceServer()
+}
+
+func RegisterAddressBookServiceServer(s grpc.ServiceRegistrar, srv AddressBookServiceServer) {
+	// If the following call panics, it indicates UnimplementedAddressBookServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AddressBookService_ServiceDesc, srv)
+}
+
+func _AddressBookService_AddWithdrawalAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWithdrawalAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressBookServiceServer).AddWithdrawalAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressBookService_AddWithdrawalAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressBookServiceServer).AddWithdrawalAddress(ctx, req.(*AddWithdrawalAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressBookService_ListWithdrawalAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWithdrawalAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressBookServiceServer).ListWithdrawalAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressBookService_ListWithdrawalAddresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressBookServiceServer).ListWithdrawalAddresses(ctx, req.(*ListWithdrawalAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AddressBookService_RemoveWithdrawalAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWithdrawalAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AddressBookServiceServer).RemoveWithdrawalAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AddressBookService_RemoveWithdrawalAddress_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AddressBookServiceServer).RemoveWithdrawalAddress(ctx, req.(*RemoveWithdrawalAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AddressBookService_ServiceDesc is the grpc.ServiceDesc for AddressBookService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AddressBookService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cbsaga.orchestrator.v1.AddressBookService",
+	HandlerType: (*AddressBookServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddWithdrawalAddress",
+			Handler:    _AddressBookService_AddWithdrawalAddress_Handler,
+		},
+		{
+			MethodName: "ListWithdrawalAddresses",
+			Handler:    _AddressBookService_ListWithdrawalAddresses_Handler,
+		},
+		{
+			MethodName: "RemoveWithdrawalAddress",
+			Handler:    _AddressBookService_RemoveWithdrawalAddress_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "orchestrator/v1/address_book.proto",
+}
@@ -0,0 +1,1151 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: orchestrator/v1/approval.proto
+
+package orchestratorv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ApprovalPolicy struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PolicyId string                 `protobuf:"bytes,1,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
+	Asset    string                 `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
+	// Withdrawals of at least this amount need approval. The highest threshold reached applies.
+	MinAmountMinor    int64  `protobuf:"varint,3,opt,name=min_amount_minor,json=minAmountMinor,proto3" json:"min_amount_minor,omitempty"`
+	RequiredApprovals int32  `protobuf:"varint,4,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	ApproverRole      string `protobuf:"bytes,5,opt,name=approver_role,json=approverRole,proto3" json:"approver_role,omitempty"`
+	UpdatedBy         string `protobuf:"bytes,6,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	CreatedAt         string `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         string `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ApprovalPolicy) Reset() {
+	*x = ApprovalPolicy{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalPolicy) ProtoMessage() {}
+
+func (x *ApprovalPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalPolicy.ProtoReflect.Descriptor instead.
+func (*ApprovalPolicy) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ApprovalPolicy) GetPolicyId() string {
+	if x != nil {
+		return x.PolicyId
+	}
+	return ""
+}
+
+func (x *ApprovalPolicy) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *ApprovalPolicy) GetMinAmountMinor() int64 {
+	if x != nil {
+		return x.MinAmountMinor
+	}
+	return 0
+}
+
+func (x *ApprovalPolicy) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *ApprovalPolicy) GetApproverRole() string {
+	if x != nil {
+		return x.ApproverRole
+	}
+	return ""
+}
+
+func (x *ApprovalPolicy) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *ApprovalPolicy) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ApprovalPolicy) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ApprovalVote struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Approver string                 `protobuf:"bytes,1,opt,name=approver,proto3" json:"approver,omitempty"`
+	// APPROVE or REJECT.
+	Decision string `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	// Roles the approver held when voting.
+	Roles         []string `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	Notes         string   `protobuf:"bytes,4,opt,name=notes,proto3" json:"notes,omitempty"`
+	CreatedAt     string   `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalVote) Reset() {
+	*x = ApprovalVote{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalVote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalVote) ProtoMessage() {}
+
+func (x *ApprovalVote) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalVote.ProtoReflect.Descriptor instead.
+func (*ApprovalVote) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ApprovalVote) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *ApprovalVote) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *ApprovalVote) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *ApprovalVote) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *ApprovalVote) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type Approval struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId      string                 `protobuf:"bytes,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Asset             string                 `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	AmountMinor       int64                  `protobuf:"varint,4,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"`
+	DestinationAddr   string                 `protobuf:"bytes,5,opt,name=destination_addr,json=destinationAddr,proto3" json:"destination_addr,omitempty"`
+	PolicyId          string                 `protobuf:"bytes,6,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
+	RequiredApprovals int32                  `protobuf:"varint,7,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	ApproverRole      string                 `protobuf:"bytes,8,opt,name=approver_role,json=approverRole,proto3" json:"approver_role,omitempty"`
+	// PENDING, APPROVED, REJECTED or STALE.
+	Status        string          `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	Votes         []*ApprovalVote `protobuf:"bytes,10,rep,name=votes,proto3" json:"votes,omitempty"`
+	DecidedAt     string          `protobuf:"bytes,11,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	CreatedAt     string          `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Approval) Reset() {
+	*x = Approval{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Approval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Approval) ProtoMessage() {}
+
+func (x *Approval) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Approval.ProtoReflect.Descriptor instead.
+func (*Approval) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Approval) GetWithdrawalId() string {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return ""
+}
+
+func (x *Approval) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Approval) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Approval) GetAmountMinor() int64 {
+	if x != nil {
+		return x.AmountMinor
+	}
+	return 0
+}
+
+func (x *Approval) GetDestinationAddr() string {
+	if x != nil {
+		return x.DestinationAddr
+	}
+	return ""
+}
+
+func (x *Approval) GetPolicyId() string {
+	if x != nil {
+		return x.PolicyId
+	}
+	return ""
+}
+
+func (x *Approval) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *Approval) GetApproverRole() string {
+	if x != nil {
+		return x.ApproverRole
+	}
+	return ""
+}
+
+func (x *Approval) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Approval) GetVotes() []*ApprovalVote {
+	if x != nil {
+		return x.Votes
+	}
+	return nil
+}
+
+func (x *Approval) GetDecidedAt() string {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return ""
+}
+
+func (x *Approval) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type UpsertApprovalPolicyRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Asset             string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	MinAmountMinor    int64                  `protobuf:"varint,2,opt,name=min_amount_minor,json=minAmountMinor,proto3" json:"min_amount_minor,omitempty"`
+	RequiredApprovals int32                  `protobuf:"varint,3,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	ApproverRole      string                 `protobuf:"bytes,4,opt,name=approver_role,json=approverRole,proto3" json:"approver_role,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpsertApprovalPolicyRequest) Reset() {
+	*x = UpsertApprovalPolicyRequest{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertApprovalPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertApprovalPolicyRequest) ProtoMessage() {}
+
+func (x *UpsertApprovalPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertApprovalPolicyRequest.ProtoReflect.Descriptor instead.
+func (*UpsertApprovalPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpsertApprovalPolicyRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *UpsertApprovalPolicyRequest) GetMinAmountMinor() int64 {
+	if x != nil {
+		return x.MinAmountMinor
+	}
+	return 0
+}
+
+func (x *UpsertApprovalPolicyRequest) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+func (x *UpsertApprovalPolicyRequest) GetApproverRole() string {
+	if x != nil {
+		return x.ApproverRole
+	}
+	return ""
+}
+
+type UpsertApprovalPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *ApprovalPolicy        `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertApprovalPolicyResponse) Reset() {
+	*x = UpsertApprovalPolicyResponse{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertApprovalPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertApprovalPolicyResponse) ProtoMessage() {}
+
+func (x *UpsertApprovalPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertApprovalPolicyResponse.ProtoReflect.Descriptor instead.
+func (*UpsertApprovalPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpsertApprovalPolicyResponse) GetPolicy() *ApprovalPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type ListApprovalPoliciesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApprovalPoliciesRequest) Reset() {
+	*x = ListApprovalPoliciesRequest{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApprovalPoliciesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApprovalPoliciesRequest) ProtoMessage() {}
+
+func (x *ListApprovalPoliciesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApprovalPoliciesRequest.ProtoReflect.Descriptor instead.
+func (*ListApprovalPoliciesRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{5}
+}
+
+type ListApprovalPoliciesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policies      []*ApprovalPolicy      `protobuf:"bytes,1,rep,name=policies,proto3" json:"policies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApprovalPoliciesResponse) Reset() {
+	*x = ListApprovalPoliciesResponse{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApprovalPoliciesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApprovalPoliciesResponse) ProtoMessage() {}
+
+func (x *ListApprovalPoliciesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApprovalPoliciesResponse.ProtoReflect.Descriptor instead.
+func (*ListApprovalPoliciesResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListApprovalPoliciesResponse) GetPolicies() []*ApprovalPolicy {
+	if x != nil {
+		return x.Policies
+	}
+	return nil
+}
+
+type DeleteApprovalPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PolicyId      string                 `protobuf:"bytes,1,opt,name=policy_id,json=policyId,proto3" json:"policy_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApprovalPolicyRequest) Reset() {
+	*x = DeleteApprovalPolicyRequest{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApprovalPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApprovalPolicyRequest) ProtoMessage() {}
+
+func (x *DeleteApprovalPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteApprovalPolicyRequest.ProtoReflect.Descriptor instead.
+func (*DeleteApprovalPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteApprovalPolicyRequest) GetPolicyId() string {
+	if x != nil {
+		return x.PolicyId
+	}
+	return ""
+}
+
+type DeleteApprovalPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *ApprovalPolicy        `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApprovalPolicyResponse) Reset() {
+	*x = DeleteApprovalPolicyResponse{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApprovalPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApprovalPolicyResponse) ProtoMessage() {}
+
+func (x *DeleteApprovalPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteApprovalPolicyResponse.ProtoReflect.Descriptor instead.
+func (*DeleteApprovalPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteApprovalPolicyResponse) GetPolicy() *ApprovalPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type ListPendingApprovalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingApprovalsRequest) Reset() {
+	*x = ListPendingApprovalsRequest{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingApprovalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingApprovalsRequest) ProtoMessage() {}
+
+func (x *ListPendingApprovalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingApprovalsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingApprovalsRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListPendingApprovalsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListPendingApprovalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approvals     []*Approval            `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingApprovalsResponse) Reset() {
+	*x = ListPendingApprovalsResponse{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingApprovalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingApprovalsResponse) ProtoMessage() {}
+
+func (x *ListPendingApprovalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingApprovalsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingApprovalsResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListPendingApprovalsResponse) GetApprovals() []*Approval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+type GetApprovalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  string                 `protobuf:"bytes,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApprovalRequest) Reset() {
+	*x = GetApprovalRequest{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApprovalRequest) ProtoMessage() {}
+
+func (x *GetApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApprovalRequest.ProtoReflect.Descriptor instead.
+func (*GetApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetApprovalRequest) GetWithdrawalId() string {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return ""
+}
+
+type GetApprovalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approval      *Approval              `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApprovalResponse) Reset() {
+	*x = GetApprovalResponse{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApprovalResponse) ProtoMessage() {}
+
+func (x *GetApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApprovalResponse.ProtoReflect.Descriptor instead.
+func (*GetApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetApprovalResponse) GetApproval() *Approval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+type ApproveWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  string                 `protobuf:"bytes,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	Notes         string                 `protobuf:"bytes,2,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveWithdrawalRequest) Reset() {
+	*x = ApproveWithdrawalRequest{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveWithdrawalRequest) ProtoMessage() {}
+
+func (x *ApproveWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*ApproveWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ApproveWithdrawalRequest) GetWithdrawalId() string {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return ""
+}
+
+func (x *ApproveWithdrawalRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type ApproveWithdrawalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approval      *Approval              `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveWithdrawalResponse) Reset() {
+	*x = ApproveWithdrawalResponse{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveWithdrawalResponse) ProtoMessage() {}
+
+func (x *ApproveWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*ApproveWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ApproveWithdrawalResponse) GetApproval() *Approval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+type RejectWithdrawalRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId string                 `protobuf:"bytes,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	// Required.
+	Notes         string `protobuf:"bytes,2,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectWithdrawalRequest) Reset() {
+	*x = RejectWithdrawalRequest{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectWithdrawalRequest) ProtoMessage() {}
+
+func (x *RejectWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*RejectWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RejectWithdrawalRequest) GetWithdrawalId() string {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return ""
+}
+
+func (x *RejectWithdrawalRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type RejectWithdrawalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approval      *Approval              `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectWithdrawalResponse) Reset() {
+	*x = RejectWithdrawalResponse{}
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectWithdrawalResponse) ProtoMessage() {}
+
+func (x *RejectWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_approval_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*RejectWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_approval_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RejectWithdrawalResponse) GetApproval() *Approval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+var File_orchestrator_v1_approval_proto protoreflect.FileDescriptor
+
+const file_orchestrator_v1_approval_proto_rawDesc = "" +
+	"\n" +
+	"\x1eorchestrator/v1/approval.proto\x12\x16cbsaga.orchestrator.v1\x1a\x1cgoogle/api/annotations.proto\"\x9e\x02\n" +
+	"\x0eApprovalPolicy\x12\x1b\n" +
+	"\tpolicy_id\x18\x01 \x01(\tR\bpolicyId\x12\x14\n" +
+	"\x05asset\x18\x02 \x01(\tR\x05asset\x12(\n" +
+	"\x10min_amount_minor\x18\x03 \x01(\x03R\x0eminAmountMinor\x12-\n" +
+	"\x12required_approvals\x18\x04 \x01(\x05R\x11requiredApprovals\x12#\n" +
+	"\rapprover_role\x18\x05 \x01(\tR\fapproverRole\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x06 \x01(\tR\tupdatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\"\x91\x01\n" +
+	"\fApprovalVote\x12\x1a\n" +
+	"\bapprover\x18\x01 \x01(\tR\bapprover\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\tR\x05notes\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"\xaf\x03\n" +
+	"\bApproval\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05asset\x18\x03 \x01(\tR\x05asset\x12!\n" +
+	"\famount_minor\x18\x04 \x01(\x03R\vamountMinor\x12)\n" +
+	"\x10destination_addr\x18\x05 \x01(\tR\x0fdestinationAddr\x12\x1b\n" +
+	"\tpolicy_id\x18\x06 \x01(\tR\bpolicyId\x12-\n" +
+	"\x12required_approvals\x18\a \x01(\x05R\x11requiredApprovals\x12#\n" +
+	"\rapprover_role\x18\b \x01(\tR\fapproverRole\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12:\n" +
+	"\x05votes\x18\n" +
+	" \x03(\v2$.cbsaga.orchestrator.v1.ApprovalVoteR\x05votes\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\v \x01(\tR\tdecidedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\f \x01(\tR\tcreatedAt\"\xb1\x01\n" +
+	"\x1bUpsertApprovalPolicyRequest\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12(\n" +
+	"\x10min_amount_minor\x18\x02 \x01(\x03R\x0eminAmountMinor\x12-\n" +
+	"\x12required_approvals\x18\x03 \x01(\x05R\x11requiredApprovals\x12#\n" +
+	"\rapprover_role\x18\x04 \x01(\tR\fapproverRole\"^\n" +
+	"\x1cUpsertApprovalPolicyResponse\x12>\n" +
+	"\x06policy\x18\x01 \x01(\v2&.cbsaga.orchestrator.v1.ApprovalPolicyR\x06policy\"\x1d\n" +
+	"\x1bListApprovalPoliciesRequest\"b\n" +
+	"\x1cListApprovalPoliciesResponse\x12B\n" +
+	"\bpolicies\x18\x01 \x03(\v2&.cbsaga.orchestrator.v1.ApprovalPolicyR\bpolicies\":\n" +
+	"\x1bDeleteApprovalPolicyRequest\x12\x1b\n" +
+	"\tpolicy_id\x18\x01 \x01(\tR\bpolicyId\"^\n" +
+	"\x1cDeleteApprovalPolicyResponse\x12>\n" +
+	"\x06policy\x18\x01 \x01(\v2&.cbsaga.orchestrator.v1.ApprovalPolicyR\x06policy\":\n" +
+	"\x1bListPendingApprovalsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\"^\n" +
+	"\x1cListPendingApprovalsResponse\x12>\n" +
+	"\tapprovals\x18\x01 \x03(\v2 .cbsaga.orchestrator.v1.ApprovalR\tapprovals\"9\n" +
+	"\x12GetApprovalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\"S\n" +
+	"\x13GetApprovalResponse\x12<\n" +
+	"\bapproval\x18\x01 \x01(\v2 .cbsaga.orchestrator.v1.ApprovalR\bapproval\"U\n" +
+	"\x18ApproveWithdrawalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\x12\x14\n" +
+	"\x05notes\x18\x02 \x01(\tR\x05notes\"Y\n" +
+	"\x19ApproveWithdrawalResponse\x12<\n" +
+	"\bapproval\x18\x01 \x01(\v2 .cbsaga.orchestrator.v1.ApprovalR\bapproval\"T\n" +
+	"\x17RejectWithdrawalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\x12\x14\n" +
+	"\x05notes\x18\x02 \x01(\tR\x05notes\"X\n" +
+	"\x18RejectWithdrawalResponse\x12<\n" +
+	"\bapproval\x18\x01 \x01(\v2 .cbsaga.orchestrator.v1.ApprovalR\bapproval2\x9c\t\n" +
+	"\x0fApprovalService\x12\xa9\x01\n" +
+	"\x14UpsertApprovalPolicy\x123.cbsaga.orchestrator.v1.UpsertApprovalPolicyRequest\x1a4.cbsaga.orchestrator.v1.UpsertApprovalPolicyResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\x1a\x1b/v1/admin/approval-policies\x12\xa6\x01\n" +
+	"\x14ListApprovalPolicies\x123.cbsaga.orchestrator.v1.ListApprovalPoliciesRequest\x1a4.cbsaga.orchestrator.v1.ListApprovalPoliciesResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/admin/approval-policies\x12\xb2\x01\n" +
+	"\x14DeleteApprovalPolicy\x123.cbsaga.orchestrator.v1.DeleteApprovalPolicyRequest\x1a4.cbsaga.orchestrator.v1.DeleteApprovalPolicyResponse\"/\x82\xd3\xe4\x93\x02)*'/v1/admin/approval-policies/{policy_id}\x12\x98\x01\n" +
+	"\x14ListPendingApprovals\x123.cbsaga.orchestrator.v1.ListPendingApprovalsRequest\x1a4.cbsaga.orchestrator.v1.ListPendingApprovalsResponse\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/approvals\x12\x8d\x01\n" +
+	"\vGetApproval\x12*.cbsaga.orchestrator.v1.GetApprovalRequest\x1a+.cbsaga.orchestrator.v1.GetApprovalResponse\"%\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/approvals/{withdrawal_id}\x12\xaa\x01\n" +
+	"\x11ApproveWithdrawal\x120.cbsaga.orchestrator.v1.ApproveWithdrawalRequest\x1a1.cbsaga.orchestrator.v1.ApproveWithdrawalResponse\"0\x82\xd3\xe4\x93\x02*:\x01*\"%/v1/approvals/{withdrawal_id}/approve\x12\xa6\x01\n" +
+	"\x10RejectWithdrawal\x12/.cbsaga.orchestrator.v1.RejectWithdrawalRequest\x1a0.cbsaga.orchestrator.v1.RejectWithdrawalResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/v1/approvals/{withdrawal_id}/rejectB?Z=github.com/cicconee/cbsaga/gen/orchestrator/v1;orchestratorv1b\x06proto3"
+
+var (
+	file_orchestrator_v1_approval_proto_rawDescOnce sync.Once
+	file_orchestrator_v1_approval_proto_rawDescData []byte
+)
+
+func file_orchestrator_v1_approval_proto_rawDescGZIP() []byte {
+	file_orchestrator_v1_approval_proto_rawDescOnce.Do(func() {
+		file_orchestrator_v1_approval_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_orchestrator_v1_approval_proto_rawDesc), len(file_orchestrator_v1_approval_proto_rawDesc)))
+	})
+	return file_orchestrator_v1_approval_proto_rawDescData
+}
+
+var file_orchestrator_v1_approval_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_orchestrator_v1_approval_proto_goTypes = []any{
+	(*ApprovalPolicy)(nil),               // 0: cbsaga.orchestrator.v1.ApprovalPolicy
+	(*ApprovalVote)(nil),                 // 1: cbsaga.orchestrator.v1.ApprovalVote
+	(*Approval)(nil),                     // 2: cbsaga.orchestrator.v1.Approval
+	(*UpsertApprovalPolicyRequest)(nil),  // 3: cbsaga.orchestrator.v1.UpsertApprovalPolicyRequest
+	(*UpsertApprovalPolicyResponse)(nil), // 4: cbsaga.orchestrator.v1.UpsertApprovalPolicyResponse
+	(*ListApprovalPoliciesRequest)(nil),  // 5: cbsaga.orchestrator.v1.ListApprovalPoliciesRequest
+	(*ListApprovalPoliciesResponse)(nil), // 6: cbsaga.orchestrator.v1.ListApprovalPoliciesResponse
+	(*DeleteApprovalPolicyRequest)(nil),  // 7: cbsaga.orchestrator.v1.DeleteApprovalPolicyRequest
+	(*DeleteApprovalPolicyResponse)(nil), // 8: cbsaga.orchestrator.v1.DeleteApprovalPolicyResponse
+	(*ListPendingApprovalsRequest)(nil),  // 9: cbsaga.orchestrator.v1.ListPendingApprovalsRequest
+	(*ListPendingApprovalsResponse)(nil), // 10: cbsaga.orchestrator.v1.ListPendingApprovalsResponse
+	(*GetApprovalRequest)(nil),           // 11: cbsaga.orchestrator.v1.GetApprovalRequest
+	(*GetApprovalResponse)(nil),          // 12: cbsaga.orchestrator.v1.GetApprovalResponse
+	(*ApproveWithdrawalRequest)(nil),     // 13: cbsaga.orchestrator.v1.ApproveWithdrawalRequest
+	(*ApproveWithdrawalResponse)(nil),    // 14: cbsaga.orchestrator.v1.ApproveWithdrawalResponse
+	(*RejectWithdrawalRequest)(nil),      // 15: cbsaga.orchestrator.v1.RejectWithdrawalRequest
+	(*RejectWithdrawalResponse)(nil),     // 16: cbsaga.orchestrator.v1.RejectWithdrawalResponse
+}
+var file_orchestrator_v1_approval_proto_depIdxs = []int32{
+	1,  // 0: cbsaga.orchestrator.v1.Approval.votes:type_name -> cbsaga.orchestrator.v1.ApprovalVote
+	0,  // 1: cbsaga.orchestrator.v1.UpsertApprovalPolicyResponse.policy:type_name -> cbsaga.orchestrator.v1.ApprovalPolicy
+	0,  // 2: cbsaga.orchestrator.v1.ListApprovalPoliciesResponse.policies:type_name -> cbsaga.orchestrator.v1.ApprovalPolicy
+	0,  // 3: cbsaga.orchestrator.v1.DeleteApprovalPolicyResponse.policy:type_name -> cbsaga.orchestrator.v1.ApprovalPolicy
+	2,  // 4: cbsaga.orchestrator.v1.ListPendingApprovalsResponse.approvals:type_name -> cbsaga.orchestrator.v1.Approval
+	2,  // 5: cbsaga.orchestrator.v1.GetApprovalResponse.approval:type_name -> cbsaga.orchestrator.v1.Approval
+	2,  // 6: cbsaga.orchestrator.v1.ApproveWithdrawalResponse.approval:type_name -> cbsaga.orchestrator.v1.Approval
+	2,  // 7: cbsaga.orchestrator.v1.RejectWithdrawalResponse.approval:type_name -> cbsaga.orchestrator.v1.Approval
+	3,  // 8: cbsaga.orchestrator.v1.ApprovalService.UpsertApprovalPolicy:input_type -> cbsaga.orchestrator.v1.UpsertApprovalPolicyRequest
+	5,  // 9: cbsaga.orchestrator.v1.ApprovalService.ListApprovalPolicies:input_type -> cbsaga.orchestrator.v1.ListApprovalPoliciesRequest
+	7,  // 10: cbsaga.orchestrator.v1.ApprovalService.DeleteApprovalPolicy:input_type -> cbsaga.orchestrator.v1.DeleteApprovalPolicyRequest
+	9,  // 11: cbsaga.orchestrator.v1.ApprovalService.ListPendingApprovals:input_type -> cbsaga.orchestrator.v1.ListPendingApprovalsRequest
+	11, // 12: cbsaga.orchestrator.v1.ApprovalService.GetApproval:input_type -> cbsaga.orchestrator.v1.GetApprovalRequest
+	13, // 13: cbsaga.orchestrator.v1.ApprovalService.ApproveWithdrawal:input_type -> cbsaga.orchestrator.v1.ApproveWithdrawalRequest
+	15, // 14: cbsaga.orchestrator.v1.ApprovalService.RejectWithdrawal:input_type -> cbsaga.orchestrator.v1.RejectWithdrawalRequest
+	4,  // 15: cbsaga.orchestrator.v1.ApprovalService.UpsertApprovalPolicy:output_type -> cbsaga.orchestrator.v1.UpsertApprovalPolicyResponse
+	6,  // 16: cbsaga.orchestrator.v1.ApprovalService.ListApprovalPolicies:output_type -> cbsaga.orchestrator.v1.ListApprovalPoliciesResponse
+	8,  // 17: cbsaga.orchestrator.v1.ApprovalService.DeleteApprovalPolicy:output_type -> cbsaga.orchestrator.v1.DeleteApprovalPolicyResponse
+	10, // 18: cbsaga.orchestrator.v1.ApprovalService.ListPendingApprovals:output_type -> cbsaga.orchestrator.v1.ListPendingApprovalsResponse
+	12, // 19: cbsaga.orchestrator.v1.ApprovalService.GetApproval:output_type -> cbsaga.orchestrator.v1.GetApprovalResponse
+	14, // 20: cbsaga.orchestrator.v1.ApprovalService.ApproveWithdrawal:output_type -> cbsaga.orchestrator.v1.ApproveWithdrawalResponse
+	16, // 21: cbsaga.orchestrator.v1.ApprovalService.RejectWithdrawal:output_type -> cbsaga.orchestrator.v1.RejectWithdrawalResponse
+	15, // [15:22] is the sub-list for method output_type
+	8,  // [8:15] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_orchestrator_v1_approval_proto_init() }
+func file_orchestrator_v1_approval_proto_init() {
+	if File_orchestrator_v1_approval_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orchestrator_v1_approval_proto_rawDesc), len(file_orchestrator_v1_approval_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_orchestrator_v1_approval_proto_goTypes,
+		DependencyIndexes: file_orchestrator_v1_approval_proto_depIdxs,
+		MessageInfos:      file_orchestrator_v1_approval_proto_msgTypes,
+	}.Build()
+	File_orchestrator_v1_approval_proto = out.File
+	file_orchestrator_v1_approval_proto_goTypes = nil
+	file_orchestrator_v1_approval_proto_depIdxs = nil
+}
@@ -0,0 +1,564 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: orchestrator/v1/asset.proto
+
+package orchestratorv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Asset struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Asset          string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Network        string                 `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Decimals       int32                  `protobuf:"varint,3,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	MinAmountMinor int64                  `protobuf:"varint,4,opt,name=min_amount_minor,json=minAmountMinor,proto3" json:"min_amount_minor,omitempty"`
+	MaxAmountMinor int64                  `protobuf:"varint,5,opt,name=max_amount_minor,json=maxAmountMinor,proto3" json:"max_amount_minor,omitempty"`
+	// ENABLED or PAUSED.
+	Status    string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt string `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt string `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Withdrawals of at least this amount must be confirmed with a one-time code; 0 never.
+	ChallengeThresholdMinor int64 `protobuf:"varint,9,opt,name=challenge_threshold_minor,json=challengeThresholdMinor,proto3" json:"challenge_threshold_minor,omitempty"`
+	// Blocks a withdrawal's transaction must be buried under before it is final.
+	Confirmations int64 `protobuf:"varint,10,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_asset_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Asset) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Asset) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *Asset) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *Asset) GetMinAmountMinor() int64 {
+	if x != nil {
+		return x.MinAmountMinor
+	}
+	return 0
+}
+
+func (x *Asset) GetMaxAmountMinor() int64 {
+	if x != nil {
+		return x.MaxAmountMinor
+	}
+	return 0
+}
+
+func (x *Asset) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Asset) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Asset) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Asset) GetChallengeThresholdMinor() int64 {
+	if x != nil {
+		return x.ChallengeThresholdMinor
+	}
+	return 0
+}
+
+func (x *Asset) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+type UpsertAssetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Asset string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	// Fixed once the asset exists; naming another network fails with FAILED_PRECONDITION.
+	Network        string `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Decimals       int32  `protobuf:"varint,3,opt,name=decimals,proto3" json:"decimals,omitempty"`
+	MinAmountMinor int64  `protobuf:"varint,4,opt,name=min_amount_minor,json=minAmountMinor,proto3" json:"min_amount_minor,omitempty"`
+	MaxAmountMinor int64  `protobuf:"varint,5,opt,name=max_amount_minor,json=maxAmountMinor,proto3" json:"max_amount_minor,omitempty"`
+	// Withdrawals of at least this amount must be confirmed with a one-time code; 0 never.
+	ChallengeThresholdMinor int64 `protobuf:"varint,6,opt,name=challenge_threshold_minor,json=challengeThresholdMinor,proto3" json:"challenge_threshold_minor,omitempty"`
+	// Blocks a withdrawal's transaction must be buried under before it is final; 0 means 1.
+	Confirmations int64 `protobuf:"varint,7,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertAssetRequest) Reset() {
+	*x = UpsertAssetRequest{}
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertAssetRequest) ProtoMessage() {}
+
+func (x *UpsertAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertAssetRequest.ProtoReflect.Descriptor instead.
+func (*UpsertAssetRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_asset_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpsertAssetRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *UpsertAssetRequest) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *UpsertAssetRequest) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *UpsertAssetRequest) GetMinAmountMinor() int64 {
+	if x != nil {
+		return x.MinAmountMinor
+	}
+	return 0
+}
+
+func (x *UpsertAssetRequest) GetMaxAmountMinor() int64 {
+	if x != nil {
+		return x.MaxAmountMinor
+	}
+	return 0
+}
+
+func (x *UpsertAssetRequest) GetChallengeThresholdMinor() int64 {
+	if x != nil {
+		return x.ChallengeThresholdMinor
+	}
+	return 0
+}
+
+func (x *UpsertAssetRequest) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+type UpsertAssetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpsertAssetResponse) Reset() {
+	*x = UpsertAssetResponse{}
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpsertAssetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpsertAssetResponse) ProtoMessage() {}
+
+func (x *UpsertAssetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpsertAssetResponse.ProtoReflect.Descriptor instead.
+func (*UpsertAssetResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_asset_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UpsertAssetResponse) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type SetAssetStatusRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Asset string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	// ENABLED or PAUSED.
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAssetStatusRequest) Reset() {
+	*x = SetAssetStatusRequest{}
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAssetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAssetStatusRequest) ProtoMessage() {}
+
+func (x *SetAssetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAssetStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetAssetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_asset_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetAssetStatusRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *SetAssetStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type SetAssetStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAssetStatusResponse) Reset() {
+	*x = SetAssetStatusResponse{}
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAssetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAssetStatusResponse) ProtoMessage() {}
+
+func (x *SetAssetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAssetStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetAssetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_asset_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SetAssetStatusResponse) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+type ListAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsRequest) Reset() {
+	*x = ListAssetsRequest{}
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsRequest) ProtoMessage() {}
+
+func (x *ListAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_asset_proto_rawDescGZIP(), []int{5}
+}
+
+type ListAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsResponse) Reset() {
+	*x = ListAssetsResponse{}
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsResponse) ProtoMessage() {}
+
+func (x *ListAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_asset_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_asset_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListAssetsResponse) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+var File_orchestrator_v1_asset_proto protoreflect.FileDescriptor
+
+const file_orchestrator_v1_asset_proto_rawDesc = "" +
+	"\n" +
+	"\x1borchestrator/v1/asset.proto\x12\x16cbsaga.orchestrator.v1\x1a\x1cgoogle/api/annotations.proto\"\xdf\x02\n" +
+	"\x05Asset\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x18\n" +
+	"\anetwork\x18\x02 \x01(\tR\anetwork\x12\x1a\n" +
+	"\bdecimals\x18\x03 \x01(\x05R\bdecimals\x12(\n" +
+	"\x10min_amount_minor\x18\x04 \x01(\x03R\x0eminAmountMinor\x12(\n" +
+	"\x10max_amount_minor\x18\x05 \x01(\x03R\x0emaxAmountMinor\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x12:\n" +
+	"\x19challenge_threshold_minor\x18\t \x01(\x03R\x17challengeThresholdMinor\x12$\n" +
+	"\rconfirmations\x18\n" +
+	" \x01(\x03R\rconfirmations\"\x96\x02\n" +
+	"\x12UpsertAssetRequest\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x18\n" +
+	"\anetwork\x18\x02 \x01(\tR\anetwork\x12\x1a\n" +
+	"\bdecimals\x18\x03 \x01(\x05R\bdecimals\x12(\n" +
+	"\x10min_amount_minor\x18\x04 \x01(\x03R\x0eminAmountMinor\x12(\n" +
+	"\x10max_amount_minor\x18\x05 \x01(\x03R\x0emaxAmountMinor\x12:\n" +
+	"\x19challenge_threshold_minor\x18\x06 \x01(\x03R\x17challengeThresholdMinor\x12$\n" +
+	"\rconfirmations\x18\a \x01(\x03R\rconfirmations\"J\n" +
+	"\x13UpsertAssetResponse\x123\n" +
+	"\x05asset\x18\x01 \x01(\v2\x1d.cbsaga.orchestrator.v1.AssetR\x05asset\"E\n" +
+	"\x15SetAssetStatusRequest\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"M\n" +
+	"\x16SetAssetStatusResponse\x123\n" +
+	"\x05asset\x18\x01 \x01(\v2\x1d.cbsaga.orchestrator.v1.AssetR\x05asset\"\x13\n" +
+	"\x11ListAssetsRequest\"K\n" +
+	"\x12ListAssetsResponse\x125\n" +
+	"\x06assets\x18\x01 \x03(\v2\x1d.cbsaga.orchestrator.v1.AssetR\x06assets2\xbe\x03\n" +
+	"\x11AssetAdminService\x12\x8b\x01\n" +
+	"\vUpsertAsset\x12*.cbsaga.orchestrator.v1.UpsertAssetRequest\x1a+.cbsaga.orchestrator.v1.UpsertAssetResponse\"#\x82\xd3\xe4\x93\x02\x1d:\x01*\x1a\x18/v1/admin/assets/{asset}\x12\x9b\x01\n" +
+	"\x0eSetAssetStatus\x12-.cbsaga.orchestrator.v1.SetAssetStatusRequest\x1a..cbsaga.orchestrator.v1.SetAssetStatusResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/admin/assets/{asset}/status\x12}\n" +
+	"\n" +
+	"ListAssets\x12).cbsaga.orchestrator.v1.ListAssetsRequest\x1a*.cbsaga.orchestrator.v1.ListAssetsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/admin/assetsB?Z=github.com/cicconee/cbsaga/gen/orchestrator/v1;orchestratorv1b\x06proto3"
+
+var (
+	file_orchestrator_v1_asset_proto_rawDescOnce sync.Once
+	file_orchestrator_v1_asset_proto_rawDescData []byte
+)
+
+func file_orchestrator_v1_asset_proto_rawDescGZIP() []byte {
+	file_orchestrator_v1_asset_proto_rawDescOnce.Do(func() {
+		file_orchestrator_v1_asset_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_orchestrator_v1_asset_proto_rawDesc), len(file_orchestrator_v1_asset_proto_rawDesc)))
+	})
+	return file_orchestrator_v1_asset_proto_rawDescData
+}
+
+var file_orchestrator_v1_asset_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_orchestrator_v1_asset_proto_goTypes = []any{
+	(*Asset)(nil),                  // 0: cbsaga.orchestrator.v1.Asset
+	(*UpsertAssetRequest)(nil),     // 1: cbsaga.orchestrator.v1.UpsertAssetRequest
+	(*UpsertAssetResponse)(nil),    // 2: cbsaga.orchestrator.v1.UpsertAssetResponse
+	(*SetAssetStatusRequest)(nil),  // 3: cbsaga.orchestrator.v1.SetAssetStatusRequest
+	(*SetAssetStatusResponse)(nil), // 4: cbsaga.orchestrator.v1.SetAssetStatusResponse
+	(*ListAssetsRequest)(nil),      // 5: cbsaga.orchestrator.v1.ListAssetsRequest
+	(*ListAssetsResponse)(nil),     // 6: cbsaga.orchestrator.v1.ListAssetsResponse
+}
+var file_orchestrator_v1_asset_proto_depIdxs = []int32{
+	0, // 0: cbsaga.orchestrator.v1.UpsertAssetResponse.asset:type_name -> cbsaga.orchestrator.v1.Asset
+	0, // 1: cbsaga.orchestrator.v1.SetAssetStatusResponse.asset:type_name -> cbsaga.orchestrator.v1.Asset
+	0, // 2: cbsaga.orchestrator.v1.ListAssetsResponse.assets:type_name -> cbsaga.orchestrator.v1.Asset
+	1, // 3: cbsaga.orchestrator.v1.AssetAdminService.UpsertAsset:input_type -> cbsaga.orchestrator.v1.UpsertAssetRequest
+	3, // 4: cbsaga.orchestrator.v1.AssetAdminService.SetAssetStatus:input_type -> cbsaga.orchestrator.v1.SetAssetStatusRequest
+	5, // 5: cbsaga.orchestrator.v1.AssetAdminService.ListAssets:input_type -> cbsaga.orchestrator.v1.ListAssetsRequest
+	2, // 6: cbsaga.orchestrator.v1.AssetAdminService.UpsertAsset:output_type -> cbsaga.orchestrator.v1.UpsertAssetResponse
+	4, // 7: cbsaga.orchestrator.v1.AssetAdminService.SetAssetStatus:output_type -> cbsaga.orchestrator.v1.SetAssetStatusResponse
+	6, // 8: cbsaga.orchestrator.v1.AssetAdminService.ListAssets:output_type -> cbsaga.orchestrator.v1.ListAssetsResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_orchestrator_v1_asset_proto_init() }
+func file_orchestrator_v1_asset_proto_init() {
+	if File_orchestrator_v1_asset_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orchestrator_v1_asset_proto_rawDesc), len(file_orchestrator_v1_asset_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_orchestrator_v1_asset_proto_goTypes,
+		DependencyIndexes: file_orchestrator_v1_asset_proto_depIdxs,
+		MessageInfos:      file_orchestrator_v1_asset_proto_msgTypes,
+	}.Build()
+	File_orchestrator_v1_asset_proto = out.File
+	file_orchestrator_v1_asset_proto_goTypes = nil
+	file_orchestrator_v1_asset_proto_depIdxs = nil
+}
@@ -0,0 +1,201 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.0
+// - protoc             v6.33.2
+// source: orchestrator/v1/asset.proto
+
+package orchestratorv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AssetAdminService_UpsertAsset_FullMethodName    = "/cbsaga.orchestrator.v1.AssetAdminService/UpsertAsset"
+	AssetAdminService_SetAssetStatus_FullMethodName = "/cbsaga.orchestrator.v1.AssetAdminService/SetAssetStatus"
+	AssetAdminService_ListAssets_FullMethodName     = "/cbsaga.orchestrator.v1.AssetAdminService/ListAssets"
+)
+
+// AssetAdminServiceClient is the client API for AssetAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AssetAdminService manages the asset registry. Every RPC requires the admin role.
+type AssetAdminServiceClient interface {
+	UpsertAsset(ctx context.Context, in *UpsertAssetRequest, opts ...grpc.CallOption) (*UpsertAssetResponse, error)
+	SetAssetStatus(ctx context.Context, in *SetAssetStatusRequest, opts ...grpc.CallOption) (*SetAssetStatusResponse, error)
+	ListAssets(ctx context.Context, in *ListAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error)
+}
+
+type assetAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAssetAdminServiceClient(cc grpc.ClientConnInterface) AssetAdminServiceClient {
+	return &assetAdminServiceClient{cc}
+}
+
+func (c *assetAdminServiceClient) UpsertAsset(ctx context.Context, in *UpsertAssetRequest, opts ...grpc.CallOption) (*UpsertAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertAssetResponse)
+	err := c.cc.Invoke(ctx, AssetAdminService_UpsertAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetAdminServiceClient) SetAssetStatus(ctx context.Context, in *SetAssetStatusRequest, opts ...grpc.CallOption) (*SetAssetStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetAssetStatusResponse)
+	err := c.cc.Invoke(ctx, AssetAdminService_SetAssetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetAdminServiceClient) ListAssets(ctx context.Context, in *ListAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetAdminService_ListAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetAdminServiceServer is the server API for AssetAdminService service.
+// All implementations must embed UnimplementedAssetAdminServiceServer
+// for forward compatibility.
+//
+// AssetAdminService manages the asset registry. Every RPC requires the admin role.
+type AssetAdminServiceServer interface {
+	UpsertAsset(context.Context, *UpsertAssetRequest) (*UpsertAssetResponse, error)
+	SetAssetStatus(context.Context, *SetAssetStatusRequest) (*SetAssetStatusResponse, error)
+	ListAssets(context.Context, *ListAssetsRequest) (*ListAssetsResponse, error)
+	mustEmbedUnimplementedAssetAdminServiceServer()
+}
+
+// UnimplementedAssetAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAssetAdminServiceServer struct{}
+
+func (UnimplementedAssetAdminServiceServer) UpsertAsset(context.Context, *UpsertAssetRequest) (*UpsertAssetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertAsset not implemented")
+}
+func (UnimplementedAssetAdminServiceServer) SetAssetStatus(context.Context, *SetAssetStatusRequest) (*SetAssetStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetAssetStatus not implemented")
+}
+func (UnimplementedAssetAdminServiceServer) ListAssets(context.Context, *ListAssetsRequest) (*ListAssetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAssets not implemented")
+}
+func (UnimplementedAssetAdminServiceServer) mustEmbedUnimplementedAssetAdminServiceServer() {}
+func (UnimplementedAssetAdminServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeAssetAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AssetAdminServiceServer will
+// result in compilation errors.
+type UnsafeAssetAdminServiceServer interface {
+	mustEmbedUnimplementedAssetAdminServiceServer()
+}
+
+func RegisterAssetAdminServiceServer(s grpc.ServiceRegistrar, srv AssetAdminServiceServer) {
+	// If the following call panics, it indicates UnimplementedAssetAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AssetAdminService_ServiceDesc, srv)
+}
+
+func _AssetAdminService_UpsertAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetAdminServiceServer).UpsertAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetAdminService_UpsertAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetAdminServiceServer).UpsertAsset(ctx, req.(*UpsertAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetAdminService_SetAssetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAssetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetAdminServiceServer).SetAssetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetAdminService_SetAssetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetAdminServiceServer).SetAssetStatus(ctx, req.(*SetAssetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetAdminService_ListAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetAdminServiceServer).ListAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetAdminService_ListAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetAdminServiceServer).ListAssets(ctx, req.(*ListAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AssetAdminService_ServiceDesc is the grpc.ServiceDesc for AssetAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AssetAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cbsaga.orchestrator.v1.AssetAdminService",
+	HandlerType: (*AssetAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpsertAsset",
+			Handler:    _AssetAdminService_UpsertAsset_Handler,
+		},
+		{
+			MethodName: "SetAssetStatus",
+			Handler:    _AssetAdminService_SetAssetStatus_Handler,
+		},
+		{
+			MethodName: "ListAssets",
+			Handler:    _AssetAdminService_ListAssets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "orchestrator/v1/asset.proto",
+}
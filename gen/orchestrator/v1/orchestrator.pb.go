@@ -7,6 +7,8 @@
 package orchestratorv1
 
 import (
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -27,9 +29,10 @@ type CreateWithdrawalRequest struct {
 	Asset           string                 `protobuf:"bytes,2,opt,name=asset,proto3" json:"asset,omitempty"`
 	AmountMinor     int64                  `protobuf:"varint,3,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"`
 	DestinationAddr string                 `protobuf:"bytes,4,opt,name=destination_addr,json=destinationAddr,proto3" json:"destination_addr,omitempty"`
-	IdempotencyKey  string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Over HTTP this may instead be sent as the Idempotency-Key header.
+	IdempotencyKey string `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateWithdrawalRequest) Reset() {
@@ -204,8 +207,17 @@ type GetWithdrawalResponse struct {
 	FailureReason   string                 `protobuf:"bytes,7,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
 	CreatedAt       string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt       string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Set once the transaction is broadcast.
+	TxHash      string `protobuf:"bytes,10,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	FromAddr    string `protobuf:"bytes,11,opt,name=from_addr,json=fromAddr,proto3" json:"from_addr,omitempty"`
+	BroadcastAt string `protobuf:"bytes,12,opt,name=broadcast_at,json=broadcastAt,proto3" json:"broadcast_at,omitempty"`
+	// Set once the withdrawal is COMPLETED: the block its transaction landed in.
+	BlockHeight   int64  `protobuf:"varint,13,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
+	BlockHash     string `protobuf:"bytes,14,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Confirmations int64  `protobuf:"varint,15,opt,name=confirmations,proto3" json:"confirmations,omitempty"`
+	CompletedAt   string `protobuf:"bytes,16,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetWithdrawalResponse) Reset() {
@@ -301,11 +313,165 @@ func (x *GetWithdrawalResponse) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *GetWithdrawalResponse) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *GetWithdrawalResponse) GetFromAddr() string {
+	if x != nil {
+		return x.FromAddr
+	}
+	return ""
+}
+
+func (x *GetWithdrawalResponse) GetBroadcastAt() string {
+	if x != nil {
+		return x.BroadcastAt
+	}
+	return ""
+}
+
+func (x *GetWithdrawalResponse) GetBlockHeight() int64 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *GetWithdrawalResponse) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *GetWithdrawalResponse) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *GetWithdrawalResponse) GetCompletedAt() string {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return ""
+}
+
+type ConfirmWithdrawalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  string                 `protobuf:"bytes,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmWithdrawalRequest) Reset() {
+	*x = ConfirmWithdrawalRequest{}
+	mi := &file_orchestrator_v1_orchestrator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmWithdrawalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmWithdrawalRequest) ProtoMessage() {}
+
+func (x *ConfirmWithdrawalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_orchestrator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmWithdrawalRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmWithdrawalRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ConfirmWithdrawalRequest) GetWithdrawalId() string {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return ""
+}
+
+func (x *ConfirmWithdrawalRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ConfirmWithdrawalResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId string                 `protobuf:"bytes,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	// The challenge status: CONFIRMED once the code is accepted.
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmWithdrawalResponse) Reset() {
+	*x = ConfirmWithdrawalResponse{}
+	mi := &file_orchestrator_v1_orchestrator_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmWithdrawalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmWithdrawalResponse) ProtoMessage() {}
+
+func (x *ConfirmWithdrawalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_orchestrator_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmWithdrawalResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmWithdrawalResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_orchestrator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConfirmWithdrawalResponse) GetWithdrawalId() string {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return ""
+}
+
+func (x *ConfirmWithdrawalResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
 var File_orchestrator_v1_orchestrator_proto protoreflect.FileDescriptor
 
 const file_orchestrator_v1_orchestrator_proto_rawDesc = "" +
 	"\n" +
-	"\"orchestrator/v1/orchestrator.proto\x12\x16cbsaga.orchestrator.v1\"\xbf\x01\n" +
+	"\"orchestrator/v1/orchestrator.proto\x12\x16cbsaga.orchestrator.v1\x1a\x1cgoogle/api/annotations.proto\x1a.protoc-gen-openapiv2/options/annotations.proto\"\xbf\x01\n" +
 	"\x17CreateWithdrawalRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
 	"\x05asset\x18\x02 \x01(\tR\x05asset\x12!\n" +
@@ -316,7 +482,7 @@ const file_orchestrator_v1_orchestrator_proto_rawDesc = "" +
 	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\";\n" +
 	"\x14GetWithdrawalRequest\x12#\n" +
-	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\"\xb6\x02\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\"\x9a\x04\n" +
 	"\x15GetWithdrawalResponse\x12#\n" +
 	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
@@ -328,10 +494,34 @@ const file_orchestrator_v1_orchestrator_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\tR\tupdatedAt2\xfa\x01\n" +
-	"\x13OrchestratorService\x12u\n" +
-	"\x10CreateWithdrawal\x12/.cbsaga.orchestrator.v1.CreateWithdrawalRequest\x1a0.cbsaga.orchestrator.v1.CreateWithdrawalResponse\x12l\n" +
-	"\rGetWithdrawal\x12,.cbsaga.orchestrator.v1.GetWithdrawalRequest\x1a-.cbsaga.orchestrator.v1.GetWithdrawalResponseB?Z=github.com/cicconee/cbsaga/gen/orchestrator/v1;orchestratorv1b\x06proto3"
+	"updated_at\x18\t \x01(\tR\tupdatedAt\x12\x17\n" +
+	"\atx_hash\x18\n" +
+	" \x01(\tR\x06txHash\x12\x1b\n" +
+	"\tfrom_addr\x18\v \x01(\tR\bfromAddr\x12!\n" +
+	"\fbroadcast_at\x18\f \x01(\tR\vbroadcastAt\x12!\n" +
+	"\fblock_height\x18\r \x01(\x03R\vblockHeight\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x0e \x01(\tR\tblockHash\x12$\n" +
+	"\rconfirmations\x18\x0f \x01(\x03R\rconfirmations\x12!\n" +
+	"\fcompleted_at\x18\x10 \x01(\tR\vcompletedAt\"S\n" +
+	"\x18ConfirmWithdrawalRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"X\n" +
+	"\x19ConfirmWithdrawalResponse\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status2\xbd\x04\n" +
+	"\x13OrchestratorService\x12\xde\x01\n" +
+	"\x10CreateWithdrawal\x12/.cbsaga.orchestrator.v1.CreateWithdrawalRequest\x1a0.cbsaga.orchestrator.v1.CreateWithdrawalResponse\"g\x92AJrH\n" +
+	"F\n" +
+	"\x0fIdempotency-Key\x121Used when idempotency_key is not set in the body.\x18\x01\x82\xd3\xe4\x93\x02\x14:\x01*\"\x0f/v1/withdrawals\x12\x95\x01\n" +
+	"\rGetWithdrawal\x12,.cbsaga.orchestrator.v1.GetWithdrawalRequest\x1a-.cbsaga.orchestrator.v1.GetWithdrawalResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/v1/withdrawals/{withdrawal_id}\x12\xac\x01\n" +
+	"\x11ConfirmWithdrawal\x120.cbsaga.orchestrator.v1.ConfirmWithdrawalRequest\x1a1.cbsaga.orchestrator.v1.ConfirmWithdrawalResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/v1/withdrawals/{withdrawal_id}/confirmB\x9a\x01\x92AX\x12\x19\n" +
+	"\x13cbsaga orchestrator2\x02v1Z-\n" +
+	"+\n" +
+	"\x06bearer\x12!\b\x02\x12\fBearer <JWT>\x1a\rAuthorization \x02b\f\n" +
+	"\n" +
+	"\n" +
+	"\x06bearer\x12\x00Z=github.com/cicconee/cbsaga/gen/orchestrator/v1;orchestratorv1b\x06proto3"
 
 var (
 	file_orchestrator_v1_orchestrator_proto_rawDescOnce sync.Once
@@ -345,20 +535,24 @@ func file_orchestrator_v1_orchestrator_proto_rawDescGZIP() []byte {
 	return file_orchestrator_v1_orchestrator_proto_rawDescData
 }
 
-var file_orchestrator_v1_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_orchestrator_v1_orchestrator_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_orchestrator_v1_orchestrator_proto_goTypes = []any{
-	(*CreateWithdrawalRequest)(nil),  // 0: cbsaga.orchestrator.v1.CreateWithdrawalRequest
-	(*CreateWithdrawalResponse)(nil), // 1: cbsaga.orchestrator.v1.CreateWithdrawalResponse
-	(*GetWithdrawalRequest)(nil),     // 2: cbsaga.orchestrator.v1.GetWithdrawalRequest
-	(*GetWithdrawalResponse)(nil),    // 3: cbsaga.orchestrator.v1.GetWithdrawalResponse
+	(*CreateWithdrawalRequest)(nil),   // 0: cbsaga.orchestrator.v1.CreateWithdrawalRequest
+	(*CreateWithdrawalResponse)(nil),  // 1: cbsaga.orchestrator.v1.CreateWithdrawalResponse
+	(*GetWithdrawalRequest)(nil),      // 2: cbsaga.orchestrator.v1.GetWithdrawalRequest
+	(*GetWithdrawalResponse)(nil),     // 3: cbsaga.orchestrator.v1.GetWithdrawalResponse
+	(*ConfirmWithdrawalRequest)(nil),  // 4: cbsaga.orchestrator.v1.ConfirmWithdrawalRequest
+	(*ConfirmWithdrawalResponse)(nil), // 5: cbsaga.orchestrator.v1.ConfirmWithdrawalResponse
 }
 var file_orchestrator_v1_orchestrator_proto_depIdxs = []int32{
 	0, // 0: cbsaga.orchestrator.v1.OrchestratorService.CreateWithdrawal:input_type -> cbsaga.orchestrator.v1.CreateWithdrawalRequest
 	2, // 1: cbsaga.orchestrator.v1.OrchestratorService.GetWithdrawal:input_type -> cbsaga.orchestrator.v1.GetWithdrawalRequest
-	1, // 2: cbsaga.orchestrator.v1.OrchestratorService.CreateWithdrawal:output_type -> cbsaga.orchestrator.v1.CreateWithdrawalResponse
-	3, // 3: cbsaga.orchestrator.v1.OrchestratorService.GetWithdrawal:output_type -> cbsaga.orchestrator.v1.GetWithdrawalResponse
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
+	4, // 2: cbsaga.orchestrator.v1.OrchestratorService.ConfirmWithdrawal:input_type -> cbsaga.orchestrator.v1.ConfirmWithdrawalRequest
+	1, // 3: cbsaga.orchestrator.v1.OrchestratorService.CreateWithdrawal:output_type -> cbsaga.orchestrator.v1.CreateWithdrawalResponse
+	3, // 4: cbsaga.orchestrator.v1.OrchestratorService.GetWithdrawal:output_type -> cbsaga.orchestrator.v1.GetWithdrawalResponse
+	5, // 5: cbsaga.orchestrator.v1.OrchestratorService.ConfirmWithdrawal:output_type -> cbsaga.orchestrator.v1.ConfirmWithdrawalResponse
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -375,7 +569,7 @@ func file_orchestrator_v1_orchestrator_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orchestrator_v1_orchestrator_proto_rawDesc), len(file_orchestrator_v1_orchestrator_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
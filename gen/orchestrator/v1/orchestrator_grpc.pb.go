@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	OrchestratorService_CreateWithdrawal_FullMethodName = "/cbsaga.orchestrator.v1.OrchestratorService/CreateWithdrawal"
-	OrchestratorService_GetWithdrawal_FullMethodName    = "/cbsaga.orchestrator.v1.OrchestratorService/GetWithdrawal"
+	OrchestratorService_CreateWithdrawal_FullMethodName  = "/cbsaga.orchestrator.v1.OrchestratorService/CreateWithdrawal"
+	OrchestratorService_GetWithdrawal_FullMethodName     = "/cbsaga.orchestrator.v1.OrchestratorService/GetWithdrawal"
+	OrchestratorService_ConfirmWithdrawal_FullMethodName = "/cbsaga.orchestrator.v1.OrchestratorService/ConfirmWithdrawal"
 )
 
 // OrchestratorServiceClient is the client API for OrchestratorService service.
@@ -29,6 +30,9 @@ const (
 type OrchestratorServiceClient interface {
 	CreateWithdrawal(ctx context.Context, in *CreateWithdrawalRequest, opts ...grpc.CallOption) (*CreateWithdrawalResponse, error)
 	GetWithdrawal(ctx context.Context, in *GetWithdrawalRequest, opts ...grpc.CallOption) (*GetWithdrawalResponse, error)
+	// ConfirmWithdrawal answers the one-time code challenge of a withdrawal at or above its
+	// asset's challenge threshold. Only the withdrawal's owner may confirm it.
+	ConfirmWithdrawal(ctx context.Context, in *ConfirmWithdrawalRequest, opts ...grpc.CallOption) (*ConfirmWithdrawalResponse, error)
 }
 
 type orchestratorServiceClient struct {
@@ -59,12 +63,25 @@ func (c *orchestratorServiceClient) GetWithdrawal(ctx context.Context, in *GetWi
 	return out, nil
 }
 
+func (c *orchestratorServiceClient) ConfirmWithdrawal(ctx context.Context, in *ConfirmWithdrawalRequest, opts ...grpc.CallOption) (*ConfirmWithdrawalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmWithdrawalResponse)
+	err := c.cc.Invoke(ctx, OrchestratorService_ConfirmWithdrawal_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrchestratorServiceServer is the server API for OrchestratorService service.
 // All implementations must embed UnimplementedOrchestratorServiceServer
 // for forward compatibility.
 type OrchestratorServiceServer interface {
 	CreateWithdrawal(context.Context, *CreateWithdrawalRequest) (*CreateWithdrawalResponse, error)
 	GetWithdrawal(context.Context, *GetWithdrawalRequest) (*GetWithdrawalResponse, error)
+	// ConfirmWithdrawal answers the one-time code challenge of a withdrawal at or above its
+	// asset's challenge threshold. Only the withdrawal's owner may confirm it.
+	ConfirmWithdrawal(context.Context, *ConfirmWithdrawalRequest) (*ConfirmWithdrawalResponse, error)
 	mustEmbedUnimplementedOrchestratorServiceServer()
 }
 
@@ -81,6 +98,9 @@ func (UnimplementedOrchestratorServiceServer) CreateWithdrawal(context.Context,
 func (UnimplementedOrchestratorServiceServer) GetWithdrawal(context.Context, *GetWithdrawalRequest) (*GetWithdrawalResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetWithdrawal not implemented")
 }
+func (UnimplementedOrchestratorServiceServer) ConfirmWithdrawal(context.Context, *ConfirmWithdrawalRequest) (*ConfirmWithdrawalResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmWithdrawal not implemented")
+}
 func (UnimplementedOrchestratorServiceServer) mustEmbedUnimplementedOrchestratorServiceServer() {}
 func (UnimplementedOrchestratorServiceServer) testEmbeddedByValue()                             {}
 
@@ -138,6 +158,24 @@ func _OrchestratorService_GetWithdrawal_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrchestratorService_ConfirmWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrchestratorServiceServer).ConfirmWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrchestratorService_ConfirmWithdrawal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrchestratorServiceServer).ConfirmWithdrawal(ctx, req.(*ConfirmWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrchestratorService_ServiceDesc is the grpc.ServiceDesc for OrchestratorService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +191,10 @@ var OrchestratorService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetWithdrawal",
 			Handler:    _OrchestratorService_GetWithdrawal_Handler,
 		},
+		{
+			MethodName: "ConfirmWithdrawal",
+			Handler:    _OrchestratorService_ConfirmWithdrawal_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "orchestrator/v1/orchestrator.proto",
@@ -0,0 +1,620 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: orchestrator/v1/review.proto
+
+package orchestratorv1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Review struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId    string                 `protobuf:"bytes,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Asset           string                 `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	AmountMinor     int64                  `protobuf:"varint,4,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"`
+	DestinationAddr string                 `protobuf:"bytes,5,opt,name=destination_addr,json=destinationAddr,proto3" json:"destination_addr,omitempty"`
+	// PENDING, CLAIMED, APPROVED, REJECTED, EXPIRED or STALE.
+	Status         string   `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	RiskScore      int32    `protobuf:"varint,7,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
+	RiskReason     string   `protobuf:"bytes,8,opt,name=risk_reason,json=riskReason,proto3" json:"risk_reason,omitempty"`
+	TriggeredRules []string `protobuf:"bytes,9,rep,name=triggered_rules,json=triggeredRules,proto3" json:"triggered_rules,omitempty"`
+	RulesVersion   string   `protobuf:"bytes,10,opt,name=rules_version,json=rulesVersion,proto3" json:"rules_version,omitempty"`
+	ClaimedBy      string   `protobuf:"bytes,11,opt,name=claimed_by,json=claimedBy,proto3" json:"claimed_by,omitempty"`
+	ClaimedAt      string   `protobuf:"bytes,12,opt,name=claimed_at,json=claimedAt,proto3" json:"claimed_at,omitempty"`
+	DecidedBy      string   `protobuf:"bytes,13,opt,name=decided_by,json=decidedBy,proto3" json:"decided_by,omitempty"`
+	DecidedAt      string   `protobuf:"bytes,14,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	Notes          string   `protobuf:"bytes,15,opt,name=notes,proto3" json:"notes,omitempty"`
+	// The review is auto-rejected if still open at this time.
+	DueAt         string `protobuf:"bytes,16,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	CreatedAt     string `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Review) Reset() {
+	*x = Review{}
+	mi := &file_orchestrator_v1_review_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Review) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Review) ProtoMessage() {}
+
+func (x *Review) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_review_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Review.ProtoReflect.Descriptor instead.
+func (*Review) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_review_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Review) GetWithdrawalId() string {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return ""
+}
+
+func (x *Review) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Review) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Review) GetAmountMinor() int64 {
+	if x != nil {
+		return x.AmountMinor
+	}
+	return 0
+}
+
+func (x *Review) GetDestinationAddr() string {
+	if x != nil {
+		return x.DestinationAddr
+	}
+	return ""
+}
+
+func (x *Review) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Review) GetRiskScore() int32 {
+	if x != nil {
+		return x.RiskScore
+	}
+	return 0
+}
+
+func (x *Review) GetRiskReason() string {
+	if x != nil {
+		return x.RiskReason
+	}
+	return ""
+}
+
+func (x *Review) GetTriggeredRules() []string {
+	if x != nil {
+		return x.TriggeredRules
+	}
+	return nil
+}
+
+func (x *Review) GetRulesVersion() string {
+	if x != nil {
+		return x.RulesVersion
+	}
+	return ""
+}
+
+func (x *Review) GetClaimedBy() string {
+	if x != nil {
+		return x.ClaimedBy
+	}
+	return ""
+}
+
+func (x *Review) GetClaimedAt() string {
+	if x != nil {
+		return x.ClaimedAt
+	}
+	return ""
+}
+
+func (x *Review) GetDecidedBy() string {
+	if x != nil {
+		return x.DecidedBy
+	}
+	return ""
+}
+
+func (x *Review) GetDecidedAt() string {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return ""
+}
+
+func (x *Review) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Review) GetDueAt() string {
+	if x != nil {
+		return x.DueAt
+	}
+	return ""
+}
+
+func (x *Review) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListReviewsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Empty lists open reviews (PENDING and CLAIMED).
+	Status        string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsRequest) Reset() {
+	*x = ListReviewsRequest{}
+	mi := &file_orchestrator_v1_review_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsRequest) ProtoMessage() {}
+
+func (x *ListReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_review_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_review_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListReviewsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListReviewsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*Review              `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsResponse) Reset() {
+	*x = ListReviewsResponse{}
+	mi := &file_orchestrator_v1_review_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsResponse) ProtoMessage() {}
+
+func (x *ListReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_review_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_review_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListReviewsResponse) GetReviews() []*Review {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+func (x *ListReviewsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ClaimReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId  string                 `protobuf:"bytes,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimReviewRequest) Reset() {
+	*x = ClaimReviewRequest{}
+	mi := &file_orchestrator_v1_review_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimReviewRequest) ProtoMessage() {}
+
+func (x *ClaimReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_review_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimReviewRequest.ProtoReflect.Descriptor instead.
+func (*ClaimReviewRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_review_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ClaimReviewRequest) GetWithdrawalId() string {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return ""
+}
+
+type ClaimReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClaimReviewResponse) Reset() {
+	*x = ClaimReviewResponse{}
+	mi := &file_orchestrator_v1_review_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClaimReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimReviewResponse) ProtoMessage() {}
+
+func (x *ClaimReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_review_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimReviewResponse.ProtoReflect.Descriptor instead.
+func (*ClaimReviewResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_review_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ClaimReviewResponse) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+type DecideReviewRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	WithdrawalId string                 `protobuf:"bytes,1,opt,name=withdrawal_id,json=withdrawalId,proto3" json:"withdrawal_id,omitempty"`
+	// APPROVE or REJECT.
+	Decision      string `protobuf:"bytes,2,opt,name=decision,proto3" json:"decision,omitempty"`
+	Notes         string `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideReviewRequest) Reset() {
+	*x = DecideReviewRequest{}
+	mi := &file_orchestrator_v1_review_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideReviewRequest) ProtoMessage() {}
+
+func (x *DecideReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_review_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideReviewRequest.ProtoReflect.Descriptor instead.
+func (*DecideReviewRequest) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_review_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DecideReviewRequest) GetWithdrawalId() string {
+	if x != nil {
+		return x.WithdrawalId
+	}
+	return ""
+}
+
+func (x *DecideReviewRequest) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *DecideReviewRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type DecideReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *Review                `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideReviewResponse) Reset() {
+	*x = DecideReviewResponse{}
+	mi := &file_orchestrator_v1_review_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideReviewResponse) ProtoMessage() {}
+
+func (x *DecideReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_orchestrator_v1_review_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideReviewResponse.ProtoReflect.Descriptor instead.
+func (*DecideReviewResponse) Descriptor() ([]byte, []int) {
+	return file_orchestrator_v1_review_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DecideReviewResponse) GetReview() *Review {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+var File_orchestrator_v1_review_proto protoreflect.FileDescriptor
+
+const file_orchestrator_v1_review_proto_rawDesc = "" +
+	"\n" +
+	"\x1corchestrator/v1/review.proto\x12\x16cbsaga.orchestrator.v1\x1a\x1cgoogle/api/annotations.proto\"\x98\x04\n" +
+	"\x06Review\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05asset\x18\x03 \x01(\tR\x05asset\x12!\n" +
+	"\famount_minor\x18\x04 \x01(\x03R\vamountMinor\x12)\n" +
+	"\x10destination_addr\x18\x05 \x01(\tR\x0fdestinationAddr\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"risk_score\x18\a \x01(\x05R\triskScore\x12\x1f\n" +
+	"\vrisk_reason\x18\b \x01(\tR\n" +
+	"riskReason\x12'\n" +
+	"\x0ftriggered_rules\x18\t \x03(\tR\x0etriggeredRules\x12#\n" +
+	"\rrules_version\x18\n" +
+	" \x01(\tR\frulesVersion\x12\x1d\n" +
+	"\n" +
+	"claimed_by\x18\v \x01(\tR\tclaimedBy\x12\x1d\n" +
+	"\n" +
+	"claimed_at\x18\f \x01(\tR\tclaimedAt\x12\x1d\n" +
+	"\n" +
+	"decided_by\x18\r \x01(\tR\tdecidedBy\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\x0e \x01(\tR\tdecidedAt\x12\x14\n" +
+	"\x05notes\x18\x0f \x01(\tR\x05notes\x12\x15\n" +
+	"\x06due_at\x18\x10 \x01(\tR\x05dueAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\tR\tcreatedAt\"h\n" +
+	"\x12ListReviewsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"w\n" +
+	"\x13ListReviewsResponse\x128\n" +
+	"\areviews\x18\x01 \x03(\v2\x1e.cbsaga.orchestrator.v1.ReviewR\areviews\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"9\n" +
+	"\x12ClaimReviewRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\"M\n" +
+	"\x13ClaimReviewResponse\x126\n" +
+	"\x06review\x18\x01 \x01(\v2\x1e.cbsaga.orchestrator.v1.ReviewR\x06review\"l\n" +
+	"\x13DecideReviewRequest\x12#\n" +
+	"\rwithdrawal_id\x18\x01 \x01(\tR\fwithdrawalId\x12\x1a\n" +
+	"\bdecision\x18\x02 \x01(\tR\bdecision\x12\x14\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\"N\n" +
+	"\x14DecideReviewResponse\x126\n" +
+	"\x06review\x18\x01 \x01(\v2\x1e.cbsaga.orchestrator.v1.ReviewR\x06review2\xc0\x03\n" +
+	"\rReviewService\x12{\n" +
+	"\vListReviews\x12*.cbsaga.orchestrator.v1.ListReviewsRequest\x1a+.cbsaga.orchestrator.v1.ListReviewsResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/reviews\x12\x94\x01\n" +
+	"\vClaimReview\x12*.cbsaga.orchestrator.v1.ClaimReviewRequest\x1a+.cbsaga.orchestrator.v1.ClaimReviewResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/v1/reviews/{withdrawal_id}/claim\x12\x9a\x01\n" +
+	"\fDecideReview\x12+.cbsaga.orchestrator.v1.DecideReviewRequest\x1a,.cbsaga.orchestrator.v1.DecideReviewResponse\"/\x82\xd3\xe4\x93\x02):\x01*\"$/v1/reviews/{withdrawal_id}/decisionB?Z=github.com/cicconee/cbsaga/gen/orchestrator/v1;orchestratorv1b\x06proto3"
+
+var (
+	file_orchestrator_v1_review_proto_rawDescOnce sync.Once
+	file_orchestrator_v1_review_proto_rawDescData []byte
+)
+
+func file_orchestrator_v1_review_proto_rawDescGZIP() []byte {
+	file_orchestrator_v1_review_proto_rawDescOnce.Do(func() {
+		file_orchestrator_v1_review_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_orchestrator_v1_review_proto_rawDesc), len(file_orchestrator_v1_review_proto_rawDesc)))
+	})
+	return file_orchestrator_v1_review_proto_rawDescData
+}
+
+var file_orchestrator_v1_review_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_orchestrator_v1_review_proto_goTypes = []any{
+	(*Review)(nil),               // 0: cbsaga.orchestrator.v1.Review
+	(*ListReviewsRequest)(nil),   // 1: cbsaga.orchestrator.v1.ListReviewsRequest
+	(*ListReviewsResponse)(nil),  // 2: cbsaga.orchestrator.v1.ListReviewsResponse
+	(*ClaimReviewRequest)(nil),   // 3: cbsaga.orchestrator.v1.ClaimReviewRequest
+	(*ClaimReviewResponse)(nil),  // 4: cbsaga.orchestrator.v1.ClaimReviewResponse
+	(*DecideReviewRequest)(nil),  // 5: cbsaga.orchestrator.v1.DecideReviewRequest
+	(*DecideReviewResponse)(nil), // 6: cbsaga.orchestrator.v1.DecideReviewResponse
+}
+var file_orchestrator_v1_review_proto_depIdxs = []int32{
+	0, // 0: cbsaga.orchestrator.v1.ListReviewsResponse.reviews:type_name -> cbsaga.orchestrator.v1.Review
+	0, // 1: cbsaga.orchestrator.v1.ClaimReviewResponse.review:type_name -> cbsaga.orchestrator.v1.Review
+	0, // 2: cbsaga.orchestrator.v1.DecideReviewResponse.review:type_name -> cbsaga.orchestrator.v1.Review
+	1, // 3: cbsaga.orchestrator.v1.ReviewService.ListReviews:input_type -> cbsaga.orchestrator.v1.ListReviewsRequest
+	3, // 4: cbsaga.orchestrator.v1.ReviewService.ClaimReview:input_type -> cbsaga.orchestrator.v1.ClaimReviewRequest
+	5, // 5: cbsaga.orchestrator.v1.ReviewService.DecideReview:input_type -> cbsaga.orchestrator.v1.DecideReviewRequest
+	2, // 6: cbsaga.orchestrator.v1.ReviewService.ListReviews:output_type -> cbsaga.orchestrator.v1.ListReviewsResponse
+	4, // 7: cbsaga.orchestrator.v1.ReviewService.ClaimReview:output_type -> cbsaga.orchestrator.v1.ClaimReviewResponse
+	6, // 8: cbsaga.orchestrator.v1.ReviewService.DecideReview:output_type -> cbsaga.orchestrator.v1.DecideReviewResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_orchestrator_v1_review_proto_init() }
+func file_orchestrator_v1_review_proto_init() {
+	if File_orchestrator_v1_review_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_orchestrator_v1_review_proto_rawDesc), len(file_orchestrator_v1_review_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_orchestrator_v1_review_proto_goTypes,
+		DependencyIndexes: file_orchestrator_v1_review_proto_depIdxs,
+		MessageInfos:      file_orchestrator_v1_review_proto_msgTypes,
+	}.Build()
+	File_orchestrator_v1_review_proto = out.File
+	file_orchestrator_v1_review_proto_goTypes = nil
+	file_orchestrator_v1_review_proto_depIdxs = nil
+}
@@ -0,0 +1,163 @@
+package chain
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrRejected is returned when the network refuses a transaction outright. Submitting the same
+// transaction again cannot succeed.
+var ErrRejected = errors.New("transaction rejected")
+
+// ErrUnknownNetwork is returned for a network whose model is not known. Guessing it would sign
+// an account transfer for a UTXO network, or the other way round.
+var ErrUnknownNetwork = errors.New("unknown network")
+
+// How a network tracks funds. Account networks order each sender's transactions by nonce; UTXO
+// networks spend earlier outputs in full and pay the change back.
+const (
+	ModelAccount = "account"
+	ModelUTXO    = "utxo"
+)
+
+// models are the networks this service can sign for, matching the networks of the orchestrator's
+// asset catalogue.
+var models = map[string]string{
+	"bitcoin":  ModelUTXO,
+	"ethereum": ModelAccount,
+}
+
+// Model returns how network tracks funds.
+func Model(network string) (string, error) {
+	model, ok := models[network]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownNetwork, network)
+	}
+	return model, nil
+}
+
+// Outpoint names an output of an earlier transaction.
+type Outpoint struct {
+	TxHash string `json:"tx_hash"`
+	Index  int    `json:"index"`
+}
+
+type Output struct {
+	Address     string `json:"address"`
+	AmountMinor int64  `json:"amount_minor"`
+}
+
+// UTXO is an unspent output held by an address.
+type UTXO struct {
+	Outpoint
+	AmountMinor int64 `json:"amount_minor"`
+}
+
+// Tx is an unsigned transfer. Reference ties it to its withdrawal, so two withdrawals with the
+// same amount and destination never produce the same transaction.
+//
+// On account networks Nonce is the sender's next transaction number. On UTXO networks Inputs are
+// spent in full and Outputs pay To and any change back to From; together they must balance.
+type Tx struct {
+	Network     string     `json:"network"`
+	Asset       string     `json:"asset"`
+	From        string     `json:"from"`
+	To          string     `json:"to"`
+	AmountMinor int64      `json:"amount_minor"`
+	Reference   string     `json:"reference"`
+	Nonce       int64      `json:"nonce,omitempty"`
+	Inputs      []Outpoint `json:"inputs,omitempty"`
+	Outputs     []Output   `json:"outputs,omitempty"`
+}
+
+// SigningBytes is the message a key signs for tx.
+func (tx Tx) SigningBytes() ([]byte, error) {
+	return json.Marshal(tx)
+}
+
+// SignedTx is a transaction ready to broadcast.
+type SignedTx struct {
+	Tx        Tx     `json:"tx"`
+	PublicKey []byte `json:"public_key"`
+	Signature []byte `json:"signature"`
+}
+
+// Raw encodes the transaction for the network. Signing is deterministic, so the same Tx signed
+// by the same key always encodes, and hashes, the same.
+func (s SignedTx) Raw() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+// Decode parses a raw transaction and checks its signature and sender.
+func Decode(raw []byte) (SignedTx, error) {
+	var s SignedTx
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return SignedTx{}, fmt.Errorf("%w: %v", ErrRejected, err)
+	}
+	if len(s.PublicKey) != ed25519.PublicKeySize {
+		return SignedTx{}, fmt.Errorf("%w: invalid public key", ErrRejected)
+	}
+	msg, err := s.Tx.SigningBytes()
+	if err != nil {
+		return SignedTx{}, fmt.Errorf("%w: %v", ErrRejected, err)
+	}
+	if !ed25519.Verify(s.PublicKey, msg, s.Signature) {
+		return SignedTx{}, fmt.Errorf("%w: invalid signature", ErrRejected)
+	}
+	if s.Tx.From != Address(s.PublicKey) {
+		return SignedTx{}, fmt.Errorf("%w: sender does not match key", ErrRejected)
+	}
+	return s, nil
+}
+
+// Hash identifies a raw transaction on the network.
+func Hash(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return "0x" + hex.EncodeToString(sum[:])
+}
+
+// Address derives the sending address of a public key.
+func Address(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return "sim1" + hex.EncodeToString(sum[:20])
+}
+
+// Where a transaction stands on the network.
+const (
+	TxPending  = "PENDING"  // waiting in the mempool
+	TxIncluded = "INCLUDED" // in a block of the current chain
+	TxUnknown  = "UNKNOWN"  // never seen, or dropped
+)
+
+// TxStatus reports a transaction as the network currently sees it. BlockHeight, BlockHash and
+// Confirmations are only set for included transactions; a transaction in the tip block has one
+// confirmation.
+type TxStatus struct {
+	State         string
+	BlockHeight   int64
+	BlockHash     string
+	Confirmations int64
+}
+
+// Client talks to a network. Submit must be safe to repeat with the same raw transaction and
+// returns its hash.
+type Client interface {
+	Submit(ctx context.Context, raw []byte) (string, error)
+	Status(ctx context.Context, hash string) (TxStatus, error)
+	// Nonce returns the next nonce an account network will execute for address. Transactions
+	// with higher nonces wait behind the gap.
+	Nonce(ctx context.Context, network, address string) (int64, error)
+	// UTXOs returns the outputs address can spend on a UTXO network, including change from
+	// transactions that are not yet mined.
+	UTXOs(ctx context.Context, network, asset, address string) ([]UTXO, error)
+}
+
+// Retryable reports whether submitting again may succeed.
+func Retryable(err error) bool {
+	return !errors.Is(err, ErrRejected)
+}
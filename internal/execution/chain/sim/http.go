@@ -0,0 +1,46 @@
+package sim
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type reorgRequest struct {
+	Depth int  `json:"depth"`
+	Drop  bool `json:"drop"`
+}
+
+// Handler exposes the simulator's controls for local testing:
+//
+//	POST /sim/mine                              mine a block now
+//	POST /sim/reorg {"depth":2,"drop":false}    replace the last depth blocks
+func (s *Simulator) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /sim/mine", s.handleMine)
+	mux.HandleFunc("POST /sim/reorg", s.handleReorg)
+	return mux
+}
+
+func (s *Simulator) handleMine(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]int64{"height": s.Mine()})
+}
+
+func (s *Simulator) handleReorg(w http.ResponseWriter, r *http.Request) {
+	var req reorgRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+
+	res, err := s.Reorg(req.Depth, req.Drop)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, res)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
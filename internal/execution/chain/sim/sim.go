@@ -0,0 +1,483 @@
+package sim
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/execution/chain"
+)
+
+const (
+	// fundingOutputs is how many outputs a UTXO address starts with, so coin selection has
+	// something to choose from.
+	fundingOutputs = 8
+	// defaultUTXOFunding is the starting total of a UTXO asset missing from Balances. Outputs
+	// cannot be unlimited, so it is just large.
+	defaultUTXOFunding = 1_000_000_000_000
+)
+
+type Config struct {
+	// Balances funds the sending addresses per asset. Assets missing from it are unlimited, so
+	// only the assets listed can run out. On UTXO networks the balance is split across a few
+	// starting outputs.
+	Balances map[string]int64
+}
+
+type block struct {
+	height int64
+	hash   string
+	txs    []string
+}
+
+type output struct {
+	network string
+	asset   string
+	address string
+	amount  int64
+}
+
+// Simulator is an in-process network for local runs. It checks signatures and balances and
+// accepts each transaction once; the same raw transaction submitted again returns the same hash.
+// Accepted transactions wait in a mempool until the next block is mined, and Reorg replaces
+// recent blocks to exercise confirmation tracking. There is no randomness, so a run replays
+// identically.
+//
+// Account networks execute each sender's transactions in nonce order: a nonce already used is
+// rejected, and one past the next waits, pending but unmineable, until the gap is filled. UTXO
+// networks reject inputs that are missing or already spent, so two transactions can never spend
+// the same output.
+type Simulator struct {
+	mu       sync.Mutex
+	balances map[string]int64 // "asset|address" -> balance
+	funded   map[string]int64 // asset -> starting balance per address
+	txs      map[string]chain.Tx
+	mempool  []string
+	blocks   []block
+	included map[string]int64 // tx hash -> block height
+	forks    int
+
+	nonces map[string]int64  // "network|address" -> next nonce
+	queued map[string]string // "network|address|nonce" -> tx hash waiting on a gap
+
+	outputs    map[chain.Outpoint]output
+	spent      map[string]map[chain.Outpoint]output // tx hash -> inputs it spent
+	fundedUTXO map[string]bool                      // "network|asset|address"
+}
+
+func New(cfg Config) *Simulator {
+	funded := make(map[string]int64, len(cfg.Balances))
+	for asset, amount := range cfg.Balances {
+		funded[asset] = amount
+	}
+	return &Simulator{
+		balances:   make(map[string]int64),
+		funded:     funded,
+		txs:        make(map[string]chain.Tx),
+		included:   make(map[string]int64),
+		nonces:     make(map[string]int64),
+		queued:     make(map[string]string),
+		outputs:    make(map[chain.Outpoint]output),
+		spent:      make(map[string]map[chain.Outpoint]output),
+		fundedUTXO: make(map[string]bool),
+	}
+}
+
+func (s *Simulator) Submit(ctx context.Context, raw []byte) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	signed, err := chain.Decode(raw)
+	if err != nil {
+		return "", err
+	}
+	tx := signed.Tx
+	// A zero self-transfer is how an account fills a nonce gap.
+	if tx.AmountMinor < 0 || tx.AmountMinor == 0 && tx.To != tx.From {
+		return "", fmt.Errorf("%w: amount must be positive", chain.ErrRejected)
+	}
+	model, err := chain.Model(tx.Network)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", chain.ErrRejected, err)
+	}
+
+	hash := chain.Hash(raw)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.txs[hash]; ok {
+		return hash, nil
+	}
+
+	if model == chain.ModelUTXO {
+		if err := s.spend(hash, tx); err != nil {
+			return "", err
+		}
+		s.txs[hash] = tx
+		s.mempool = append(s.mempool, hash)
+		return hash, nil
+	}
+
+	key := tx.Network + "|" + tx.From
+	next := s.nonces[key]
+	if tx.Nonce < next {
+		return "", fmt.Errorf("%w: nonce %d already used, next is %d",
+			chain.ErrRejected,
+			tx.Nonce,
+			next,
+		)
+	}
+	queueKey := fmt.Sprintf("%s|%d", key, tx.Nonce)
+	if _, ok := s.queued[queueKey]; ok {
+		return "", fmt.Errorf("%w: nonce %d already queued", chain.ErrRejected, tx.Nonce)
+	}
+
+	if start, limited := s.funded[tx.Asset]; limited {
+		balanceKey := tx.Asset + "|" + tx.From
+		balance, ok := s.balances[balanceKey]
+		if !ok {
+			balance = start
+		}
+		if balance < tx.AmountMinor {
+			return "", fmt.Errorf("%w: insufficient %s balance: have %d, need %d",
+				chain.ErrRejected,
+				tx.Asset,
+				balance,
+				tx.AmountMinor,
+			)
+		}
+		s.balances[balanceKey] = balance - tx.AmountMinor
+	}
+
+	s.txs[hash] = tx
+	if tx.Nonce > next {
+		s.queued[queueKey] = hash
+		return hash, nil
+	}
+	s.mempool = append(s.mempool, hash)
+	s.nonces[key] = next + 1
+	s.promote(key)
+	return hash, nil
+}
+
+func (s *Simulator) Status(ctx context.Context, hash string) (chain.TxStatus, error) {
+	if err := ctx.Err(); err != nil {
+		return chain.TxStatus{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.txs[hash]; !ok {
+		return chain.TxStatus{State: chain.TxUnknown}, nil
+	}
+	height, ok := s.included[hash]
+	if !ok {
+		return chain.TxStatus{State: chain.TxPending}, nil
+	}
+	return chain.TxStatus{
+		State:         chain.TxIncluded,
+		BlockHeight:   height,
+		BlockHash:     s.blocks[height-1].hash,
+		Confirmations: int64(len(s.blocks)) - height + 1,
+	}, nil
+}
+
+func (s *Simulator) Nonce(ctx context.Context, network, address string) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if model, err := chain.Model(network); err != nil || model != chain.ModelAccount {
+		return 0, fmt.Errorf("%w: %s has no nonces", chain.ErrRejected, network)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.nonces[network+"|"+address], nil
+}
+
+func (s *Simulator) UTXOs(
+	ctx context.Context,
+	network string,
+	asset string,
+	address string,
+) ([]chain.UTXO, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if model, err := chain.Model(network); err != nil || model != chain.ModelUTXO {
+		return nil, fmt.Errorf("%w: %s has no outputs", chain.ErrRejected, network)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.fund(network, asset, address)
+
+	var utxos []chain.UTXO
+	for op, out := range s.outputs {
+		if out.network == network && out.asset == asset && out.address == address {
+			utxos = append(utxos, chain.UTXO{Outpoint: op, AmountMinor: out.amount})
+		}
+	}
+	sort.Slice(utxos, func(i, j int) bool {
+		if utxos[i].TxHash != utxos[j].TxHash {
+			return utxos[i].TxHash < utxos[j].TxHash
+		}
+		return utxos[i].Index < utxos[j].Index
+	})
+	return utxos, nil
+}
+
+// Mine appends a block holding every pending transaction and returns its height. Transactions
+// queued behind a nonce gap stay out.
+func (s *Simulator) Mine() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := s.appendBlock(s.mempool)
+	s.mempool = nil
+	return b.height
+}
+
+// Run mines a block every interval until ctx is done.
+func (s *Simulator) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			s.Mine()
+		}
+	}
+}
+
+type ReorgResult struct {
+	Height   int64    `json:"height"`
+	Orphaned []string `json:"orphaned"`
+	Dropped  bool     `json:"dropped"`
+}
+
+// Reorg replaces the last depth blocks with a longer chain of empty blocks. The transactions of
+// the replaced blocks go back to the mempool and are mined again with the next block, or, with
+// drop, are forgotten and their amounts refunded, as if they had never been submitted. A dropped
+// transaction frees its nonce, so the sender's later transactions wait behind it again.
+func (s *Simulator) Reorg(depth int, drop bool) (ReorgResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if depth <= 0 || depth > len(s.blocks) {
+		return ReorgResult{}, fmt.Errorf("reorg depth must be between 1 and %d", len(s.blocks))
+	}
+
+	var orphaned []string
+	for _, b := range s.blocks[len(s.blocks)-depth:] {
+		for _, hash := range b.txs {
+			delete(s.included, hash)
+			orphaned = append(orphaned, hash)
+		}
+	}
+	s.blocks = s.blocks[:len(s.blocks)-depth]
+	s.forks++
+
+	if drop {
+		for _, hash := range orphaned {
+			s.forget(hash)
+		}
+	} else {
+		s.mempool = append(orphaned, s.mempool...)
+	}
+
+	var tip block
+	for range depth + 1 {
+		tip = s.appendBlock(nil)
+	}
+
+	return ReorgResult{Height: tip.height, Orphaned: orphaned, Dropped: drop}, nil
+}
+
+// appendBlock must be called with mu held.
+func (s *Simulator) appendBlock(txs []string) block {
+	height := int64(len(s.blocks)) + 1
+	prev := ""
+	if len(s.blocks) > 0 {
+		prev = s.blocks[len(s.blocks)-1].hash
+	}
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s|%d|%d|%s",
+		prev,
+		height,
+		s.forks,
+		strings.Join(txs, ","),
+	))
+
+	b := block{height: height, hash: "0x" + hex.EncodeToString(sum[:]), txs: txs}
+	s.blocks = append(s.blocks, b)
+	for _, hash := range txs {
+		s.included[hash] = height
+	}
+	return b
+}
+
+// promote moves transactions queued behind a filled gap into the mempool. It must be called
+// with mu held.
+func (s *Simulator) promote(key string) {
+	for {
+		next := s.nonces[key]
+		queueKey := fmt.Sprintf("%s|%d", key, next)
+		hash, ok := s.queued[queueKey]
+		if !ok {
+			return
+		}
+		delete(s.queued, queueKey)
+		s.mempool = append(s.mempool, hash)
+		s.nonces[key] = next + 1
+	}
+}
+
+// spend checks a UTXO transaction and moves its inputs to its outputs. It must be called with
+// mu held.
+func (s *Simulator) spend(hash string, tx chain.Tx) error {
+	s.fund(tx.Network, tx.Asset, tx.From)
+
+	if len(tx.Inputs) == 0 {
+		return fmt.Errorf("%w: transaction has no inputs", chain.ErrRejected)
+	}
+	var in int64
+	seen := make(map[chain.Outpoint]bool, len(tx.Inputs))
+	for _, op := range tx.Inputs {
+		out, ok := s.outputs[op]
+		if !ok || seen[op] {
+			return fmt.Errorf("%w: input %s:%d is missing or already spent",
+				chain.ErrRejected,
+				op.TxHash,
+				op.Index,
+			)
+		}
+		if out.network != tx.Network || out.asset != tx.Asset || out.address != tx.From {
+			return fmt.Errorf("%w: input %s:%d does not belong to the sender",
+				chain.ErrRejected,
+				op.TxHash,
+				op.Index,
+			)
+		}
+		seen[op] = true
+		in += out.amount
+	}
+
+	var out int64
+	paid := false
+	for _, o := range tx.Outputs {
+		if o.AmountMinor <= 0 {
+			return fmt.Errorf("%w: output amounts must be positive", chain.ErrRejected)
+		}
+		if o.Address == tx.To && o.AmountMinor == tx.AmountMinor {
+			paid = true
+		}
+		out += o.AmountMinor
+	}
+	if !paid {
+		return fmt.Errorf("%w: no output pays %d to %s", chain.ErrRejected, tx.AmountMinor, tx.To)
+	}
+	if in != out {
+		return fmt.Errorf("%w: inputs total %d but outputs total %d", chain.ErrRejected, in, out)
+	}
+
+	spent := make(map[chain.Outpoint]output, len(tx.Inputs))
+	for _, op := range tx.Inputs {
+		spent[op] = s.outputs[op]
+		delete(s.outputs, op)
+	}
+	s.spent[hash] = spent
+	for i, o := range tx.Outputs {
+		s.outputs[chain.Outpoint{TxHash: hash, Index: i}] = output{
+			network: tx.Network,
+			asset:   tx.Asset,
+			address: o.Address,
+			amount:  o.AmountMinor,
+		}
+	}
+	return nil
+}
+
+// fund gives a UTXO address its starting outputs the first time it is seen. It must be called
+// with mu held.
+func (s *Simulator) fund(network, asset, address string) {
+	key := network + "|" + asset + "|" + address
+	if s.fundedUTXO[key] {
+		return
+	}
+	s.fundedUTXO[key] = true
+
+	total, ok := s.funded[asset]
+	if !ok {
+		total = defaultUTXOFunding
+	}
+	sum := sha256.Sum256([]byte("funding|" + key))
+	hash := "0x" + hex.EncodeToString(sum[:])
+	for i := range fundingOutputs {
+		amount := total / fundingOutputs
+		if i == fundingOutputs-1 {
+			amount = total - amount*(fundingOutputs-1)
+		}
+		if amount <= 0 {
+			continue
+		}
+		s.outputs[chain.Outpoint{TxHash: hash, Index: i}] = output{
+			network: network,
+			asset:   asset,
+			address: address,
+			amount:  amount,
+		}
+	}
+}
+
+// forget must be called with mu held.
+func (s *Simulator) forget(hash string) {
+	tx, ok := s.txs[hash]
+	if !ok {
+		return
+	}
+	delete(s.txs, hash)
+
+	if model, _ := chain.Model(tx.Network); model == chain.ModelUTXO {
+		for i := range tx.Outputs {
+			delete(s.outputs, chain.Outpoint{TxHash: hash, Index: i})
+		}
+		for op, out := range s.spent[hash] {
+			s.outputs[op] = out
+		}
+		delete(s.spent, hash)
+		return
+	}
+
+	if _, limited := s.funded[tx.Asset]; limited {
+		s.balances[tx.Asset+"|"+tx.From] += tx.AmountMinor
+	}
+
+	// The nonce is free again: everything the sender sent after it waits behind the gap.
+	key := tx.Network + "|" + tx.From
+	if tx.Nonce >= s.nonces[key] {
+		return
+	}
+	s.nonces[key] = tx.Nonce
+	kept := s.mempool[:0]
+	for _, h := range s.mempool {
+		t := s.txs[h]
+		if t.Network+"|"+t.From == key && t.Nonce > tx.Nonce {
+			s.queued[fmt.Sprintf("%s|%d", key, t.Nonce)] = h
+			continue
+		}
+		kept = append(kept, h)
+	}
+	s.mempool = kept
+}
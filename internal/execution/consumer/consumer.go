@@ -0,0 +1,371 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/execution/chain"
+	"github.com/cicconee/cbsaga/internal/execution/repo"
+	"github.com/cicconee/cbsaga/internal/execution/wallet"
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/health"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/messaging"
+	"github.com/cicconee/cbsaga/internal/platform/retry"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/shared/execution"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/cicconee/cbsaga/internal/execution/consumer"
+
+// reservedWait is how long a command waits before signing again when only outputs reserved by
+// other withdrawals stand in its way.
+const reservedWait = 5 * time.Second
+
+type Consumer struct {
+	db     *pgxpool.Pool
+	repo   *repo.Repo
+	wallet *wallet.Wallet
+	chain  chain.Client
+	log    *logging.Logger
+	r      *kafka.Reader
+	tracer trace.Tracer
+	live   *health.ConsumerLiveness
+}
+
+func New(
+	db *pgxpool.Pool,
+	log *logging.Logger,
+	w *wallet.Wallet,
+	client chain.Client,
+	brokers []string,
+	groupID, topic string,
+) *Consumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		GroupID:     groupID,
+		Topic:       topic,
+		MinBytes:    1,
+		MaxBytes:    10e6, // 10mb
+		MaxWait:     500 * time.Millisecond,
+		StartOffset: kafka.LastOffset,
+	})
+
+	return &Consumer{
+		db:     db,
+		repo:   repo.New(),
+		wallet: w,
+		chain:  client,
+		log:    log,
+		r:      reader,
+		tracer: tracing.Tracer(tracerName),
+		live:   health.NewConsumerLiveness(),
+	}
+}
+
+func (c *Consumer) Liveness() *health.ConsumerLiveness {
+	return c.live
+}
+
+func (c *Consumer) Close() error {
+	return c.r.Close()
+}
+
+func (c *Consumer) Run(ctx context.Context) (err error) {
+	defer func() { c.live.Stopped(err) }()
+
+	c.log.Info("execution consumer started")
+
+	for {
+		c.live.Fetching()
+		m, err := c.r.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				c.log.Info("execution consumer stopped")
+				return nil
+			}
+			return err
+		}
+		c.live.Fetched()
+
+		started := time.Now()
+		err = c.handleMessage(ctx, m)
+		messaging.ObserveMessage("execution", m, started, err)
+		if errors.Is(err, context.Canceled) {
+			c.log.Info("execution consumer stopped")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Consumer) handleMessage(ctx context.Context, m kafka.Message) (err error) {
+	ctx, span := messaging.StartConsumerSpan(ctx, c.tracer, "execution.HandleExecuteWithdrawal", m)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	headers := messaging.NewHeaders(m.Headers)
+	traceID, ok := headers.String("trace_id")
+	if !ok || traceID == "" {
+		traceID = "local-trace-id-execution"
+	}
+	ctx = logging.WithTraceID(ctx, traceID)
+
+	req := execution.ExecuteWithdrawalPayload{}
+	err = messaging.DecodeConnectEnvelopeValid(m.Value, &req)
+	if err != nil {
+		c.log.ErrorContext(ctx, "invalid execute withdrawal command, skipping", "err", err)
+		return c.r.CommitMessages(ctx, m)
+	}
+	ctx = logging.WithWithdrawalID(ctx, req.WithdrawalID)
+
+	t, err := c.signed(ctx, req, traceID)
+	if errors.Is(err, wallet.ErrInsufficientFunds) || errors.Is(err, chain.ErrUnknownNetwork) {
+		return c.refuse(ctx, m, req, traceID, err)
+	}
+	if err != nil {
+		return err
+	}
+	// A reorged transaction is submitted again when the saga asks for it. Signing is
+	// deterministic, so it goes out with the same bytes and hash.
+	if t.Status != execution.ExecutionStatusSigned && t.Status != execution.ExecutionStatusReorged {
+		c.log.InfoContext(ctx, "withdrawal already executed", "status", t.Status)
+		return c.r.CommitMessages(ctx, m)
+	}
+
+	started := time.Now()
+	hash, err := c.submit(ctx, t)
+	submitDuration.WithLabelValues(t.Network).Observe(time.Since(started).Seconds())
+
+	res := execution.ExecutionResultPayload{
+		WithdrawalID: t.WithdrawalID,
+		UserID:       t.UserID,
+		Status:       execution.ExecutionStatusBroadcast,
+		Asset:        t.Asset,
+		Network:      t.Network,
+		FromAddr:     t.FromAddr,
+		TxHash:       t.TxHash,
+	}
+	eventType := execution.EventTypeWithdrawalBroadcast
+	switch {
+	case errors.Is(err, chain.ErrRejected):
+		reason := "execution failed: " + err.Error()
+		res = execution.ExecutionResultPayload{
+			WithdrawalID: t.WithdrawalID,
+			UserID:       t.UserID,
+			Status:       execution.ExecutionStatusFailed,
+			Asset:        t.Asset,
+			Network:      t.Network,
+			Reason:       &reason,
+		}
+		eventType = execution.EventTypeExecutionFailed
+	case err != nil:
+		// The message stays uncommitted, so the same signed transaction is submitted again on
+		// redelivery.
+		c.log.ErrorContext(ctx, "chain submit failed", "err", err, "tx_hash", t.TxHash)
+		return err
+	case hash != t.TxHash:
+		c.log.WarnContext(ctx, "chain reported a different tx hash",
+			"tx_hash", t.TxHash,
+			"chain_tx_hash", hash,
+		)
+	}
+
+	payload, err := codec.EncodeValid(&res)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	applied, err := c.repo.ResolveAndEmitTx(ctx, tx, repo.ResolveAndEmitParams{
+		WithdrawalID:    t.WithdrawalID,
+		Status:          res.Status,
+		Reason:          res.Reason,
+		At:              time.Now().UTC(),
+		OutboxEventType: eventType,
+		OutboxPayload:   string(payload),
+		TraceID:         traceID,
+		Traceparent:     tracing.Traceparent(ctx),
+		RouteKey:        execution.RouteKeyExecutionEvt,
+	})
+	if err != nil {
+		c.log.ErrorContext(ctx, "ResolveAndEmitTx failed", "err", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if err := c.r.CommitMessages(ctx, m); err != nil {
+		c.log.ErrorContext(ctx, "CommitMessages failed", "err", err)
+		return err
+	}
+
+	if !applied {
+		c.log.InfoContext(ctx, "execution result already recorded")
+		return nil
+	}
+	resultsTotal.WithLabelValues(t.Asset, res.Status).Inc()
+
+	c.log.InfoContext(ctx, "execution emitted result",
+		"status", res.Status,
+		"asset", t.Asset,
+		"network", t.Network,
+		"tx_hash", res.TxHash,
+		"event_type", eventType,
+	)
+
+	return nil
+}
+
+// signed returns the withdrawal's transaction, having the wallet build and sign it on first
+// delivery. The signed bytes are stored before anything is submitted.
+func (c *Consumer) signed(
+	ctx context.Context,
+	req execution.ExecuteWithdrawalPayload,
+	traceID string,
+) (repo.Transaction, error) {
+	t, err := c.repo.GetTransaction(ctx, c.db, req.WithdrawalID)
+	if err == nil {
+		return t, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return repo.Transaction{}, err
+	}
+
+	for {
+		t, err = c.wallet.Sign(ctx, req, traceID)
+		if !errors.Is(err, wallet.ErrFundsReserved) {
+			return t, err
+		}
+		// Not a refusal: the outputs come back once the withdrawals holding them confirm or
+		// are released, so the command waits here instead of failing.
+		c.log.WarnContext(ctx, "funds reserved by other withdrawals, waiting",
+			"err", err,
+			"wait", reservedWait,
+		)
+		if err := c.wait(ctx, reservedWait); err != nil {
+			return repo.Transaction{}, err
+		}
+	}
+}
+
+// wait pauses the loop without it counting as stalled.
+func (c *Consumer) wait(ctx context.Context, d time.Duration) error {
+	c.live.Waiting()
+	defer c.live.Resumed()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// refuse fails a withdrawal the wallet could not sign a transaction for.
+func (c *Consumer) refuse(
+	ctx context.Context,
+	m kafka.Message,
+	req execution.ExecuteWithdrawalPayload,
+	traceID string,
+	cause error,
+) error {
+	reason := "execution failed: " + cause.Error()
+	payload, err := codec.EncodeValid(&execution.ExecutionResultPayload{
+		WithdrawalID: req.WithdrawalID,
+		UserID:       req.UserID,
+		Status:       execution.ExecutionStatusFailed,
+		Asset:        req.Asset,
+		Network:      req.Network,
+		Reason:       &reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	applied, err := c.repo.FailUnsignedAndEmitTx(ctx, tx, repo.FailUnsignedParams{
+		Transaction: repo.Transaction{
+			WithdrawalID:          req.WithdrawalID,
+			UserID:                req.UserID,
+			Asset:                 req.Asset,
+			Network:               req.Network,
+			AmountMinor:           req.AmountMinor,
+			FromAddr:              c.wallet.Address(),
+			DestinationAddr:       req.DestinationAddr,
+			RequiredConfirmations: req.Confirmations,
+			TraceID:               &traceID,
+		},
+		Reason:          reason,
+		OutboxEventType: execution.EventTypeExecutionFailed,
+		OutboxPayload:   string(payload),
+		TraceID:         traceID,
+		Traceparent:     tracing.Traceparent(ctx),
+		RouteKey:        execution.RouteKeyExecutionEvt,
+	})
+	if err != nil {
+		c.log.ErrorContext(ctx, "FailUnsignedAndEmitTx failed", "err", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if err := c.r.CommitMessages(ctx, m); err != nil {
+		c.log.ErrorContext(ctx, "CommitMessages failed", "err", err)
+		return err
+	}
+
+	if !applied {
+		c.log.InfoContext(ctx, "execution result already recorded")
+		return nil
+	}
+	resultsTotal.WithLabelValues(req.Asset, execution.ExecutionStatusFailed).Inc()
+
+	c.log.WarnContext(ctx, "execution refused withdrawal",
+		"asset", req.Asset,
+		"network", req.Network,
+		"reason", reason,
+	)
+	return nil
+}
+
+func (c *Consumer) submit(ctx context.Context, t repo.Transaction) (string, error) {
+	var hash string
+	err := retry.Do(ctx, retry.Config{
+		Op:          "chain_submit",
+		MaxAttempts: 5,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		IsRetryable: chain.Retryable,
+	}, func() error {
+		var err error
+		hash, err = c.chain.Submit(ctx, t.RawTx)
+		return err
+	})
+	return hash, err
+}
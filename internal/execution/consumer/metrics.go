@@ -0,0 +1,23 @@
+package consumer
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	resultsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "execution",
+		Name:      "results_total",
+		Help:      "Withdrawal transactions by asset and outcome (BROADCAST, FAILED).",
+	}, []string{"asset", "status"})
+
+	submitDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "execution",
+		Name:      "submit_duration_seconds",
+		Help:      "Time to submit a signed transaction to the chain client, retries included.",
+		Buckets:   metrics.DurationBuckets,
+	}, []string{"network"})
+)
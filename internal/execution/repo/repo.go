@@ -0,0 +1,429 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/shared/execution"
+	"github.com/jackc/pgx/v5"
+)
+
+type Repo struct{}
+
+func New() *Repo { return &Repo{} }
+
+type Transaction struct {
+	WithdrawalID    string
+	UserID          string
+	Asset           string
+	Network         string
+	AmountMinor     int64
+	FromAddr        string
+	DestinationAddr string
+	TxHash          string
+	RawTx           []byte
+	Status          string // SIGNED | BROADCAST | CONFIRMED | REORGED | FAILED
+	FailureReason   *string
+	BroadcastAt     *time.Time
+	// RequiredConfirmations is the depth at which the transaction is final. BlockHeight and
+	// BlockHash are where it was last seen included, nil while it is not.
+	RequiredConfirmations int64
+	Confirmations         int64
+	BlockHeight           *int64
+	BlockHash             *string
+	ConfirmedAt           *time.Time
+	Reorgs                int32
+	// TraceID is the trace of the command that signed the transaction, so events emitted
+	// later by the tracker stay on the withdrawal's trace.
+	TraceID *string
+	// Nonce is the sender's transaction number on account networks, nil on UTXO networks.
+	Nonce     *int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+const transactionColumns = `
+	withdrawal_id,
+	user_id,
+	asset,
+	network,
+	amount_minor,
+	from_addr,
+	destination_addr,
+	tx_hash,
+	raw_tx,
+	status,
+	failure_reason,
+	broadcast_at,
+	required_confirmations,
+	confirmations,
+	block_height,
+	block_hash,
+	confirmed_at,
+	reorgs,
+	trace_id,
+	nonce,
+	created_at,
+	updated_at
+`
+
+func scanTransaction(row pgx.Row) (Transaction, error) {
+	var t Transaction
+	err := row.Scan(
+		&t.WithdrawalID,
+		&t.UserID,
+		&t.Asset,
+		&t.Network,
+		&t.AmountMinor,
+		&t.FromAddr,
+		&t.DestinationAddr,
+		&t.TxHash,
+		&t.RawTx,
+		&t.Status,
+		&t.FailureReason,
+		&t.BroadcastAt,
+		&t.RequiredConfirmations,
+		&t.Confirmations,
+		&t.BlockHeight,
+		&t.BlockHash,
+		&t.ConfirmedAt,
+		&t.Reorgs,
+		&t.TraceID,
+		&t.Nonce,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	return t, err
+}
+
+func (r *Repo) GetTransaction(
+	ctx context.Context,
+	db postgres.DBTX,
+	withdrawalID string,
+) (Transaction, error) {
+	return scanTransaction(db.QueryRow(ctx, `
+		SELECT `+transactionColumns+`
+		FROM execution.transactions
+		WHERE withdrawal_id = $1
+	`, withdrawalID))
+}
+
+// InsertSignedTx records a signed transaction before it is submitted. If the withdrawal already
+// has one, nothing is written and false is returned.
+func (r *Repo) InsertSignedTx(ctx context.Context, db postgres.DBTX, t Transaction) (bool, error) {
+	tag, err := db.Exec(ctx, `
+		INSERT INTO execution.transactions (
+			withdrawal_id,
+			user_id,
+			asset,
+			network,
+			amount_minor,
+			from_addr,
+			destination_addr,
+			tx_hash,
+			raw_tx,
+			status,
+			required_confirmations,
+			trace_id,
+			nonce
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (withdrawal_id) DO NOTHING
+	`,
+		t.WithdrawalID,
+		t.UserID,
+		t.Asset,
+		t.Network,
+		t.AmountMinor,
+		t.FromAddr,
+		t.DestinationAddr,
+		t.TxHash,
+		t.RawTx,
+		execution.ExecutionStatusSigned,
+		t.RequiredConfirmations,
+		t.TraceID,
+		t.Nonce,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+type FailUnsignedParams struct {
+	Transaction     Transaction
+	Reason          string
+	OutboxEventType string
+	OutboxPayload   string
+	TraceID         string
+	Traceparent     *string
+	RouteKey        string
+}
+
+// FailUnsignedAndEmitTx records a withdrawal that failed before a transaction could be signed,
+// with its outbox event, so a redelivered command does not try again. If the withdrawal already
+// has a transaction, nothing is written and false is returned.
+func (r *Repo) FailUnsignedAndEmitTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p FailUnsignedParams,
+) (bool, error) {
+	t := p.Transaction
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO execution.transactions (
+			withdrawal_id,
+			user_id,
+			asset,
+			network,
+			amount_minor,
+			from_addr,
+			destination_addr,
+			tx_hash,
+			raw_tx,
+			status,
+			failure_reason,
+			required_confirmations,
+			trace_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, '', ''::bytea, 'FAILED', $8, $9, $10)
+		ON CONFLICT (withdrawal_id) DO NOTHING
+	`,
+		t.WithdrawalID,
+		t.UserID,
+		t.Asset,
+		t.Network,
+		t.AmountMinor,
+		t.FromAddr,
+		t.DestinationAddr,
+		p.Reason,
+		t.RequiredConfirmations,
+		t.TraceID,
+	)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	err = r.insertOutboxTx(ctx, tx, t.WithdrawalID, p.OutboxEventType, p.OutboxPayload,
+		p.TraceID, p.Traceparent, p.RouteKey,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type ResolveAndEmitParams struct {
+	WithdrawalID    string
+	Status          string // BROADCAST | FAILED
+	Reason          *string
+	At              time.Time
+	OutboxEventType string
+	OutboxPayload   string
+	TraceID         string
+	Traceparent     *string
+	RouteKey        string
+}
+
+// ResolveAndEmitTx records the submission outcome of a signed or reorged transaction and its
+// outbox event. A failed transaction gives back its nonce and inputs. A transaction in any other
+// status is left untouched, nothing is emitted and false is returned.
+func (r *Repo) ResolveAndEmitTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p ResolveAndEmitParams,
+) (bool, error) {
+	tag, err := tx.Exec(ctx, `
+		UPDATE execution.transactions
+		SET
+			status = $2,
+			failure_reason = $3,
+			broadcast_at = CASE WHEN $2 = 'BROADCAST' THEN $4 ELSE broadcast_at END,
+			updated_at = $4
+		WHERE
+			withdrawal_id = $1
+			AND status IN ('SIGNED', 'REORGED')
+	`, p.WithdrawalID, p.Status, p.Reason, p.At)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if p.Status == execution.ExecutionStatusFailed {
+		if err := r.releaseTx(ctx, tx, p.WithdrawalID, p.At); err != nil {
+			return false, err
+		}
+	}
+
+	err = r.insertOutboxTx(ctx, tx, p.WithdrawalID, p.OutboxEventType, p.OutboxPayload,
+		p.TraceID, p.Traceparent, p.RouteKey,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// ListBroadcast returns broadcast transactions, least recently checked first.
+func (r *Repo) ListBroadcast(
+	ctx context.Context,
+	db postgres.DBTX,
+	limit int,
+) ([]Transaction, error) {
+	rows, err := db.Query(ctx, `
+		SELECT `+transactionColumns+`
+		FROM execution.transactions
+		WHERE status = 'BROADCAST'
+		ORDER BY updated_at ASC, withdrawal_id ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Transaction
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+type TrackParams struct {
+	WithdrawalID  string
+	Confirmations int64
+	BlockHeight   *int64
+	BlockHash     *string
+	At            time.Time
+}
+
+// Track records where a broadcast transaction was seen. It also moves the transaction to the back
+// of ListBroadcast.
+func (r *Repo) Track(ctx context.Context, db postgres.DBTX, p TrackParams) error {
+	_, err := db.Exec(ctx, `
+		UPDATE execution.transactions
+		SET
+			confirmations = $2,
+			block_height = $3,
+			block_hash = $4,
+			updated_at = $5
+		WHERE
+			withdrawal_id = $1
+			AND status = 'BROADCAST'
+	`, p.WithdrawalID, p.Confirmations, p.BlockHeight, p.BlockHash, p.At)
+	return err
+}
+
+type SettleAndEmitParams struct {
+	WithdrawalID string
+	Status       string // CONFIRMED | REORGED
+	// Confirmations, BlockHeight and BlockHash are where a confirmed transaction landed.
+	Confirmations   int64
+	BlockHeight     int64
+	BlockHash       string
+	At              time.Time
+	OutboxEventType string
+	OutboxPayload   string
+	TraceID         string
+	Traceparent     *string
+	RouteKey        string
+}
+
+// SettleAndEmitTx closes tracking of a broadcast transaction, either confirmed or reorged out,
+// and records its outbox event. A confirmed transaction's inputs are spent for good, so their
+// reservations go; a reorged one keeps them and waits for the saga to ask for a rebroadcast. A
+// transaction that is no longer BROADCAST is left untouched and false is returned.
+func (r *Repo) SettleAndEmitTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p SettleAndEmitParams,
+) (bool, error) {
+	var query string
+	var args []any
+	switch p.Status {
+	case execution.ExecutionStatusConfirmed:
+		query = `
+			UPDATE execution.transactions
+			SET
+				status = 'CONFIRMED',
+				confirmations = $2,
+				block_height = $3,
+				block_hash = $4,
+				confirmed_at = $5,
+				updated_at = $5
+			WHERE
+				withdrawal_id = $1
+				AND status = 'BROADCAST'
+		`
+		args = []any{p.WithdrawalID, p.Confirmations, p.BlockHeight, p.BlockHash, p.At}
+	case execution.ExecutionStatusReorged:
+		query = `
+			UPDATE execution.transactions
+			SET
+				status = 'REORGED',
+				confirmations = 0,
+				block_height = NULL,
+				block_hash = NULL,
+				reorgs = reorgs + 1,
+				updated_at = $2
+			WHERE
+				withdrawal_id = $1
+				AND status = 'BROADCAST'
+		`
+		args = []any{p.WithdrawalID, p.At}
+	default:
+		return false, fmt.Errorf("settle transaction: invalid status %q", p.Status)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	if p.Status == execution.ExecutionStatusConfirmed {
+		if err := r.unreserveTx(ctx, tx, p.WithdrawalID); err != nil {
+			return false, err
+		}
+	}
+
+	err = r.insertOutboxTx(ctx, tx, p.WithdrawalID, p.OutboxEventType, p.OutboxPayload,
+		p.TraceID, p.Traceparent, p.RouteKey,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *Repo) insertOutboxTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID, eventType, payload, traceID string,
+	traceparent *string,
+	routeKey string,
+) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO execution.outbox_events
+			(event_id, aggregate_type, aggregate_id, event_type, payload_json, trace_id, route_key,
+			traceparent)
+		VALUES
+			(gen_random_uuid(), 'execution', $1, $2, $3, $4, $5, $6)
+	`, withdrawalID, eventType, payload, traceID, routeKey, traceparent)
+	return err
+}
@@ -0,0 +1,223 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/execution/chain"
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// AllocateNonceTx hands out the next nonce of a sending address and holds the address's row
+// until tx ends. chainNext is the next nonce the chain expects; if the chain is ahead, e.g. the
+// key sent transactions this service never recorded, allocation skips forward to it.
+func (r *Repo) AllocateNonceTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	network, address string,
+	chainNext int64,
+) (int64, error) {
+	var nonce int64
+	err := tx.QueryRow(ctx, `
+		INSERT INTO execution.wallet_nonces (network, address, next_nonce)
+		VALUES ($1, $2, $3::bigint + 1)
+		ON CONFLICT (network, address) DO UPDATE
+		SET
+			next_nonce = GREATEST(execution.wallet_nonces.next_nonce, $3::bigint) + 1,
+			updated_at = now()
+		RETURNING next_nonce - 1
+	`, network, address, chainNext).Scan(&nonce)
+	return nonce, err
+}
+
+// releaseTx gives back what a failed transaction held: its inputs, and its nonce if nothing was
+// allocated after it.
+func (r *Repo) releaseTx(ctx context.Context, tx pgx.Tx, withdrawalID string, at time.Time) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE execution.wallet_nonces n
+		SET
+			next_nonce = t.nonce,
+			updated_at = $2
+		FROM execution.transactions t
+		WHERE
+			t.withdrawal_id = $1
+			AND t.nonce IS NOT NULL
+			AND n.network = t.network
+			AND n.address = t.from_addr
+			AND n.next_nonce = t.nonce + 1
+	`, withdrawalID, at)
+	if err != nil {
+		return err
+	}
+	return r.unreserveTx(ctx, tx, withdrawalID)
+}
+
+func (r *Repo) unreserveTx(ctx context.Context, tx pgx.Tx, withdrawalID string) error {
+	_, err := tx.Exec(ctx, `
+		DELETE FROM execution.utxo_reservations
+		WHERE withdrawal_id = $1
+	`, withdrawalID)
+	return err
+}
+
+// ReservedOutpoints returns the outputs of address that withdrawals hold as inputs.
+func (r *Repo) ReservedOutpoints(
+	ctx context.Context,
+	db postgres.DBTX,
+	network, address string,
+) (map[chain.Outpoint]bool, error) {
+	rows, err := db.Query(ctx, `
+		SELECT tx_hash, output_index
+		FROM execution.utxo_reservations
+		WHERE network = $1 AND address = $2
+	`, network, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[chain.Outpoint]bool)
+	for rows.Next() {
+		var op chain.Outpoint
+		if err := rows.Scan(&op.TxHash, &op.Index); err != nil {
+			return nil, err
+		}
+		out[op] = true
+	}
+	return out, rows.Err()
+}
+
+type ReserveInputsParams struct {
+	WithdrawalID string
+	Network      string
+	Asset        string
+	Address      string
+	Inputs       []chain.UTXO
+}
+
+// ReserveInputsTx reserves the inputs selected for a withdrawal. If another withdrawal reserved
+// any of them first, false is returned and tx must be rolled back.
+func (r *Repo) ReserveInputsTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p ReserveInputsParams,
+) (bool, error) {
+	for _, in := range p.Inputs {
+		tag, err := tx.Exec(ctx, `
+			INSERT INTO execution.utxo_reservations
+				(network, tx_hash, output_index, asset, address, amount_minor, withdrawal_id)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (network, tx_hash, output_index) DO NOTHING
+		`, p.Network, in.TxHash, in.Index, p.Asset, p.Address, in.AmountMinor, p.WithdrawalID)
+		if err != nil {
+			return false, err
+		}
+		if tag.RowsAffected() == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ReleaseAbandoned drops the reservations of transactions that failed or stayed reorged since
+// before, and returns how many were dropped.
+func (r *Repo) ReleaseAbandoned(
+	ctx context.Context,
+	db postgres.DBTX,
+	before time.Time,
+) (int64, error) {
+	tag, err := db.Exec(ctx, `
+		DELETE FROM execution.utxo_reservations u
+		USING execution.transactions t
+		WHERE
+			u.withdrawal_id = t.withdrawal_id
+			AND t.status IN ('REORGED', 'FAILED')
+			AND t.updated_at < $1
+	`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ListNonceNetworks returns the networks address has allocated nonces on.
+func (r *Repo) ListNonceNetworks(
+	ctx context.Context,
+	db postgres.DBTX,
+	address string,
+) ([]string, error) {
+	rows, err := db.Query(ctx, `
+		SELECT network
+		FROM execution.wallet_nonces
+		WHERE address = $1
+		ORDER BY network
+	`, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var network string
+		if err := rows.Scan(&network); err != nil {
+			return nil, err
+		}
+		out = append(out, network)
+	}
+	return out, rows.Err()
+}
+
+// FirstQueued returns the broadcast transaction with the lowest nonce above chainNext, the first
+// one waiting behind a gap. ok is false when nothing waits.
+func (r *Repo) FirstQueued(
+	ctx context.Context,
+	db postgres.DBTX,
+	network, address string,
+	chainNext int64,
+) (t Transaction, ok bool, err error) {
+	t, err = scanTransaction(db.QueryRow(ctx, `
+		SELECT `+transactionColumns+`
+		FROM execution.transactions
+		WHERE
+			network = $1
+			AND from_addr = $2
+			AND status = 'BROADCAST'
+			AND nonce > $3
+		ORDER BY nonce ASC
+		LIMIT 1
+	`, network, address, chainNext))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Transaction{}, false, nil
+	}
+	return t, err == nil, err
+}
+
+// NonceHeld reports whether a transaction still holds nonce: one that is signed, broadcast or
+// confirmed, or reorged since before and so still waiting for its rebroadcast.
+func (r *Repo) NonceHeld(
+	ctx context.Context,
+	db postgres.DBTX,
+	network, address string,
+	nonce int64,
+	before time.Time,
+) (bool, error) {
+	var held bool
+	err := db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM execution.transactions
+			WHERE
+				network = $1
+				AND from_addr = $2
+				AND nonce = $3
+				AND (
+					status IN ('SIGNED', 'BROADCAST', 'CONFIRMED')
+					OR (status = 'REORGED' AND updated_at >= $4)
+				)
+		)
+	`, network, address, nonce, before).Scan(&held)
+	return held, err
+}
@@ -0,0 +1,60 @@
+package signer
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cicconee/cbsaga/internal/execution/chain"
+)
+
+// Key is the hot wallet's signing key, held in process memory.
+type Key struct {
+	priv ed25519.PrivateKey
+}
+
+// Load reads a key file holding a hex-encoded 32-byte ed25519 seed.
+func Load(path string) (*Key, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := hex.DecodeString(strings.TrimSpace(string(b)))
+	if err != nil {
+		return nil, fmt.Errorf("signer: key file %s: %w", path, err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("signer: key file %s: want %d-byte seed, got %d",
+			path,
+			ed25519.SeedSize,
+			len(seed),
+		)
+	}
+	return &Key{priv: ed25519.NewKeyFromSeed(seed)}, nil
+}
+
+func (k *Key) PublicKey() ed25519.PublicKey {
+	return k.priv.Public().(ed25519.PublicKey)
+}
+
+// Address is where the wallet sends from.
+func (k *Key) Address() string {
+	return chain.Address(k.PublicKey())
+}
+
+func (k *Key) Sign(tx chain.Tx) (chain.SignedTx, error) {
+	if tx.From != k.Address() {
+		return chain.SignedTx{}, fmt.Errorf("signer: tx is from %s, not %s", tx.From, k.Address())
+	}
+	msg, err := tx.SigningBytes()
+	if err != nil {
+		return chain.SignedTx{}, err
+	}
+	return chain.SignedTx{
+		Tx:        tx,
+		PublicKey: k.PublicKey(),
+		Signature: ed25519.Sign(k.priv, msg),
+	}, nil
+}
@@ -0,0 +1,23 @@
+package tracker
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	trackedTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "execution",
+		Name:      "tracked_total",
+		Help:      "Broadcast transactions by asset and tracking outcome.",
+	}, []string{"asset", "outcome"})
+
+	confirmationDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "execution",
+		Name:      "confirmation_duration_seconds",
+		Help:      "Time from broadcast until a transaction reaches its confirmation depth.",
+		Buckets:   metrics.DurationBuckets,
+	}, []string{"asset"})
+)
@@ -0,0 +1,280 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/execution/chain"
+	"github.com/cicconee/cbsaga/internal/execution/repo"
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/shared/execution"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/cicconee/cbsaga/internal/execution/tracker"
+
+const (
+	outcomeConfirmed = "confirmed"
+	outcomeReorged   = "reorged"
+	outcomeDropped   = "dropped"
+	outcomeMoved     = "moved"
+)
+
+type Config struct {
+	// Interval is how often broadcast transactions are checked.
+	Interval time.Duration
+	// BatchSize caps the transactions checked per interval; the least recently checked go
+	// first.
+	BatchSize int
+}
+
+// Tracker follows broadcast transactions on the chain until they are final. A transaction that
+// reaches its confirmation depth emits WithdrawalConfirmed. One that leaves the chain, reorged
+// back into the mempool or dropped altogether, emits WithdrawalReorged and waits for the saga to
+// rebroadcast it. Only a dropped transaction lets the saga give up, since one in the mempool can
+// still be mined.
+type Tracker struct {
+	db     *pgxpool.Pool
+	repo   *repo.Repo
+	chain  chain.Client
+	log    *logging.Logger
+	tracer trace.Tracer
+	cfg    Config
+}
+
+func New(db *pgxpool.Pool, log *logging.Logger, client chain.Client, cfg Config) *Tracker {
+	return &Tracker{
+		db:     db,
+		repo:   repo.New(),
+		chain:  client,
+		log:    log,
+		tracer: tracing.Tracer(tracerName),
+		cfg:    cfg,
+	}
+}
+
+// Run checks broadcast transactions every interval until ctx is done.
+func (t *Tracker) Run(ctx context.Context) {
+	tk := time.NewTicker(t.cfg.Interval)
+	defer tk.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tk.C:
+			n, err := t.Poll(ctx)
+			if err != nil {
+				t.log.ErrorContext(ctx, "confirmation tracking failed", "err", err)
+				continue
+			}
+			if n > 0 {
+				t.log.InfoContext(ctx, "broadcast transactions settled", "count", n)
+			}
+		}
+	}
+}
+
+// Poll checks one batch of broadcast transactions and returns how many were confirmed or
+// reorged out.
+func (t *Tracker) Poll(ctx context.Context) (int, error) {
+	ctx, span := t.tracer.Start(ctx, "tracker.Poll")
+	defer span.End()
+
+	txs, err := t.repo.ListBroadcast(ctx, t.db, t.cfg.BatchSize)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return 0, err
+	}
+
+	settled := 0
+	for _, bt := range txs {
+		ok, err := t.check(ctx, bt)
+		if err != nil {
+			// One transaction the chain cannot answer for must not hold up the rest.
+			t.log.ErrorContext(ctx, "confirmation check failed",
+				"err", err,
+				"withdrawal_id", bt.WithdrawalID,
+				"tx_hash", bt.TxHash,
+			)
+			continue
+		}
+		if ok {
+			settled++
+		}
+	}
+	return settled, nil
+}
+
+func (t *Tracker) check(ctx context.Context, bt repo.Transaction) (bool, error) {
+	traceID := tracing.TraceID(ctx)
+	if bt.TraceID != nil && *bt.TraceID != "" {
+		traceID = *bt.TraceID
+	}
+	if traceID == "" {
+		traceID = uuid.NewString()
+	}
+	ctx = logging.WithTraceID(ctx, traceID)
+	ctx = logging.WithWithdrawalID(ctx, bt.WithdrawalID)
+
+	st, err := t.chain.Status(ctx, bt.TxHash)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now().UTC()
+
+	switch st.State {
+	case chain.TxIncluded:
+		if bt.BlockHash != nil && *bt.BlockHash != st.BlockHash {
+			// Reorged into another block: still on chain, but the depth starts over.
+			trackedTotal.WithLabelValues(bt.Asset, outcomeMoved).Inc()
+			t.log.WarnContext(ctx, "transaction moved to another block",
+				"tx_hash", bt.TxHash,
+				"old_block_height", *bt.BlockHeight,
+				"block_height", st.BlockHeight,
+			)
+		}
+		if st.Confirmations >= bt.RequiredConfirmations {
+			return t.confirm(ctx, bt, st, traceID, now)
+		}
+		return false, t.repo.Track(ctx, t.db, repo.TrackParams{
+			WithdrawalID:  bt.WithdrawalID,
+			Confirmations: st.Confirmations,
+			BlockHeight:   &st.BlockHeight,
+			BlockHash:     &st.BlockHash,
+			At:            now,
+		})
+
+	case chain.TxPending:
+		if bt.BlockHeight != nil {
+			reason := fmt.Sprintf("transaction reorged out of block %d", *bt.BlockHeight)
+			return t.reorg(ctx, bt, outcomeReorged, reason, traceID, now)
+		}
+		return false, t.repo.Track(ctx, t.db, repo.TrackParams{
+			WithdrawalID: bt.WithdrawalID,
+			At:           now,
+		})
+
+	case chain.TxUnknown:
+		reason := "transaction dropped from the mempool"
+		if bt.BlockHeight != nil {
+			reason = fmt.Sprintf("transaction dropped after a reorg of block %d", *bt.BlockHeight)
+		}
+		return t.reorg(ctx, bt, outcomeDropped, reason, traceID, now)
+
+	default:
+		return false, fmt.Errorf("unexpected chain state %q", st.State)
+	}
+}
+
+func (t *Tracker) confirm(
+	ctx context.Context,
+	bt repo.Transaction,
+	st chain.TxStatus,
+	traceID string,
+	at time.Time,
+) (bool, error) {
+	payload, err := codec.EncodeValid(&execution.ConfirmationPayload{
+		WithdrawalID:  bt.WithdrawalID,
+		UserID:        bt.UserID,
+		Asset:         bt.Asset,
+		Network:       bt.Network,
+		TxHash:        bt.TxHash,
+		BlockHeight:   st.BlockHeight,
+		BlockHash:     st.BlockHash,
+		Confirmations: st.Confirmations,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	applied, err := t.settle(ctx, repo.SettleAndEmitParams{
+		WithdrawalID:    bt.WithdrawalID,
+		Status:          execution.ExecutionStatusConfirmed,
+		Confirmations:   st.Confirmations,
+		BlockHeight:     st.BlockHeight,
+		BlockHash:       st.BlockHash,
+		At:              at,
+		OutboxEventType: execution.EventTypeWithdrawalConfirmed,
+		OutboxPayload:   string(payload),
+		TraceID:         traceID,
+		Traceparent:     tracing.Traceparent(ctx),
+		RouteKey:        execution.RouteKeyExecutionEvt,
+	})
+	if err != nil || !applied {
+		return false, err
+	}
+
+	trackedTotal.WithLabelValues(bt.Asset, outcomeConfirmed).Inc()
+	if bt.BroadcastAt != nil {
+		confirmationDuration.WithLabelValues(bt.Asset).Observe(at.Sub(*bt.BroadcastAt).Seconds())
+	}
+	t.log.InfoContext(ctx, "transaction confirmed",
+		"tx_hash", bt.TxHash,
+		"block_height", st.BlockHeight,
+		"confirmations", st.Confirmations,
+	)
+	return true, nil
+}
+
+func (t *Tracker) reorg(
+	ctx context.Context,
+	bt repo.Transaction,
+	outcome string,
+	reason string,
+	traceID string,
+	at time.Time,
+) (bool, error) {
+	payload, err := codec.EncodeValid(&execution.ReorgPayload{
+		WithdrawalID: bt.WithdrawalID,
+		UserID:       bt.UserID,
+		Asset:        bt.Asset,
+		Network:      bt.Network,
+		TxHash:       bt.TxHash,
+		Reason:       reason,
+		Dropped:      outcome == outcomeDropped,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	applied, err := t.settle(ctx, repo.SettleAndEmitParams{
+		WithdrawalID:    bt.WithdrawalID,
+		Status:          execution.ExecutionStatusReorged,
+		At:              at,
+		OutboxEventType: execution.EventTypeWithdrawalReorged,
+		OutboxPayload:   string(payload),
+		TraceID:         traceID,
+		Traceparent:     tracing.Traceparent(ctx),
+		RouteKey:        execution.RouteKeyExecutionEvt,
+	})
+	if err != nil || !applied {
+		return false, err
+	}
+
+	trackedTotal.WithLabelValues(bt.Asset, outcome).Inc()
+	t.log.WarnContext(ctx, "transaction left the chain",
+		"tx_hash", bt.TxHash,
+		"reason", reason,
+		"reorgs", bt.Reorgs+1,
+	)
+	return true, nil
+}
+
+func (t *Tracker) settle(ctx context.Context, p repo.SettleAndEmitParams) (bool, error) {
+	var applied bool
+	err := postgres.WithTx(ctx, t.db, pgx.TxOptions{}, "execution_settle",
+		func(ctx context.Context, tx pgx.Tx) error {
+			var err error
+			applied, err = t.repo.SettleAndEmitTx(ctx, tx, p)
+			return err
+		})
+	return applied, err
+}
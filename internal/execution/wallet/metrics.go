@@ -0,0 +1,22 @@
+package wallet
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	gapsFilledTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "execution",
+		Name:      "nonce_gaps_filled_total",
+		Help:      "Nonce gaps filled with a zero self-transfer, by network.",
+	}, []string{"network"})
+
+	releasedTotal = metrics.Factory.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "execution",
+		Name:      "utxo_reservations_released_total",
+		Help:      "Input reservations released from transactions that failed or were given up.",
+	})
+)
@@ -0,0 +1,61 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cicconee/cbsaga/internal/execution/chain"
+)
+
+type selection struct {
+	inputs []chain.UTXO
+	change int64
+}
+
+// selectCoins picks the inputs that pay amount. The smallest output that covers it alone is
+// preferred, leaving the least change; otherwise the largest outputs are combined, so a withdrawal
+// spends as few inputs as possible. reserved is the total of the outputs other withdrawals hold;
+// when only those stand in the way, the shortfall is temporary and ErrFundsReserved is returned.
+func selectCoins(utxos []chain.UTXO, reserved, amount int64) (selection, error) {
+	sorted := make([]chain.UTXO, len(utxos))
+	copy(sorted, utxos)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].AmountMinor != sorted[j].AmountMinor {
+			return sorted[i].AmountMinor < sorted[j].AmountMinor
+		}
+		if sorted[i].TxHash != sorted[j].TxHash {
+			return sorted[i].TxHash < sorted[j].TxHash
+		}
+		return sorted[i].Index < sorted[j].Index
+	})
+
+	for _, u := range sorted {
+		if u.AmountMinor >= amount {
+			return selection{inputs: []chain.UTXO{u}, change: u.AmountMinor - amount}, nil
+		}
+	}
+
+	var sel selection
+	var total int64
+	for i := len(sorted) - 1; i >= 0; i-- {
+		sel.inputs = append(sel.inputs, sorted[i])
+		total += sorted[i].AmountMinor
+		if total >= amount {
+			sel.change = total - amount
+			return sel, nil
+		}
+	}
+	if total+reserved >= amount {
+		return selection{}, fmt.Errorf("%w: unreserved outputs total %d, reserved %d, need %d",
+			ErrFundsReserved,
+			total,
+			reserved,
+			amount,
+		)
+	}
+	return selection{}, fmt.Errorf("%w: outputs total %d, need %d",
+		ErrInsufficientFunds,
+		total+reserved,
+		amount,
+	)
+}
@@ -0,0 +1,120 @@
+package wallet
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cicconee/cbsaga/internal/execution/chain"
+)
+
+func utxo(hash string, index int, amount int64) chain.UTXO {
+	return chain.UTXO{Outpoint: chain.Outpoint{TxHash: hash, Index: index}, AmountMinor: amount}
+}
+
+func TestSelectCoins(t *testing.T) {
+	tests := []struct {
+		name     string
+		utxos    []chain.UTXO
+		reserved int64
+		amount   int64
+		inputs   []chain.UTXO
+		change   int64
+	}{
+		{
+			name:   "smallest single output that covers the amount",
+			utxos:  []chain.UTXO{utxo("a", 0, 500), utxo("b", 0, 80), utxo("c", 0, 120)},
+			amount: 100,
+			inputs: []chain.UTXO{utxo("c", 0, 120)},
+			change: 20,
+		},
+		{
+			name:   "exact match leaves no change",
+			utxos:  []chain.UTXO{utxo("a", 0, 150), utxo("b", 0, 100)},
+			amount: 100,
+			inputs: []chain.UTXO{utxo("b", 0, 100)},
+			change: 0,
+		},
+		{
+			name: "largest outputs combined",
+			utxos: []chain.UTXO{
+				utxo("a", 0, 10), utxo("b", 0, 60), utxo("c", 0, 30), utxo("d", 0, 50),
+			},
+			amount: 100,
+			inputs: []chain.UTXO{utxo("b", 0, 60), utxo("d", 0, 50)},
+			change: 10,
+		},
+		{
+			name:   "all outputs combined",
+			utxos:  []chain.UTXO{utxo("a", 0, 40), utxo("b", 0, 35), utxo("c", 0, 25)},
+			amount: 100,
+			inputs: []chain.UTXO{utxo("a", 0, 40), utxo("b", 0, 35), utxo("c", 0, 25)},
+			change: 0,
+		},
+		{
+			name:     "reserved outputs do not matter when the rest cover it",
+			utxos:    []chain.UTXO{utxo("a", 0, 70), utxo("b", 0, 40)},
+			reserved: 500,
+			amount:   100,
+			inputs:   []chain.UTXO{utxo("a", 0, 70), utxo("b", 0, 40)},
+			change:   10,
+		},
+		{
+			name:   "equal amounts ordered by outpoint",
+			utxos:  []chain.UTXO{utxo("b", 1, 100), utxo("b", 0, 100), utxo("a", 2, 100)},
+			amount: 100,
+			inputs: []chain.UTXO{utxo("a", 2, 100)},
+			change: 0,
+		},
+	}
+	for _, tc := range tests {
+		sel, err := selectCoins(tc.utxos, tc.reserved, tc.amount)
+		if err != nil {
+			t.Errorf("%s: selectCoins: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(sel.inputs, tc.inputs) {
+			t.Errorf("%s: inputs = %v, want %v", tc.name, sel.inputs, tc.inputs)
+		}
+		if sel.change != tc.change {
+			t.Errorf("%s: change = %d, want %d", tc.name, sel.change, tc.change)
+		}
+	}
+}
+
+func TestSelectCoinsShortfall(t *testing.T) {
+	tests := []struct {
+		name     string
+		utxos    []chain.UTXO
+		reserved int64
+		amount   int64
+		want     error
+	}{
+		{"no outputs", nil, 0, 100, ErrInsufficientFunds},
+		{
+			"outputs short",
+			[]chain.UTXO{utxo("a", 0, 40), utxo("b", 0, 50)}, 0, 100,
+			ErrInsufficientFunds,
+		},
+		{
+			"reserved outputs still short",
+			[]chain.UTXO{utxo("a", 0, 40)}, 50, 100,
+			ErrInsufficientFunds,
+		},
+		{
+			"reserved outputs cover the rest",
+			[]chain.UTXO{utxo("a", 0, 40)}, 60, 100,
+			ErrFundsReserved,
+		},
+		{"only reserved outputs", nil, 100, 100, ErrFundsReserved},
+	}
+	for _, tc := range tests {
+		sel, err := selectCoins(tc.utxos, tc.reserved, tc.amount)
+		if !errors.Is(err, tc.want) {
+			t.Errorf("%s: selectCoins error = %v, want %v", tc.name, err, tc.want)
+		}
+		if len(sel.inputs) != 0 || sel.change != 0 {
+			t.Errorf("%s: selection = %+v, want empty", tc.name, sel)
+		}
+	}
+}
@@ -0,0 +1,453 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/execution/chain"
+	"github.com/cicconee/cbsaga/internal/execution/repo"
+	"github.com/cicconee/cbsaga/internal/execution/signer"
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/retry"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/shared/execution"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/cicconee/cbsaga/internal/execution/wallet"
+
+var (
+	// ErrInsufficientFunds means the address's outputs, reserved or not, cannot cover a
+	// withdrawal.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrFundsReserved means the outputs that would cover a withdrawal are reserved by others
+	// until their transactions confirm or fail; trying again later may succeed.
+	ErrFundsReserved = errors.New("funds reserved by other withdrawals")
+)
+
+var (
+	errAlreadySigned = errors.New("withdrawal already signed")
+	errInputsTaken   = errors.New("selected inputs reserved by another withdrawal")
+)
+
+type Config struct {
+	// Interval is how often nonce gaps and abandoned reservations are looked for.
+	Interval time.Duration
+	// ReleaseAfter is how long a reorged transaction may wait for its rebroadcast before the
+	// wallet gives up its nonce and inputs.
+	ReleaseAfter time.Duration
+}
+
+// Wallet keeps the hot wallet's spending state next to the transactions it signs. On account
+// networks it hands out each sender's nonces in order, so concurrent withdrawals never collide;
+// on UTXO networks it selects inputs, pays the change back, and reserves the inputs until the
+// transaction confirms or fails.
+//
+// Recover repairs what failures leave behind. A nonce whose transaction failed or was given up
+// stalls every later transaction of its sender, so it is filled with a zero self-transfer; the
+// inputs of a given up transaction are released.
+type Wallet struct {
+	db     *pgxpool.Pool
+	repo   *repo.Repo
+	key    *signer.Key
+	chain  chain.Client
+	log    *logging.Logger
+	tracer trace.Tracer
+	cfg    Config
+}
+
+func New(
+	db *pgxpool.Pool,
+	log *logging.Logger,
+	key *signer.Key,
+	client chain.Client,
+	cfg Config,
+) *Wallet {
+	return &Wallet{
+		db:     db,
+		repo:   repo.New(),
+		key:    key,
+		chain:  client,
+		log:    log,
+		tracer: tracing.Tracer(tracerName),
+		cfg:    cfg,
+	}
+}
+
+// Address is the hot wallet's sending address.
+func (w *Wallet) Address() string {
+	return w.key.Address()
+}
+
+// Sign builds the withdrawal's transaction, signs it and records it together with the nonce or
+// inputs it takes. If the withdrawal was signed meanwhile, the recorded transaction is returned.
+func (w *Wallet) Sign(
+	ctx context.Context,
+	req execution.ExecuteWithdrawalPayload,
+	traceID string,
+) (repo.Transaction, error) {
+	ctx, span := w.tracer.Start(ctx, "wallet.Sign")
+	defer span.End()
+
+	model, err := chain.Model(req.Network)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return repo.Transaction{}, err
+	}
+
+	var t repo.Transaction
+	err = retry.Do(ctx, retry.Config{
+		Op:          "wallet_sign",
+		MaxAttempts: 5,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		IsRetryable: func(err error) bool {
+			return errors.Is(err, errInputsTaken) || errors.Is(err, ErrFundsReserved)
+		},
+	}, func() error {
+		var err error
+		if model == chain.ModelUTXO {
+			t, err = w.signUTXO(ctx, req, traceID)
+		} else {
+			t, err = w.signAccount(ctx, req, traceID)
+		}
+		return err
+	})
+	if errors.Is(err, errAlreadySigned) {
+		return w.repo.GetTransaction(ctx, w.db, req.WithdrawalID)
+	}
+	if err != nil {
+		tracing.RecordError(span, err)
+	}
+	return t, err
+}
+
+func (w *Wallet) signAccount(
+	ctx context.Context,
+	req execution.ExecuteWithdrawalPayload,
+	traceID string,
+) (repo.Transaction, error) {
+	from := w.key.Address()
+	chainNext, err := w.nonce(ctx, req.Network, from)
+	if err != nil {
+		return repo.Transaction{}, err
+	}
+
+	var t repo.Transaction
+	err = postgres.WithTx(ctx, w.db, pgx.TxOptions{}, "wallet_sign",
+		func(ctx context.Context, tx pgx.Tx) error {
+			nonce, err := w.repo.AllocateNonceTx(ctx, tx, req.Network, from, chainNext)
+			if err != nil {
+				return err
+			}
+			t, err = w.record(ctx, tx, req, traceID, chain.Tx{
+				Network:     req.Network,
+				Asset:       req.Asset,
+				From:        from,
+				To:          req.DestinationAddr,
+				AmountMinor: req.AmountMinor,
+				Reference:   req.WithdrawalID,
+				Nonce:       nonce,
+			})
+			return err
+		})
+	if err != nil {
+		return repo.Transaction{}, err
+	}
+
+	w.log.InfoContext(ctx, "nonce allocated", "network", req.Network, "nonce", *t.Nonce)
+	return t, nil
+}
+
+func (w *Wallet) signUTXO(
+	ctx context.Context,
+	req execution.ExecuteWithdrawalPayload,
+	traceID string,
+) (repo.Transaction, error) {
+	from := w.key.Address()
+	utxos, err := w.utxos(ctx, req.Network, req.Asset, from)
+	if err != nil {
+		return repo.Transaction{}, err
+	}
+
+	var t repo.Transaction
+	var sel selection
+	err = postgres.WithTx(ctx, w.db, pgx.TxOptions{}, "wallet_sign",
+		func(ctx context.Context, tx pgx.Tx) error {
+			reserved, err := w.repo.ReservedOutpoints(ctx, tx, req.Network, from)
+			if err != nil {
+				return err
+			}
+			free := make([]chain.UTXO, 0, len(utxos))
+			var held int64
+			for _, u := range utxos {
+				if reserved[u.Outpoint] {
+					held += u.AmountMinor
+					continue
+				}
+				free = append(free, u)
+			}
+
+			sel, err = selectCoins(free, held, req.AmountMinor)
+			if err != nil {
+				return err
+			}
+
+			outputs := []chain.Output{{Address: req.DestinationAddr, AmountMinor: req.AmountMinor}}
+			if sel.change > 0 {
+				outputs = append(outputs, chain.Output{Address: from, AmountMinor: sel.change})
+			}
+			inputs := make([]chain.Outpoint, len(sel.inputs))
+			for i, in := range sel.inputs {
+				inputs[i] = in.Outpoint
+			}
+
+			t, err = w.record(ctx, tx, req, traceID, chain.Tx{
+				Network:     req.Network,
+				Asset:       req.Asset,
+				From:        from,
+				To:          req.DestinationAddr,
+				AmountMinor: req.AmountMinor,
+				Reference:   req.WithdrawalID,
+				Inputs:      inputs,
+				Outputs:     outputs,
+			})
+			if err != nil {
+				return err
+			}
+
+			ok, err := w.repo.ReserveInputsTx(ctx, tx, repo.ReserveInputsParams{
+				WithdrawalID: req.WithdrawalID,
+				Network:      req.Network,
+				Asset:        req.Asset,
+				Address:      from,
+				Inputs:       sel.inputs,
+			})
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return errInputsTaken
+			}
+			return nil
+		})
+	if err != nil {
+		return repo.Transaction{}, err
+	}
+
+	w.log.InfoContext(ctx, "inputs selected",
+		"network", req.Network,
+		"inputs", len(sel.inputs),
+		"change_minor", sel.change,
+	)
+	return t, nil
+}
+
+// record signs stx and stores it as the withdrawal's transaction.
+func (w *Wallet) record(
+	ctx context.Context,
+	tx pgx.Tx,
+	req execution.ExecuteWithdrawalPayload,
+	traceID string,
+	stx chain.Tx,
+) (repo.Transaction, error) {
+	signed, err := w.key.Sign(stx)
+	if err != nil {
+		return repo.Transaction{}, err
+	}
+	raw, err := signed.Raw()
+	if err != nil {
+		return repo.Transaction{}, err
+	}
+
+	t := repo.Transaction{
+		WithdrawalID:          req.WithdrawalID,
+		UserID:                req.UserID,
+		Asset:                 req.Asset,
+		Network:               req.Network,
+		AmountMinor:           req.AmountMinor,
+		FromAddr:              stx.From,
+		DestinationAddr:       req.DestinationAddr,
+		TxHash:                chain.Hash(raw),
+		RawTx:                 raw,
+		Status:                execution.ExecutionStatusSigned,
+		RequiredConfirmations: req.Confirmations,
+		TraceID:               &traceID,
+	}
+	if model, _ := chain.Model(stx.Network); model == chain.ModelAccount {
+		t.Nonce = &stx.Nonce
+	}
+
+	inserted, err := w.repo.InsertSignedTx(ctx, tx, t)
+	if err != nil {
+		return repo.Transaction{}, err
+	}
+	if !inserted {
+		// Roll back, so the nonce or inputs taken for this copy are not kept.
+		return repo.Transaction{}, errAlreadySigned
+	}
+	return t, nil
+}
+
+// Run looks for nonce gaps and abandoned reservations every interval until ctx is done.
+func (w *Wallet) Run(ctx context.Context) {
+	tk := time.NewTicker(w.cfg.Interval)
+	defer tk.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tk.C:
+			if err := w.Recover(ctx); err != nil {
+				w.log.ErrorContext(ctx, "wallet recovery failed", "err", err)
+			}
+		}
+	}
+}
+
+// Recover releases the inputs of transactions that failed or were given up, and fills nonce gaps
+// that later transactions wait behind.
+func (w *Wallet) Recover(ctx context.Context) error {
+	ctx, span := w.tracer.Start(ctx, "wallet.Recover")
+	defer span.End()
+
+	before := time.Now().UTC().Add(-w.cfg.ReleaseAfter)
+	released, err := w.repo.ReleaseAbandoned(ctx, w.db, before)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	if released > 0 {
+		releasedTotal.Add(float64(released))
+		w.log.WarnContext(ctx, "released inputs of abandoned transactions", "count", released)
+	}
+
+	from := w.key.Address()
+	networks, err := w.repo.ListNonceNetworks(ctx, w.db, from)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return err
+	}
+	for _, network := range networks {
+		if err := w.fillGaps(ctx, network, from, before); err != nil {
+			// One network the chain cannot answer for must not hold up the rest.
+			w.log.ErrorContext(ctx, "nonce gap check failed", "err", err, "network", network)
+		}
+	}
+	return nil
+}
+
+// fillGaps sends a zero self-transfer at every nonce that broadcast transactions wait behind and
+// that no transaction holds anymore.
+func (w *Wallet) fillGaps(ctx context.Context, network, address string, before time.Time) error {
+	chainNext, err := w.nonce(ctx, network, address)
+	if err != nil {
+		return err
+	}
+	queued, ok, err := w.repo.FirstQueued(ctx, w.db, network, address, chainNext)
+	if err != nil || !ok {
+		return err
+	}
+
+	for nonce := chainNext; nonce < *queued.Nonce; nonce++ {
+		held, err := w.repo.NonceHeld(ctx, w.db, network, address, nonce, before)
+		if err != nil {
+			return err
+		}
+		if held {
+			continue
+		}
+
+		hash, err := w.fill(ctx, network, queued.Asset, address, nonce)
+		if errors.Is(err, chain.ErrRejected) {
+			// Something else took the nonce meanwhile; the gap is closed either way.
+			w.log.InfoContext(ctx, "nonce gap closed before it was filled",
+				"network", network,
+				"nonce", nonce,
+				"err", err,
+			)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		gapsFilledTotal.WithLabelValues(network).Inc()
+		w.log.WarnContext(ctx, "nonce gap filled",
+			"network", network,
+			"nonce", nonce,
+			"tx_hash", hash,
+			"queued_withdrawal_id", queued.WithdrawalID,
+			"queued_nonce", *queued.Nonce,
+		)
+	}
+	return nil
+}
+
+// fill submits a zero self-transfer at nonce. It signs the same bytes for the same gap, so
+// filling a gap twice is harmless.
+func (w *Wallet) fill(
+	ctx context.Context,
+	network, asset, address string,
+	nonce int64,
+) (string, error) {
+	signed, err := w.key.Sign(chain.Tx{
+		Network:   network,
+		Asset:     asset,
+		From:      address,
+		To:        address,
+		Reference: fmt.Sprintf("nonce-gap:%d", nonce),
+		Nonce:     nonce,
+	})
+	if err != nil {
+		return "", err
+	}
+	raw, err := signed.Raw()
+	if err != nil {
+		return "", err
+	}
+
+	var hash string
+	err = w.callChain(ctx, "chain_fill_gap", func() error {
+		var err error
+		hash, err = w.chain.Submit(ctx, raw)
+		return err
+	})
+	return hash, err
+}
+
+func (w *Wallet) nonce(ctx context.Context, network, address string) (int64, error) {
+	var next int64
+	err := w.callChain(ctx, "chain_nonce", func() error {
+		var err error
+		next, err = w.chain.Nonce(ctx, network, address)
+		return err
+	})
+	return next, err
+}
+
+func (w *Wallet) utxos(ctx context.Context, network, asset, address string) ([]chain.UTXO, error) {
+	var utxos []chain.UTXO
+	err := w.callChain(ctx, "chain_utxos", func() error {
+		var err error
+		utxos, err = w.chain.UTXOs(ctx, network, asset, address)
+		return err
+	})
+	return utxos, err
+}
+
+func (w *Wallet) callChain(ctx context.Context, op string, fn func() error) error {
+	return retry.Do(ctx, retry.Config{
+		Op:          op,
+		MaxAttempts: 5,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		IsRetryable: chain.Retryable,
+	}, fn)
+}
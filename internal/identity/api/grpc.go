@@ -0,0 +1,12 @@
+package api
+
+import (
+	identityv1 "github.com/cicconee/cbsaga/gen/identity/v1"
+	"github.com/cicconee/cbsaga/internal/identity/app"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"google.golang.org/grpc"
+)
+
+func Register(gs *grpc.Server, svc *app.Service, log *logging.Logger) {
+	identityv1.RegisterIdentityServiceServer(gs, NewHandler(svc, log))
+}
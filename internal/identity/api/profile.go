@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	identityv1 "github.com/cicconee/cbsaga/gen/identity/v1"
+	"github.com/cicconee/cbsaga/internal/identity/app"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Handler struct {
+	identityv1.UnimplementedIdentityServiceServer
+	svc *app.Service
+	log *logging.Logger
+}
+
+func NewHandler(svc *app.Service, log *logging.Logger) *Handler {
+	return &Handler{svc: svc, log: log}
+}
+
+func (h *Handler) GetProfile(
+	ctx context.Context,
+	req *identityv1.GetProfileRequest,
+) (*identityv1.GetProfileResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	p, err := h.svc.GetProfile(ctx, req.GetUserId(), principal)
+	if err != nil {
+		return nil, h.toStatus(ctx, "GetProfile", err)
+	}
+
+	return &identityv1.GetProfileResponse{Profile: toProfilePB(p)}, nil
+}
+
+func (h *Handler) UpsertProfile(
+	ctx context.Context,
+	req *identityv1.UpsertProfileRequest,
+) (*identityv1.UpsertProfileResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	p, err := h.svc.UpsertProfile(ctx, app.UpsertProfileParams{
+		UserID:             req.GetUserId(),
+		KYCTier:            int(req.GetKycTier()),
+		VerificationStatus: req.GetVerificationStatus(),
+		DocumentExpiresAt:  req.GetDocumentExpiresAt(),
+		Principal:          principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "UpsertProfile", err)
+	}
+
+	return &identityv1.UpsertProfileResponse{Profile: toProfilePB(p)}, nil
+}
+
+func (h *Handler) SetProfileFrozen(
+	ctx context.Context,
+	req *identityv1.SetProfileFrozenRequest,
+) (*identityv1.SetProfileFrozenResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	p, err := h.svc.SetProfileFrozen(ctx, app.SetProfileFrozenParams{
+		UserID:    req.GetUserId(),
+		Frozen:    req.GetFrozen(),
+		Reason:    req.GetReason(),
+		Principal: principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "SetProfileFrozen", err)
+	}
+
+	return &identityv1.SetProfileFrozenResponse{Profile: toProfilePB(p)}, nil
+}
+
+func (h *Handler) toStatus(ctx context.Context, method string, err error) error {
+	switch {
+	case errors.Is(err, app.ErrAdminRequired),
+		errors.Is(err, app.ErrProfileAccessDenied),
+		errors.Is(err, app.ErrVerificationAccessDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, app.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, app.ErrProfileNotFound),
+		errors.Is(err, app.ErrVerificationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		h.log.ErrorContext(ctx, method+" failed", "err", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func toProfilePB(p app.Profile) *identityv1.Profile {
+	out := &identityv1.Profile{
+		UserId:             p.UserID,
+		KycTier:            int32(p.KYCTier),
+		VerificationStatus: p.VerificationStatus,
+		Frozen:             p.Frozen,
+		UpdatedBy:          p.UpdatedBy,
+		CreatedAt:          p.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:          p.UpdatedAt.Format(time.RFC3339Nano),
+	}
+	if p.DocumentExpiresAt != nil {
+		out.DocumentExpiresAt = p.DocumentExpiresAt.Format(time.RFC3339)
+	}
+	if p.FrozenReason != nil {
+		out.FrozenReason = *p.FrozenReason
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	identityv1 "github.com/cicconee/cbsaga/gen/identity/v1"
+	"github.com/cicconee/cbsaga/internal/identity/app"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) GetVerification(
+	ctx context.Context,
+	req *identityv1.GetVerificationRequest,
+) (*identityv1.GetVerificationResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	v, err := h.svc.GetVerification(ctx, app.GetVerificationParams{
+		WithdrawalID: req.GetWithdrawalId(),
+		Principal:    principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "GetVerification", err)
+	}
+
+	return &identityv1.GetVerificationResponse{Verification: toVerificationPB(v)}, nil
+}
+
+func (h *Handler) ListUserVerifications(
+	ctx context.Context,
+	req *identityv1.ListUserVerificationsRequest,
+) (*identityv1.ListUserVerificationsResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	vs, next, err := h.svc.ListUserVerifications(ctx, app.ListUserVerificationsParams{
+		UserID:    req.GetUserId(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+		Principal: principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "ListUserVerifications", err)
+	}
+
+	resp := &identityv1.ListUserVerificationsResponse{NextPageToken: next}
+	for _, v := range vs {
+		resp.Verifications = append(resp.Verifications, toVerificationPB(v))
+	}
+	return resp, nil
+}
+
+func toVerificationPB(v app.Verification) *identityv1.Verification {
+	out := &identityv1.Verification{
+		VerificationId: v.VerificationID,
+		WithdrawalId:   v.WithdrawalID,
+		UserId:         v.UserID,
+		Status:         v.Status,
+		CreatedAt:      v.CreatedAt.Format(time.RFC3339Nano),
+	}
+	if v.Reason != nil {
+		out.Reason = *v.Reason
+	}
+	if v.Provider != nil {
+		out.Provider = *v.Provider
+	}
+	if v.ReusedFrom != nil {
+		out.ReusedFrom = *v.ReusedFrom
+	}
+	if v.DecidedAt != nil {
+		out.DecidedAt = v.DecidedAt.Format(time.RFC3339Nano)
+	}
+	return out
+}
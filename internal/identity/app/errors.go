@@ -0,0 +1,17 @@
+package app
+
+import "errors"
+
+var (
+	ErrInvalidInput = errors.New("invalid input")
+
+	ErrAdminRequired = errors.New("admin role required")
+
+	ErrProfileAccessDenied = errors.New("caller may not access this profile")
+
+	ErrProfileNotFound = errors.New("identity profile not found")
+
+	ErrVerificationAccessDenied = errors.New("caller may not access this verification")
+
+	ErrVerificationNotFound = errors.New("identity verification not found")
+)
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	providerCallbacksTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "identity",
+		Name:      "provider_callbacks_total",
+		Help:      "KYC provider callbacks applied, by outcome.",
+	}, []string{"outcome"})
+
+	providerTimeoutsTotal = metrics.Factory.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "identity",
+		Name:      "provider_timeouts_total",
+		Help:      "KYC provider checks rejected for getting no callback in time.",
+	})
+
+	providerLatency = metrics.Factory.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "identity",
+		Name:      "provider_latency_seconds",
+		Help:      "Time from submitting a check to the provider's callback.",
+		Buckets:   metrics.DurationBuckets,
+	})
+)
@@ -0,0 +1,175 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/identity/repo"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/shared/identity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	maxKYCTier         = 3
+	maxFrozenReasonLen = 500
+)
+
+type Profile = repo.Profile
+
+// GetProfile returns a user's profile. Users may read their own; admin and support may read any.
+func (s *Service) GetProfile(
+	ctx context.Context,
+	userID string,
+	principal auth.Principal,
+) (Profile, error) {
+	userID = strings.TrimSpace(userID)
+	if _, err := uuid.Parse(userID); err != nil {
+		return Profile{}, fmt.Errorf("%w: user_id must be a uuid", ErrInvalidInput)
+	}
+	if principal.Subject != userID && !principal.IsPrivileged() {
+		return Profile{}, ErrProfileAccessDenied
+	}
+
+	p, err := s.repo.GetProfile(ctx, s.db, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Profile{}, ErrProfileNotFound
+	}
+	return p, err
+}
+
+type UpsertProfileParams struct {
+	UserID             string
+	KYCTier            int
+	VerificationStatus string
+	// DocumentExpiresAt is RFC 3339; empty clears it.
+	DocumentExpiresAt string
+	Principal         auth.Principal
+}
+
+// UpsertProfile records the outcome of a KYC review. The change applies to the next identity
+// check; withdrawals already verified are not revisited.
+func (s *Service) UpsertProfile(ctx context.Context, p UpsertProfileParams) (Profile, error) {
+	if !p.Principal.HasRole(auth.RoleAdmin) {
+		return Profile{}, ErrAdminRequired
+	}
+
+	userID := strings.TrimSpace(p.UserID)
+	if _, err := uuid.Parse(userID); err != nil {
+		return Profile{}, fmt.Errorf("%w: user_id must be a uuid", ErrInvalidInput)
+	}
+	if p.KYCTier < 0 || p.KYCTier > maxKYCTier {
+		return Profile{}, fmt.Errorf("%w: kyc_tier must be between 0 and %d",
+			ErrInvalidInput,
+			maxKYCTier,
+		)
+	}
+
+	status := strings.ToUpper(strings.TrimSpace(p.VerificationStatus))
+	switch status {
+	case identity.ProfileStatusUnverified,
+		identity.ProfileStatusPending,
+		identity.ProfileStatusVerified,
+		identity.ProfileStatusRejected:
+	default:
+		return Profile{}, fmt.Errorf(
+			"%w: verification_status must be UNVERIFIED, PENDING, VERIFIED or REJECTED",
+			ErrInvalidInput,
+		)
+	}
+
+	var expiresAt *time.Time
+	if v := strings.TrimSpace(p.DocumentExpiresAt); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return Profile{}, fmt.Errorf("%w: document_expires_at must be RFC 3339",
+				ErrInvalidInput,
+			)
+		}
+		t = t.UTC()
+		expiresAt = &t
+	}
+
+	out, err := s.repo.UpsertProfile(ctx, s.db, repo.UpsertProfileParams{
+		UserID:             userID,
+		KYCTier:            p.KYCTier,
+		VerificationStatus: status,
+		DocumentExpiresAt:  expiresAt,
+		UpdatedBy:          p.Principal.Subject,
+	})
+	if err != nil {
+		return Profile{}, err
+	}
+
+	s.log.InfoContext(ctx, "audit: identity profile upserted",
+		"principal", p.Principal.Subject,
+		"user_id", out.UserID,
+		"kyc_tier", out.KYCTier,
+		"verification_status", out.VerificationStatus,
+		"document_expires_at", out.DocumentExpiresAt,
+	)
+
+	return out, nil
+}
+
+type SetProfileFrozenParams struct {
+	UserID string
+	Frozen bool
+	// Reason is required when freezing and ignored when unfreezing.
+	Reason    string
+	Principal auth.Principal
+}
+
+// SetProfileFrozen freezes or unfreezes a user. Frozen users fail every identity check
+// regardless of their KYC status.
+func (s *Service) SetProfileFrozen(ctx context.Context, p SetProfileFrozenParams) (Profile, error) {
+	if !p.Principal.HasRole(auth.RoleAdmin) {
+		return Profile{}, ErrAdminRequired
+	}
+
+	userID := strings.TrimSpace(p.UserID)
+	if _, err := uuid.Parse(userID); err != nil {
+		return Profile{}, fmt.Errorf("%w: user_id must be a uuid", ErrInvalidInput)
+	}
+
+	var reason *string
+	if p.Frozen {
+		r := strings.TrimSpace(p.Reason)
+		if r == "" {
+			return Profile{}, fmt.Errorf("%w: reason is required to freeze", ErrInvalidInput)
+		}
+		if len(r) > maxFrozenReasonLen {
+			return Profile{}, fmt.Errorf("%w: reason exceeds %d bytes",
+				ErrInvalidInput,
+				maxFrozenReasonLen,
+			)
+		}
+		reason = &r
+	}
+
+	out, err := s.repo.SetProfileFrozen(ctx, s.db, repo.SetProfileFrozenParams{
+		UserID:    userID,
+		Frozen:    p.Frozen,
+		Reason:    reason,
+		UpdatedBy: p.Principal.Subject,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Profile{}, ErrProfileNotFound
+	}
+	if err != nil {
+		return Profile{}, err
+	}
+
+	s.log.InfoContext(ctx, "audit: identity profile frozen flag changed",
+		"principal", p.Principal.Subject,
+		"user_id", out.UserID,
+		"frozen", out.Frozen,
+		"reason", reason,
+	)
+
+	return out, nil
+}
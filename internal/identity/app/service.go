@@ -0,0 +1,33 @@
+package app
+
+import (
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/identity/repo"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Config struct {
+	// VerificationTTL is how long a provider verification is reused for the user's later
+	// withdrawals. Zero disables reuse.
+	VerificationTTL time.Duration
+	// PendingTimeout is how long a provider check may stay PENDING before it is rejected.
+	PendingTimeout time.Duration
+}
+
+type Service struct {
+	db   *pgxpool.Pool
+	repo *repo.Repo
+	log  *logging.Logger
+	cfg  Config
+}
+
+func NewService(db *pgxpool.Pool, log *logging.Logger, cfg Config) *Service {
+	return &Service{
+		db:   db,
+		repo: repo.New(),
+		log:  log,
+		cfg:  cfg,
+	}
+}
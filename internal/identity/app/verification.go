@@ -0,0 +1,356 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/identity/provider"
+	"github.com/cicconee/cbsaga/internal/identity/repo"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/shared/identity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultVerificationPageSize = 50
+	maxVerificationPageSize     = 200
+
+	pendingExpireBatch = 100
+)
+
+type Verification = repo.Verification
+
+// CompleteVerification applies a provider callback to its PENDING verification and emits the
+// identity decision the orchestrator is waiting for. Callbacks for verifications that are
+// already decided are acknowledged and ignored. A VERIFIED outcome is cached for the user so
+// later withdrawals can reuse it.
+func (s *Service) CompleteVerification(ctx context.Context, res provider.Result) error {
+	var status, eventType string
+	switch res.Outcome {
+	case identity.IdentityStatusVerified:
+		status, eventType = identity.IdentityStatusVerified, identity.EventTypeIdentityVerified
+	case identity.IdentityStatusRejected:
+		status, eventType = identity.IdentityStatusRejected, identity.EventTypeIdentityRejected
+	default:
+		return fmt.Errorf("%w: outcome must be VERIFIED or REJECTED", provider.ErrInvalidResult)
+	}
+
+	var reason *string
+	if status == identity.IdentityStatusRejected {
+		r := "kyc provider rejected"
+		if res.Reason != nil && *res.Reason != "" {
+			r += ": " + *res.Reason
+		}
+		reason = &r
+	}
+
+	var v repo.Verification
+	var applied bool
+	err := postgres.WithTx(ctx, s.db, pgx.TxOptions{}, "kyc_complete",
+		func(ctx context.Context, tx pgx.Tx) error {
+			var err error
+			v, err = s.repo.LockVerificationTx(ctx, tx, res.Reference)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: %s", provider.ErrUnknownReference, res.Reference)
+			}
+			if err != nil {
+				return err
+			}
+			if v.Status != identity.IdentityStatusPending {
+				return nil
+			}
+
+			payload, err := codec.EncodeValid(&identity.IdentityRequestEvtPayload{
+				WithdrawalID: v.WithdrawalID,
+				UserID:       v.UserID,
+				Reason:       reason,
+			})
+			if err != nil {
+				return err
+			}
+
+			applied, err = s.repo.CompleteVerificationTx(ctx, tx, repo.CompleteVerificationParams{
+				VerificationID:  v.VerificationID,
+				Status:          status,
+				Reason:          reason,
+				OutboxEventType: eventType,
+				OutboxPayload:   string(payload),
+				TraceID:         deref(v.TraceID),
+				Traceparent:     v.Traceparent,
+				RouteKey:        identity.RouteKeyIdentityEvt,
+			})
+			if err != nil || !applied || status != identity.IdentityStatusVerified {
+				return err
+			}
+			return s.cacheVerificationTx(ctx, tx, v)
+		})
+	if err != nil {
+		return err
+	}
+
+	if !applied {
+		s.log.InfoContext(ctx, "kyc callback for decided verification ignored",
+			"verification_id", v.VerificationID,
+			"status", v.Status,
+			"outcome", res.Outcome,
+		)
+		return nil
+	}
+
+	providerCallbacksTotal.WithLabelValues(status).Inc()
+	providerLatency.Observe(time.Since(v.CreatedAt).Seconds())
+	s.log.InfoContext(ctx, "identity emitted provider decision",
+		"verification_id", v.VerificationID,
+		"withdrawal_id", v.WithdrawalID,
+		"decision", status,
+		"reason", reason,
+	)
+	return nil
+}
+
+// ExpirePendingVerifications rejects provider checks still PENDING after the timeout, so their
+// withdrawals fail instead of waiting for a callback that may never come. It returns how many
+// it rejected; a callback arriving afterwards is acknowledged and ignored.
+func (s *Service) ExpirePendingVerifications(ctx context.Context) (int, error) {
+	reason := fmt.Sprintf("kyc provider did not answer within %s", s.cfg.PendingTimeout)
+
+	total := 0
+	for {
+		n, seen := 0, 0
+		before := time.Now().UTC().Add(-s.cfg.PendingTimeout)
+		err := postgres.WithTx(ctx, s.db, pgx.TxOptions{}, "kyc_expire",
+			func(ctx context.Context, tx pgx.Tx) error {
+				due, err := s.repo.ListOverduePendingTx(ctx, tx, before, pendingExpireBatch)
+				if err != nil {
+					return err
+				}
+				seen = len(due)
+
+				for _, v := range due {
+					payload, err := codec.EncodeValid(&identity.IdentityRequestEvtPayload{
+						WithdrawalID: v.WithdrawalID,
+						UserID:       v.UserID,
+						Reason:       &reason,
+					})
+					if err != nil {
+						return err
+					}
+
+					applied, err := s.repo.CompleteVerificationTx(ctx, tx,
+						repo.CompleteVerificationParams{
+							VerificationID:  v.VerificationID,
+							Status:          identity.IdentityStatusRejected,
+							Reason:          &reason,
+							OutboxEventType: identity.EventTypeIdentityRejected,
+							OutboxPayload:   string(payload),
+							TraceID:         deref(v.TraceID),
+							Traceparent:     v.Traceparent,
+							RouteKey:        identity.RouteKeyIdentityEvt,
+						},
+					)
+					if err != nil {
+						return err
+					}
+					if applied {
+						n++
+						s.log.WarnContext(ctx, "kyc provider check timed out",
+							"verification_id", v.VerificationID,
+							"withdrawal_id", v.WithdrawalID,
+							"created_at", v.CreatedAt,
+						)
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			return total, err
+		}
+
+		total += n
+		providerTimeoutsTotal.Add(float64(n))
+		if seen < pendingExpireBatch {
+			return total, nil
+		}
+	}
+}
+
+// RunPendingSweeper expires overdue provider checks every interval until ctx is done.
+func (s *Service) RunPendingSweeper(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			n, err := s.ExpirePendingVerifications(ctx)
+			if err != nil {
+				s.log.ErrorContext(ctx, "expire pending verifications failed", "err", err)
+				continue
+			}
+			if n > 0 {
+				s.log.InfoContext(ctx, "pending verifications expired", "count", n)
+			}
+		}
+	}
+}
+
+// cacheVerificationTx makes v the user's reusable verification. Verifications recorded before
+// the cache existed carry no profile version and are not cached.
+func (s *Service) cacheVerificationTx(ctx context.Context, tx pgx.Tx, v repo.Verification) error {
+	if s.cfg.VerificationTTL <= 0 || v.ProfileUpdatedAt == nil || v.Provider == nil {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	return s.repo.PutCachedVerificationTx(ctx, tx, repo.CachedVerification{
+		UserID:           v.UserID,
+		VerificationID:   v.VerificationID,
+		Provider:         *v.Provider,
+		ProfileUpdatedAt: *v.ProfileUpdatedAt,
+		VerifiedAt:       now,
+		ExpiresAt:        now.Add(s.cfg.VerificationTTL),
+	})
+}
+
+type GetVerificationParams struct {
+	WithdrawalID string
+	Principal    auth.Principal
+}
+
+// GetVerification returns the identity decision for a withdrawal. Users may read their own;
+// admin and support may read any.
+func (s *Service) GetVerification(
+	ctx context.Context,
+	p GetVerificationParams,
+) (Verification, error) {
+	withdrawalID := strings.TrimSpace(p.WithdrawalID)
+	if _, err := uuid.Parse(withdrawalID); err != nil {
+		return Verification{}, fmt.Errorf("%w: withdrawal_id must be a uuid", ErrInvalidInput)
+	}
+
+	v, err := s.repo.GetVerificationByWithdrawal(ctx, s.db, withdrawalID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Verification{}, ErrVerificationNotFound
+	}
+	if err != nil {
+		return Verification{}, err
+	}
+
+	if v.UserID != p.Principal.Subject {
+		if !p.Principal.IsPrivileged() {
+			return Verification{}, ErrVerificationAccessDenied
+		}
+		s.log.InfoContext(ctx, "audit: verification read by privileged principal",
+			"principal", p.Principal.Subject,
+			"roles", p.Principal.Roles,
+			"withdrawal_id", v.WithdrawalID,
+			"owner_user_id", v.UserID,
+		)
+	}
+
+	return v, nil
+}
+
+type ListUserVerificationsParams struct {
+	UserID    string
+	PageSize  int
+	PageToken string
+	Principal auth.Principal
+}
+
+// ListUserVerifications pages through a user's identity decisions, newest first.
+func (s *Service) ListUserVerifications(
+	ctx context.Context,
+	p ListUserVerificationsParams,
+) ([]Verification, string, error) {
+	userID := strings.TrimSpace(p.UserID)
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, "", fmt.Errorf("%w: user_id must be a uuid", ErrInvalidInput)
+	}
+	if userID != p.Principal.Subject {
+		if !p.Principal.IsPrivileged() {
+			return nil, "", ErrVerificationAccessDenied
+		}
+		s.log.InfoContext(ctx, "audit: verifications listed by privileged principal",
+			"principal", p.Principal.Subject,
+			"roles", p.Principal.Roles,
+			"user_id", userID,
+		)
+	}
+
+	size := p.PageSize
+	if size <= 0 {
+		size = defaultVerificationPageSize
+	}
+	size = min(size, maxVerificationPageSize)
+
+	after, err := decodeVerificationCursor(p.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	vs, err := s.repo.ListUserVerifications(ctx, s.db, repo.ListUserVerificationsParams{
+		UserID: userID,
+		After:  after,
+		Limit:  size + 1,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(vs) > size {
+		vs = vs[:size]
+		last := vs[size-1]
+		next = encodeVerificationCursor(repo.VerificationCursor{
+			CreatedAt:      last.CreatedAt,
+			VerificationID: last.VerificationID,
+		})
+	}
+	return vs, next, nil
+}
+
+func encodeVerificationCursor(c repo.VerificationCursor) string {
+	raw := c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.VerificationID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeVerificationCursor(token string) (*repo.VerificationCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid page_token", ErrInvalidInput)
+	}
+	created, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid page_token", ErrInvalidInput)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, created)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid page_token", ErrInvalidInput)
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%w: invalid page_token", ErrInvalidInput)
+	}
+
+	return &repo.VerificationCursor{CreatedAt: createdAt, VerificationID: id}, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -1,11 +1,20 @@
 package config
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/cicconee/cbsaga/internal/platform/config"
 )
 
+const (
+	// ProviderNone decides every check locally from the user's profile.
+	ProviderNone = "none"
+	// ProviderHTTP also sends checks that pass locally to an external KYC provider and waits
+	// for its callback.
+	ProviderHTTP = "http"
+)
+
 type IdentityConfig struct {
 	Env                     string
 	ShutdownTimeout         time.Duration
@@ -13,6 +22,28 @@ type IdentityConfig struct {
 	KafkaBrokers            []string
 	IdentityCmdTopic        string
 	IdentityConsumerGroupID string
+	GRPCAddr                string
+	GRPCReflection          bool
+	AuthHMACKeys            []string
+	AuthIssuer              string
+	AuthAudience            string
+	Provider                string
+	ProviderURL             string
+	WebhookAddr             string
+	WebhookURL              string
+	WebhookSecret           string
+	VerificationTTL         time.Duration
+	PendingTimeout          time.Duration
+	PendingSweepInterval    time.Duration
+	TraceExporter           string
+	TraceFile               string
+	OTLPEndpoint            string
+	OTLPInsecure            bool
+	OpsAddr                 string
+	HealthInterval          time.Duration
+	ConsumerStall           time.Duration
+	LogFormat               string
+	LogLevel                string
 }
 
 func Load() (IdentityConfig, error) {
@@ -31,6 +62,87 @@ func Load() (IdentityConfig, error) {
 			"CBSAGA_IDENTITY_CONSUMER_GROUP_ID",
 			"cbsaga-identity",
 		),
+		GRPCAddr:     config.GetEnv("CBSAGA_IDENTITY_GRPC_ADDR", ":9001"),
+		AuthIssuer:   config.GetEnv("CBSAGA_AUTH_ISSUER", "cbsaga-dev"),
+		AuthAudience: config.GetEnv("CBSAGA_IDENTITY_AUTH_AUDIENCE", "cbsaga-identity"),
+		Provider:     config.GetEnv("CBSAGA_IDENTITY_PROVIDER", ProviderNone),
+		ProviderURL:  config.GetEnv("CBSAGA_IDENTITY_PROVIDER_URL", "http://localhost:9300"),
+		WebhookAddr:  config.GetEnv("CBSAGA_IDENTITY_WEBHOOK_ADDR", ":9201"),
+		WebhookURL: config.GetEnv(
+			"CBSAGA_IDENTITY_WEBHOOK_URL",
+			"http://localhost:9201/webhooks/kyc",
+		),
+		PendingTimeout: config.GetEnvDuration(
+			"CBSAGA_IDENTITY_PENDING_TIMEOUT",
+			30*time.Minute,
+		),
+		PendingSweepInterval: config.GetEnvDuration(
+			"CBSAGA_IDENTITY_PENDING_SWEEP_INTERVAL",
+			time.Minute,
+		),
+		VerificationTTL: config.GetEnvDuration("CBSAGA_IDENTITY_VERIFICATION_TTL", 24*time.Hour),
+		TraceExporter:   config.GetEnv("CBSAGA_TRACE_EXPORTER", "none"),
+		TraceFile: config.GetEnv(
+			"CBSAGA_IDENTITY_TRACE_FILE",
+			"./.run/traces/identity.json",
+		),
+		OTLPEndpoint:   config.GetEnv("CBSAGA_OTLP_ENDPOINT", "localhost:4317"),
+		OTLPInsecure:   config.GetEnvBool("CBSAGA_OTLP_INSECURE", true),
+		OpsAddr:        config.GetEnv("CBSAGA_IDENTITY_OPS_ADDR", ":9101"),
+		HealthInterval: config.GetEnvDuration("CBSAGA_HEALTH_INTERVAL", 5*time.Second),
+		ConsumerStall:  config.GetEnvDuration("CBSAGA_CONSUMER_STALL_TIMEOUT", 30*time.Second),
+		LogFormat:      config.GetEnv("CBSAGA_LOG_FORMAT", "json"),
+		LogLevel:       config.GetEnv("CBSAGA_LOG_LEVEL", "info"),
+	}
+
+	cfg.GRPCReflection = config.GetEnvBoolDevDefault("CBSAGA_GRPC_REFLECTION", cfg.Env)
+
+	hmacKeys, err := config.GetEnvDevDefault(
+		"CBSAGA_AUTH_HMAC_KEYS",
+		cfg.Env,
+		"dev:dev-secret-change-me",
+	)
+	if err != nil {
+		return IdentityConfig{}, err
+	}
+	cfg.AuthHMACKeys = config.SplitCSV(hmacKeys)
+
+	// The fake KYC provider signs with the dev webhook secret by default. Only the http provider
+	// needs a secret, so its absence is checked below.
+	cfg.WebhookSecret = config.GetEnv(
+		"CBSAGA_IDENTITY_WEBHOOK_SECRET",
+		config.DevDefault(cfg.Env, "dev-webhook-secret-change-me"),
+	)
+
+	if cfg.GRPCAddr == "" {
+		return IdentityConfig{}, fmt.Errorf("CBSAGA_IDENTITY_GRPC_ADDR cannot be empty")
+	}
+	if len(cfg.AuthHMACKeys) == 0 {
+		return IdentityConfig{}, fmt.Errorf("CBSAGA_AUTH_HMAC_KEYS cannot be empty")
+	}
+	if cfg.VerificationTTL < 0 {
+		return IdentityConfig{}, fmt.Errorf("CBSAGA_IDENTITY_VERIFICATION_TTL cannot be negative")
+	}
+	if cfg.PendingTimeout <= 0 || cfg.PendingSweepInterval <= 0 {
+		return IdentityConfig{}, fmt.Errorf(
+			"CBSAGA_IDENTITY_PENDING_TIMEOUT and CBSAGA_IDENTITY_PENDING_SWEEP_INTERVAL " +
+				"must be positive",
+		)
+	}
+	switch cfg.Provider {
+	case ProviderNone:
+	case ProviderHTTP:
+		if cfg.ProviderURL == "" || cfg.WebhookURL == "" || cfg.WebhookSecret == "" {
+			return IdentityConfig{}, fmt.Errorf(
+				"CBSAGA_IDENTITY_PROVIDER=http requires CBSAGA_IDENTITY_PROVIDER_URL, " +
+					"CBSAGA_IDENTITY_WEBHOOK_URL and CBSAGA_IDENTITY_WEBHOOK_SECRET",
+			)
+		}
+	default:
+		return IdentityConfig{}, fmt.Errorf(
+			"CBSAGA_IDENTITY_PROVIDER must be one of none, http: got %q",
+			cfg.Provider,
+		)
 	}
 
 	return cfg, nil
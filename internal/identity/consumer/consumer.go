@@ -5,25 +5,47 @@ import (
 	"errors"
 	"time"
 
+	"github.com/cicconee/cbsaga/internal/identity/provider"
 	"github.com/cicconee/cbsaga/internal/identity/repo"
+	"github.com/cicconee/cbsaga/internal/identity/verifier"
 	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/health"
 	"github.com/cicconee/cbsaga/internal/platform/logging"
 	"github.com/cicconee/cbsaga/internal/platform/messaging"
+	"github.com/cicconee/cbsaga/internal/platform/retry"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
 	"github.com/cicconee/cbsaga/internal/shared/identity"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "github.com/cicconee/cbsaga/internal/identity/consumer"
+
 type Consumer struct {
-	db   *pgxpool.Pool
-	repo *repo.Repo
-	log  *logging.Logger
-	r    *kafka.Reader
+	db       *pgxpool.Pool
+	repo     *repo.Repo
+	provider provider.Provider
+	cacheTTL time.Duration
+	log      *logging.Logger
+	r        *kafka.Reader
+	tracer   trace.Tracer
+	live     *health.ConsumerLiveness
 }
 
-func New(db *pgxpool.Pool, log *logging.Logger, brokers []string, groupID, topic string) *Consumer {
+// New creates the consumer. With a nil provider every check is decided locally from the user's
+// profile; otherwise checks that pass locally are completed asynchronously by the provider,
+// unless the user has a provider verification younger than cacheTTL (0 disables reuse).
+func New(
+	db *pgxpool.Pool,
+	log *logging.Logger,
+	kyc provider.Provider,
+	cacheTTL time.Duration,
+	brokers []string,
+	groupID, topic string,
+) *Consumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:     brokers,
 		GroupID:     groupID,
@@ -35,13 +57,21 @@ func New(db *pgxpool.Pool, log *logging.Logger, brokers []string, groupID, topic
 	})
 
 	return &Consumer{
-		db:   db,
-		repo: repo.New(),
-		log:  log,
-		r:    reader,
+		db:       db,
+		repo:     repo.New(),
+		provider: kyc,
+		cacheTTL: cacheTTL,
+		log:      log,
+		r:        reader,
+		tracer:   tracing.Tracer(tracerName),
+		live:     health.NewConsumerLiveness(),
 	}
 }
 
+func (c *Consumer) Liveness() *health.ConsumerLiveness {
+	return c.live
+}
+
 func (c *Consumer) Close() error {
 	return c.r.Close()
 }
@@ -55,10 +85,13 @@ type WithdrawalRequested struct {
 	Status          string `json:"status"`
 }
 
-func (c *Consumer) Run(ctx context.Context) error {
+func (c *Consumer) Run(ctx context.Context) (err error) {
+	defer func() { c.live.Stopped(err) }()
+
 	c.log.Info("identity consumer started")
 
 	for {
+		c.live.Fetching()
 		m, err := c.r.FetchMessage(ctx)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
@@ -67,80 +100,285 @@ func (c *Consumer) Run(ctx context.Context) error {
 			}
 			return err
 		}
+		c.live.Fetched()
 
-		headers := messaging.NewHeaders(m.Headers)
-		traceID, ok := headers.String("trace_id")
-		if !ok || traceID == "" {
-			// TODO: This should never be ignored. This must be made apparent the moment it happens.
-			traceID = "local-trace-id-identity"
-		}
-
-		identityPayload := identity.IdentityRequestCmdPayload{}
-		err = messaging.DecodeConnectEnvelopeValid(m.Value, &identityPayload)
+		started := time.Now()
+		err = c.handleMessage(ctx, m)
+		messaging.ObserveMessage("identity", m, started, err)
 		if err != nil {
-			// TODO: log and continue, remember decoding also validates.
-			continue
+			return err
 		}
+	}
+}
 
-		// Mocking identity verification for now. Maybe implement this or add some random REJECTED
-		// and delays?
-		status := identity.IdentityStatusVerified
-		outboxType := identity.EventTypeIdentityVerified
-		var reason *string
+func (c *Consumer) handleMessage(ctx context.Context, m kafka.Message) (err error) {
+	ctx, span := messaging.StartConsumerSpan(ctx, c.tracer, "identity.HandleVerifyRequest", m)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
 
-		identityEvtPayload, err := codec.EncodeValid(&identity.IdentityRequestEvtPayload{
-			WithdrawalID: identityPayload.WithdrawalID,
-			UserID:       identityPayload.UserID,
-			Reason:       reason,
-		})
+	headers := messaging.NewHeaders(m.Headers)
+	traceID, ok := headers.String("trace_id")
+	if !ok || traceID == "" {
+		// TODO: This should never be ignored. This must be made apparent the moment it happens.
+		traceID = "local-trace-id-identity"
+	}
+	ctx = logging.WithTraceID(ctx, traceID)
+
+	identityPayload := identity.IdentityRequestCmdPayload{}
+	err = messaging.DecodeConnectEnvelopeValid(m.Value, &identityPayload)
+	if err != nil {
+		// TODO: log and continue, remember decoding also validates.
+		return nil
+	}
+	ctx = logging.WithWithdrawalID(ctx, identityPayload.WithdrawalID)
+
+	tx, err := c.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	decision, profile, err := c.verify(ctx, tx, identityPayload)
+	if err != nil {
+		return err
+	}
+	// Checks that pass locally still need the provider's answer when one is configured.
+	if decision.Verified() && c.provider != nil {
+		return c.submit(ctx, tx, m, identityPayload, traceID, profile)
+	}
+	return c.decide(ctx, tx, m, identityPayload, traceID, decision, nil)
+}
+
+// decide records the decision for the withdrawal and emits it. reused is the cached provider
+// verification a VERIFIED decision was taken from, if any.
+func (c *Consumer) decide(
+	ctx context.Context,
+	tx pgx.Tx,
+	m kafka.Message,
+	req identity.IdentityRequestCmdPayload,
+	traceID string,
+	decision verifier.Decision,
+	reused *repo.CachedVerification,
+) error {
+	status := decision.Status
+	reason := decision.Reason
+	outboxType := identity.EventTypeIdentityRejected
+	if decision.Verified() {
+		outboxType = identity.EventTypeIdentityVerified
+	}
+
+	identityEvtPayload, err := codec.EncodeValid(&identity.IdentityRequestEvtPayload{
+		WithdrawalID: req.WithdrawalID,
+		UserID:       req.UserID,
+		Reason:       reason,
+	})
+	if err != nil {
+		// TODO: log and continue, remember encoding also validates.
+		return nil
+	}
+
+	params := repo.VerifyAndEmitParams{
+		VerificationID:  uuid.New().String(),
+		WithdrawalID:    req.WithdrawalID,
+		UserID:          req.UserID,
+		Status:          status,
+		Reason:          reason,
+		OutboxEventType: outboxType,
+		OutboxPayload:   string(identityEvtPayload),
+		TraceID:         traceID,
+		Traceparent:     tracing.Traceparent(ctx),
+		RouteKey:        identity.RouteKeyIdentityEvt,
+	}
+	if reused != nil {
+		params.Provider = &reused.Provider
+		params.ReusedFrom = &reused.VerificationID
+	}
+
+	if err := c.repo.VerifyAndEmitTx(ctx, tx, params); err != nil {
+		c.log.ErrorContext(ctx, "VerifyAndEmitTx failed", "err", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if err := c.r.CommitMessages(ctx, m); err != nil {
+		c.log.ErrorContext(ctx, "CommitMessages failed", "err", err)
+		return err
+	}
+
+	if reused != nil {
+		c.log.InfoContext(ctx, "identity emitted cached provider decision",
+			"decision", status,
+			"reused_from", reused.VerificationID,
+			"expires_at", reused.ExpiresAt,
+		)
+		return nil
+	}
+	c.log.InfoContext(ctx, "identity emitted decision",
+		"decision", status,
+		"reason", reason,
+		"event_type", outboxType,
+	)
+
+	return nil
+}
+
+// submit records a PENDING verification and hands it to the provider. A redelivered command
+// whose verification is still pending is submitted again under the same reference; the outcome
+// is emitted later by the webhook. A first check for a user with a reusable provider
+// verification is decided from it without contacting the provider.
+func (c *Consumer) submit(
+	ctx context.Context,
+	tx pgx.Tx,
+	m kafka.Message,
+	req identity.IdentityRequestCmdPayload,
+	traceID string,
+	profile *repo.Profile,
+) error {
+	check := provider.Check{WithdrawalID: req.WithdrawalID, UserID: req.UserID}
+
+	existing, err := c.repo.GetVerificationByWithdrawal(ctx, tx, req.WithdrawalID)
+	switch {
+	case err == nil:
+		if existing.Status != identity.IdentityStatusPending {
+			c.log.InfoContext(ctx, "identity already decided", "status", existing.Status)
+			return c.r.CommitMessages(ctx, m)
+		}
+		check.Reference = existing.VerificationID
+	case errors.Is(err, pgx.ErrNoRows):
+		cached, ok, err := c.cachedVerification(ctx, tx, profile)
 		if err != nil {
-			// TODO: log and continue, remember encoding also validates.
-			continue
+			return err
+		}
+		if ok {
+			verified := verifier.Decision{Status: identity.IdentityStatusVerified}
+			return c.decide(ctx, tx, m, req, traceID, verified, &cached)
 		}
 
-		tx, err := c.db.BeginTx(ctx, pgx.TxOptions{})
+		check.Reference = uuid.New().String()
+		inserted, err := c.repo.RecordPendingTx(ctx, tx, repo.RecordPendingParams{
+			VerificationID:   check.Reference,
+			WithdrawalID:     req.WithdrawalID,
+			UserID:           req.UserID,
+			Provider:         c.provider.Name(),
+			TraceID:          traceID,
+			Traceparent:      tracing.Traceparent(ctx),
+			ProfileUpdatedAt: profile.UpdatedAt,
+		})
 		if err != nil {
 			return err
 		}
-		defer func() { _ = tx.Rollback(ctx) }()
-
-		verificationID := uuid.New().String()
-
-		if err := c.repo.VerifyAndEmitTx(ctx, tx, repo.VerifyAndEmitParams{
-			VerificationID:  verificationID,
-			WithdrawalID:    identityPayload.WithdrawalID,
-			UserID:          identityPayload.UserID,
-			Status:          status,
-			Reason:          reason,
-			OutboxEventType: outboxType,
-			OutboxPayload:   string(identityEvtPayload),
-			TraceID:         traceID,
-			RouteKey:        identity.RouteKeyIdentityEvt,
-		}); err != nil {
-			c.log.Error(
-				"VerifyAndEmitTx failed",
-				"err",
-				err,
-				"withdrawal_id",
-				identityPayload.WithdrawalID,
-			)
-			return err
+		if !inserted {
+			// Another delivery of this command recorded it first and submits it.
+			c.log.InfoContext(ctx, "identity check already recorded")
+			return c.r.CommitMessages(ctx, m)
 		}
-
 		if err := tx.Commit(ctx); err != nil {
 			return err
 		}
+	default:
+		return err
+	}
 
-		if err := c.r.CommitMessages(ctx, m); err != nil {
-			c.log.Error("CommitMessages failed", "err", err)
-			return err
-		}
+	err = retry.Do(ctx, retry.Config{
+		Op:          "kyc_submit",
+		MaxAttempts: 5,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		IsRetryable: provider.Retryable,
+	}, func() error {
+		return c.provider.Submit(ctx, check)
+	})
+	if err != nil {
+		// The message stays uncommitted, so the check is submitted again on redelivery.
+		c.log.ErrorContext(ctx, "kyc submit failed", "err", err, "reference", check.Reference)
+		return err
+	}
 
-		c.log.Info("identity emitted decision",
-			"withdrawal_id", identityPayload.WithdrawalID,
-			"decision", status,
-			"event_type", outboxType,
-			"trace_id", traceID,
-		)
+	if err := c.r.CommitMessages(ctx, m); err != nil {
+		c.log.ErrorContext(ctx, "CommitMessages failed", "err", err)
+		return err
 	}
+
+	c.log.InfoContext(ctx, "identity check submitted to provider",
+		"provider", c.provider.Name(),
+		"verification_id", check.Reference,
+	)
+	return nil
+}
+
+// cachedVerification returns the user's provider verification when it can be reused: it has not
+// expired and the profile has not changed since the check was made.
+func (c *Consumer) cachedVerification(
+	ctx context.Context,
+	tx pgx.Tx,
+	profile *repo.Profile,
+) (repo.CachedVerification, bool, error) {
+	if c.cacheTTL <= 0 {
+		return repo.CachedVerification{}, false, nil
+	}
+
+	cached, err := c.repo.GetCachedVerification(ctx, tx, profile.UserID)
+	result := cacheHit
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		result = cacheMiss
+	case err != nil:
+		return repo.CachedVerification{}, false, err
+	case !cached.ExpiresAt.After(time.Now()):
+		result = cacheExpired
+	case !cached.ProfileUpdatedAt.Equal(profile.UpdatedAt):
+		result = cacheStale
+	}
+	verificationCacheTotal.WithLabelValues(result).Inc()
+
+	return cached, result == cacheHit, nil
+}
+
+// verify loads the user's profile and the tier limit for the requested asset and decides. The
+// profile is nil when the user has none.
+func (c *Consumer) verify(
+	ctx context.Context,
+	tx pgx.Tx,
+	req identity.IdentityRequestCmdPayload,
+) (verifier.Decision, *repo.Profile, error) {
+	in := verifier.Input{
+		Asset:       req.Asset,
+		AmountMinor: req.AmountMinor,
+		Now:         time.Now().UTC(),
+	}
+	// Commands enqueued before they carried the asset and amount still get every profile check;
+	// only the tier limit, which needs both, is skipped.
+	if req.Legacy() {
+		c.log.WarnContext(ctx, "identity command without asset and amount, skipping tier limit")
+		in.SkipTierLimit = true
+	}
+
+	p, err := c.repo.GetProfile(ctx, tx, req.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return verifier.Verify(in), nil, nil
+	}
+	if err != nil {
+		return verifier.Decision{}, nil, err
+	}
+	in.Profile = &verifier.Profile{
+		KYCTier:            p.KYCTier,
+		VerificationStatus: p.VerificationStatus,
+		DocumentExpiresAt:  p.DocumentExpiresAt,
+		Frozen:             p.Frozen,
+		FrozenReason:       p.FrozenReason,
+	}
+
+	if !in.SkipTierLimit {
+		in.TierLimit, err = c.repo.GetTierLimit(ctx, tx, p.KYCTier, req.Asset)
+		if err != nil {
+			return verifier.Decision{}, nil, err
+		}
+	}
+
+	return verifier.Verify(in), &p, nil
 }
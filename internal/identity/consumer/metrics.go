@@ -0,0 +1,20 @@
+package consumer
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	cacheHit     = "hit"
+	cacheMiss    = "miss"
+	cacheExpired = "expired"
+	cacheStale   = "stale"
+)
+
+var verificationCacheTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "identity",
+	Name:      "verification_cache_total",
+	Help:      "Provider verification cache lookups, by result (hit, miss, expired, stale).",
+}, []string{"result"})
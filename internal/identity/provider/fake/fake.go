@@ -0,0 +1,143 @@
+package fake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/identity/provider"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/retry"
+	"github.com/cicconee/cbsaga/internal/shared/identity"
+)
+
+type Config struct {
+	// Delay is how long after a submission the callback is sent.
+	Delay time.Duration
+	// RejectPercent rejects this share (0-100) of checks at random.
+	RejectPercent int
+	// RejectUsers are always rejected.
+	RejectUsers []string
+	// Secret signs callbacks; it must match the identity service's webhook secret.
+	Secret string
+}
+
+// Provider is a local stand-in for a KYC vendor. It accepts checks on POST /v1/checks and
+// answers each one on its callback URL after Delay, so the asynchronous path can be exercised
+// without network access.
+type Provider struct {
+	cfg    Config
+	log    *logging.Logger
+	client *http.Client
+	ctx    context.Context
+}
+
+// New returns a provider whose pending callbacks are abandoned once ctx is done.
+func New(ctx context.Context, cfg Config, log *logging.Logger) *Provider {
+	return &Provider{
+		cfg:    cfg,
+		log:    log,
+		client: &http.Client{Timeout: 5 * time.Second},
+		ctx:    ctx,
+	}
+}
+
+func (p *Provider) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/checks", p.handleSubmit)
+	return mux
+}
+
+func (p *Provider) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	var c provider.Check
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		http.Error(w, "invalid json", http.StatusBadRequest)
+		return
+	}
+	if c.Reference == "" || c.CallbackURL == "" {
+		http.Error(w, "reference and callback_url are required", http.StatusBadRequest)
+		return
+	}
+
+	res := p.decide(c)
+	p.log.Info("fake kyc check accepted",
+		"reference", c.Reference,
+		"user_id", c.UserID,
+		"outcome", res.Outcome,
+		"delay", p.cfg.Delay,
+	)
+
+	go p.answer(c.CallbackURL, res)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (p *Provider) decide(c provider.Check) provider.Result {
+	res := provider.Result{Reference: c.Reference, Outcome: identity.IdentityStatusVerified}
+
+	var reason string
+	switch {
+	case slices.Contains(p.cfg.RejectUsers, c.UserID):
+		reason = "document could not be verified"
+	case rand.IntN(100) < p.cfg.RejectPercent:
+		reason = "selfie does not match document"
+	default:
+		return res
+	}
+	res.Outcome = identity.IdentityStatusRejected
+	res.Reason = &reason
+	return res
+}
+
+func (p *Provider) answer(url string, res provider.Result) {
+	select {
+	case <-p.ctx.Done():
+		return
+	case <-time.After(p.cfg.Delay):
+	}
+
+	body, err := json.Marshal(res)
+	if err != nil {
+		p.log.Error("fake kyc encode callback failed", "err", err)
+		return
+	}
+
+	err = retry.Do(p.ctx, retry.Config{
+		Op:          "fake_kyc_callback",
+		MaxAttempts: 5,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		IsRetryable: provider.Retryable,
+	}, func() error {
+		return p.post(url, body)
+	})
+	if err != nil {
+		p.log.Error("fake kyc callback failed", "reference", res.Reference, "err", err)
+		return
+	}
+	p.log.Info("fake kyc callback delivered", "reference", res.Reference, "outcome", res.Outcome)
+}
+
+func (p *Provider) post(url string, body []byte) error {
+	req, err := http.NewRequestWithContext(p.ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(provider.SignatureHeader, provider.Sign(p.cfg.Secret, body))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return &provider.StatusError{Code: resp.StatusCode, Body: fmt.Sprint(resp.Status)}
+	}
+	return nil
+}
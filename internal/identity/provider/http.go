@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type HTTPConfig struct {
+	Name string
+	// BaseURL is the provider's API root; checks are posted to BaseURL + "/v1/checks".
+	BaseURL string
+	// CallbackURL is our webhook the provider reports outcomes to.
+	CallbackURL string
+	Timeout     time.Duration
+}
+
+// HTTPProvider submits checks as JSON over HTTP.
+type HTTPProvider struct {
+	cfg    HTTPConfig
+	client *http.Client
+}
+
+func NewHTTP(cfg HTTPConfig) (*HTTPProvider, error) {
+	if cfg.BaseURL == "" || cfg.CallbackURL == "" {
+		return nil, errors.New("provider: base URL and callback URL are required")
+	}
+	if cfg.Name == "" {
+		cfg.Name = "http"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	return &HTTPProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+func (p *HTTPProvider) Name() string {
+	return p.cfg.Name
+}
+
+// StatusError is a non-2xx answer from the provider.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("provider: status %d: %s", e.Code, e.Body)
+}
+
+// Retryable reports whether submitting again may succeed. Network errors and 5xx/429 answers
+// are retryable; other 4xx answers are not.
+func Retryable(err error) bool {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Code >= 500 || se.Code == http.StatusTooManyRequests
+	}
+	return true
+}
+
+func (p *HTTPProvider) Submit(ctx context.Context, c Check) error {
+	c.CallbackURL = p.cfg.CallbackURL
+	body, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	url := strings.TrimRight(p.cfg.BaseURL, "/") + "/v1/checks"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("provider: submit: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &StatusError{Code: resp.StatusCode, Body: strings.TrimSpace(string(msg))}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+const SignatureHeader = "X-Kyc-Signature"
+
+var (
+	// ErrUnknownReference is returned by a Completer for a callback that matches no verification.
+	ErrUnknownReference = errors.New("unknown verification reference")
+
+	ErrInvalidResult = errors.New("invalid provider result")
+)
+
+// Check is one identity check submitted to a provider. Reference is our verification_id and
+// comes back unchanged on the callback.
+type Check struct {
+	Reference    string `json:"reference"`
+	UserID       string `json:"user_id"`
+	WithdrawalID string `json:"withdrawal_id"`
+	CallbackURL  string `json:"callback_url"`
+}
+
+// Provider submits checks to an external KYC service. Submit only hands the check over; the
+// outcome arrives later on the webhook. Submitting the same Reference twice must be safe.
+type Provider interface {
+	Name() string
+	Submit(ctx context.Context, c Check) error
+}
+
+// Result is the callback body a provider posts to the webhook.
+type Result struct {
+	Reference string  `json:"reference"`
+	Outcome   string  `json:"outcome"` // VERIFIED | REJECTED
+	Reason    *string `json:"reason,omitempty"`
+}
+
+// Sign returns the signature header value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func verifySignature(secret string, body []byte, header string) bool {
+	got, ok := strings.CutPrefix(header, "sha256=")
+	if !ok {
+		return false
+	}
+	sig, err := hex.DecodeString(got)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(sig, mac.Sum(nil))
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+)
+
+const maxCallbackBytes = 64 << 10
+
+// Completer applies a provider result. It returns nil for a result that was already applied so
+// provider redeliveries are acknowledged.
+type Completer func(ctx context.Context, r Result) error
+
+// WebhookHandler accepts signed provider callbacks. Only a 2xx answer tells the provider to stop
+// redelivering, so anything we may succeed at later is answered with 5xx.
+func WebhookHandler(secret string, complete Completer, log *logging.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxCallbackBytes))
+		if err != nil {
+			http.Error(w, "read body", http.StatusBadRequest)
+			return
+		}
+		if !verifySignature(secret, body, r.Header.Get(SignatureHeader)) {
+			log.WarnContext(r.Context(), "kyc callback rejected: bad signature")
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var res Result
+		if err := json.Unmarshal(body, &res); err != nil {
+			http.Error(w, "invalid json", http.StatusBadRequest)
+			return
+		}
+
+		err = complete(r.Context(), res)
+		switch {
+		case err == nil:
+			w.WriteHeader(http.StatusNoContent)
+		case errors.Is(err, ErrUnknownReference):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errors.Is(err, ErrInvalidResult):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			log.ErrorContext(r.Context(), "kyc callback failed",
+				"reference", res.Reference,
+				"err", err,
+			)
+			http.Error(w, "internal error", http.StatusInternalServerError)
+		}
+	})
+}
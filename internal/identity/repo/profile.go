@@ -0,0 +1,150 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type Profile struct {
+	UserID             string
+	KYCTier            int
+	VerificationStatus string
+	DocumentExpiresAt  *time.Time
+	Frozen             bool
+	FrozenReason       *string
+	UpdatedBy          string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+const profileColumns = `
+	user_id,
+	kyc_tier,
+	verification_status,
+	document_expires_at,
+	frozen,
+	frozen_reason,
+	updated_by,
+	created_at,
+	updated_at
+`
+
+func scanProfile(row pgx.Row) (Profile, error) {
+	var p Profile
+	err := row.Scan(
+		&p.UserID,
+		&p.KYCTier,
+		&p.VerificationStatus,
+		&p.DocumentExpiresAt,
+		&p.Frozen,
+		&p.FrozenReason,
+		&p.UpdatedBy,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	return p, err
+}
+
+func (r *Repo) GetProfile(ctx context.Context, db postgres.DBTX, userID string) (Profile, error) {
+	return scanProfile(db.QueryRow(ctx, `
+		SELECT `+profileColumns+`
+		FROM identity.user_profiles
+		WHERE user_id = $1
+	`, userID))
+}
+
+// GetTierLimit returns the largest single withdrawal of asset allowed at tier, or nil when the
+// tier may not withdraw the asset.
+func (r *Repo) GetTierLimit(
+	ctx context.Context,
+	db postgres.DBTX,
+	tier int,
+	asset string,
+) (*int64, error) {
+	var limit int64
+	err := db.QueryRow(ctx, `
+		SELECT max_amount_minor
+		FROM identity.kyc_tier_limits
+		WHERE kyc_tier = $1 AND asset = $2
+	`, tier, asset).Scan(&limit)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &limit, nil
+}
+
+type UpsertProfileParams struct {
+	UserID             string
+	KYCTier            int
+	VerificationStatus string
+	DocumentExpiresAt  *time.Time
+	UpdatedBy          string
+}
+
+// UpsertProfile creates the profile or replaces its KYC fields. The frozen flag is left as is;
+// it only changes through SetProfileFrozen.
+func (r *Repo) UpsertProfile(
+	ctx context.Context,
+	db postgres.DBTX,
+	p UpsertProfileParams,
+) (Profile, error) {
+	return scanProfile(db.QueryRow(ctx, `
+		INSERT INTO identity.user_profiles (
+			user_id,
+			kyc_tier,
+			verification_status,
+			document_expires_at,
+			updated_by
+		)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE SET
+			kyc_tier = EXCLUDED.kyc_tier,
+			verification_status = EXCLUDED.verification_status,
+			document_expires_at = EXCLUDED.document_expires_at,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = now()
+		RETURNING `+profileColumns,
+		p.UserID,
+		p.KYCTier,
+		p.VerificationStatus,
+		p.DocumentExpiresAt,
+		p.UpdatedBy,
+	))
+}
+
+type SetProfileFrozenParams struct {
+	UserID    string
+	Frozen    bool
+	Reason    *string
+	UpdatedBy string
+}
+
+// SetProfileFrozen freezes or unfreezes an existing profile. It returns pgx.ErrNoRows when the
+// user has no profile.
+func (r *Repo) SetProfileFrozen(
+	ctx context.Context,
+	db postgres.DBTX,
+	p SetProfileFrozenParams,
+) (Profile, error) {
+	return scanProfile(db.QueryRow(ctx, `
+		UPDATE identity.user_profiles
+		SET
+			frozen = $2,
+			frozen_reason = $3,
+			updated_by = $4,
+			updated_at = now()
+		WHERE user_id = $1
+		RETURNING `+profileColumns,
+		p.UserID,
+		p.Frozen,
+		p.Reason,
+		p.UpdatedBy,
+	))
+}
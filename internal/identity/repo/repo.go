@@ -11,25 +11,40 @@ type Repo struct{}
 func New() *Repo { return &Repo{} }
 
 type VerifyAndEmitParams struct {
-	VerificationID  string
-	WithdrawalID    string
-	UserID          string
-	Status          string
-	Reason          *string
+	VerificationID string
+	WithdrawalID   string
+	UserID         string
+	Status         string
+	Reason         *string
+	// Provider and ReusedFrom are set when the decision reuses a cached provider verification.
+	Provider        *string
+	ReusedFrom      *string
 	OutboxEventType string
 	OutboxPayload   string
 	TraceID         string
+	Traceparent     *string
 	RouteKey        string
 }
 
 func (r *Repo) VerifyAndEmitTx(ctx context.Context, tx pgx.Tx, p VerifyAndEmitParams) error {
 	tag, err := tx.Exec(ctx, `
 		INSERT INTO identity.verifications
-			(verification_id, withdrawal_id, user_id, status, reason)
+			(verification_id, withdrawal_id, user_id, status, reason, trace_id, traceparent,
+			provider, reused_from, decided_at)
 		VALUES
-			($1, $2, $3, $4, $5)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, now())
 		ON CONFLICT (withdrawal_id) DO NOTHING
-	`, p.VerificationID, p.WithdrawalID, p.UserID, p.Status, p.Reason)
+	`,
+		p.VerificationID,
+		p.WithdrawalID,
+		p.UserID,
+		p.Status,
+		p.Reason,
+		p.TraceID,
+		p.Traceparent,
+		p.Provider,
+		p.ReusedFrom,
+	)
 	if err != nil {
 		return err
 	}
@@ -37,11 +52,32 @@ func (r *Repo) VerifyAndEmitTx(ctx context.Context, tx pgx.Tx, p VerifyAndEmitPa
 		return nil
 	}
 
-	_, err = tx.Exec(ctx, `
+	return r.insertOutboxTx(ctx, tx, outboxParams{
+		VerificationID: p.VerificationID,
+		EventType:      p.OutboxEventType,
+		Payload:        p.OutboxPayload,
+		TraceID:        p.TraceID,
+		Traceparent:    p.Traceparent,
+		RouteKey:       p.RouteKey,
+	})
+}
+
+type outboxParams struct {
+	VerificationID string
+	EventType      string
+	Payload        string
+	TraceID        string
+	Traceparent    *string
+	RouteKey       string
+}
+
+func (r *Repo) insertOutboxTx(ctx context.Context, tx pgx.Tx, p outboxParams) error {
+	_, err := tx.Exec(ctx, `
 		INSERT INTO identity.outbox_events
-			(event_id, aggregate_type, aggregate_id, event_type, payload_json, trace_id, route_key)
+			(event_id, aggregate_type, aggregate_id, event_type, payload_json, trace_id, route_key,
+			traceparent)
 		VALUES
-			(gen_random_uuid(), 'identity', $1, $2, $3, $4, $5)
-	`, p.VerificationID, p.OutboxEventType, p.OutboxPayload, p.TraceID, p.RouteKey)
+			(gen_random_uuid(), 'identity', $1, $2, $3, $4, $5, $6)
+	`, p.VerificationID, p.EventType, p.Payload, p.TraceID, p.RouteKey, p.Traceparent)
 	return err
 }
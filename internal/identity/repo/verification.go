@@ -0,0 +1,309 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type Verification struct {
+	VerificationID string
+	WithdrawalID   string
+	UserID         string
+	Status         string
+	Reason         *string
+	Provider       *string
+	TraceID        *string
+	Traceparent    *string
+	// ProfileUpdatedAt is the profile version a provider check was made against.
+	ProfileUpdatedAt *time.Time
+	ReusedFrom       *string
+	CreatedAt        time.Time
+	DecidedAt        *time.Time
+}
+
+const verificationColumns = `
+	verification_id,
+	withdrawal_id,
+	user_id,
+	status,
+	reason,
+	provider,
+	trace_id,
+	traceparent,
+	profile_updated_at,
+	reused_from,
+	created_at,
+	decided_at
+`
+
+func scanVerification(row pgx.Row) (Verification, error) {
+	var v Verification
+	err := row.Scan(
+		&v.VerificationID,
+		&v.WithdrawalID,
+		&v.UserID,
+		&v.Status,
+		&v.Reason,
+		&v.Provider,
+		&v.TraceID,
+		&v.Traceparent,
+		&v.ProfileUpdatedAt,
+		&v.ReusedFrom,
+		&v.CreatedAt,
+		&v.DecidedAt,
+	)
+	return v, err
+}
+
+func (r *Repo) GetVerificationByWithdrawal(
+	ctx context.Context,
+	db postgres.DBTX,
+	withdrawalID string,
+) (Verification, error) {
+	return scanVerification(db.QueryRow(ctx, `
+		SELECT `+verificationColumns+`
+		FROM identity.verifications
+		WHERE withdrawal_id = $1
+	`, withdrawalID))
+}
+
+// LockVerificationTx reads the verification and holds its row lock until tx ends.
+func (r *Repo) LockVerificationTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	verificationID string,
+) (Verification, error) {
+	return scanVerification(tx.QueryRow(ctx, `
+		SELECT `+verificationColumns+`
+		FROM identity.verifications
+		WHERE verification_id = $1
+		FOR UPDATE
+	`, verificationID))
+}
+
+type RecordPendingParams struct {
+	VerificationID string
+	WithdrawalID   string
+	UserID         string
+	Provider       string
+	TraceID        string
+	Traceparent    *string
+	// ProfileUpdatedAt is the profile version the check was made against.
+	ProfileUpdatedAt time.Time
+}
+
+// RecordPendingTx records a verification handed to an external provider. Nothing is emitted
+// until CompleteVerificationTx. It returns false when the withdrawal already has a verification.
+func (r *Repo) RecordPendingTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p RecordPendingParams,
+) (bool, error) {
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO identity.verifications
+			(verification_id, withdrawal_id, user_id, status, provider, trace_id, traceparent,
+			profile_updated_at)
+		VALUES
+			($1, $2, $3, 'PENDING', $4, $5, $6, $7)
+		ON CONFLICT (withdrawal_id) DO NOTHING
+	`,
+		p.VerificationID,
+		p.WithdrawalID,
+		p.UserID,
+		p.Provider,
+		p.TraceID,
+		p.Traceparent,
+		p.ProfileUpdatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListOverduePendingTx locks up to limit PENDING verifications created before before, oldest
+// first. Rows locked by another sweeper are skipped.
+func (r *Repo) ListOverduePendingTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	before time.Time,
+	limit int,
+) ([]Verification, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+verificationColumns+`
+		FROM identity.verifications
+		WHERE
+			status = 'PENDING'
+			AND created_at < $1
+		ORDER BY created_at ASC
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Verification
+	for rows.Next() {
+		v, err := scanVerification(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+type CompleteVerificationParams struct {
+	VerificationID  string
+	Status          string // VERIFIED | REJECTED
+	Reason          *string
+	OutboxEventType string
+	OutboxPayload   string
+	TraceID         string
+	Traceparent     *string
+	RouteKey        string
+}
+
+// CompleteVerificationTx decides a PENDING verification and emits its outbox event. It returns
+// false, emitting nothing, when the verification is no longer pending.
+func (r *Repo) CompleteVerificationTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p CompleteVerificationParams,
+) (bool, error) {
+	tag, err := tx.Exec(ctx, `
+		UPDATE identity.verifications
+		SET
+			status = $2,
+			reason = $3,
+			decided_at = now()
+		WHERE
+			verification_id = $1
+			AND status = 'PENDING'
+	`, p.VerificationID, p.Status, p.Reason)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	err = r.insertOutboxTx(ctx, tx, outboxParams{
+		VerificationID: p.VerificationID,
+		EventType:      p.OutboxEventType,
+		Payload:        p.OutboxPayload,
+		TraceID:        p.TraceID,
+		Traceparent:    p.Traceparent,
+		RouteKey:       p.RouteKey,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+type CachedVerification struct {
+	UserID           string
+	VerificationID   string
+	Provider         string
+	ProfileUpdatedAt time.Time
+	VerifiedAt       time.Time
+	ExpiresAt        time.Time
+}
+
+// GetCachedVerification returns the user's cached provider verification, expired or not.
+func (r *Repo) GetCachedVerification(
+	ctx context.Context,
+	db postgres.DBTX,
+	userID string,
+) (CachedVerification, error) {
+	var c CachedVerification
+	err := db.QueryRow(ctx, `
+		SELECT user_id, verification_id, provider, profile_updated_at, verified_at, expires_at
+		FROM identity.verification_cache
+		WHERE user_id = $1
+	`, userID).Scan(
+		&c.UserID,
+		&c.VerificationID,
+		&c.Provider,
+		&c.ProfileUpdatedAt,
+		&c.VerifiedAt,
+		&c.ExpiresAt,
+	)
+	return c, err
+}
+
+// PutCachedVerificationTx makes c the user's cached verification, replacing any older one.
+func (r *Repo) PutCachedVerificationTx(ctx context.Context, tx pgx.Tx, c CachedVerification) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO identity.verification_cache
+			(user_id, verification_id, provider, profile_updated_at, verified_at, expires_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE SET
+			verification_id = EXCLUDED.verification_id,
+			provider = EXCLUDED.provider,
+			profile_updated_at = EXCLUDED.profile_updated_at,
+			verified_at = EXCLUDED.verified_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE identity.verification_cache.verified_at <= EXCLUDED.verified_at
+	`, c.UserID, c.VerificationID, c.Provider, c.ProfileUpdatedAt, c.VerifiedAt, c.ExpiresAt)
+	return err
+}
+
+// VerificationCursor is the position after the last verification of a page, in
+// (created_at, verification_id) descending order.
+type VerificationCursor struct {
+	CreatedAt      time.Time
+	VerificationID string
+}
+
+type ListUserVerificationsParams struct {
+	UserID string
+	After  *VerificationCursor
+	Limit  int
+}
+
+// ListUserVerifications returns the user's verifications newest first, using
+// idx_identity_user_created.
+func (r *Repo) ListUserVerifications(
+	ctx context.Context,
+	db postgres.DBTX,
+	p ListUserVerificationsParams,
+) ([]Verification, error) {
+	var afterCreated *time.Time
+	var afterID *string
+	if p.After != nil {
+		afterCreated, afterID = &p.After.CreatedAt, &p.After.VerificationID
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT `+verificationColumns+`
+		FROM identity.verifications
+		WHERE
+			user_id = $1
+			AND ($2::timestamptz IS NULL OR (created_at, verification_id) < ($2, $3::uuid))
+		ORDER BY created_at DESC, verification_id DESC
+		LIMIT $4
+	`, p.UserID, afterCreated, afterID, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Verification
+	for rows.Next() {
+		v, err := scanVerification(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,96 @@
+package verifier
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/shared/identity"
+)
+
+// Profile is the part of a user's identity profile the checks look at.
+type Profile struct {
+	KYCTier            int
+	VerificationStatus string
+	DocumentExpiresAt  *time.Time
+	Frozen             bool
+	FrozenReason       *string
+}
+
+// Input is gathered by the caller so Verify stays a pure function of it. Profile is nil when
+// the user has none; TierLimit is nil when the user's tier has no limit for the asset.
+// SkipTierLimit leaves out the tier check, for legacy commands that carry no asset or amount.
+type Input struct {
+	Profile       *Profile
+	Asset         string
+	AmountMinor   int64
+	TierLimit     *int64
+	SkipTierLimit bool
+	Now           time.Time
+}
+
+type Decision struct {
+	Status string // VERIFIED | REJECTED
+	Reason *string
+}
+
+func (d Decision) Verified() bool {
+	return d.Status == identity.IdentityStatusVerified
+}
+
+// Verify applies the checks in order and rejects on the first failure, so the reason names the
+// most fundamental problem: no profile, frozen, not verified, expired document, then tier.
+func Verify(in Input) Decision {
+	p := in.Profile
+	if p == nil {
+		return reject("no identity profile on file")
+	}
+
+	if p.Frozen {
+		reason := "account is frozen"
+		if p.FrozenReason != nil && strings.TrimSpace(*p.FrozenReason) != "" {
+			reason += ": " + *p.FrozenReason
+		}
+		return reject(reason)
+	}
+
+	switch p.VerificationStatus {
+	case identity.ProfileStatusVerified:
+	case identity.ProfileStatusPending:
+		return reject("identity verification is still pending")
+	case identity.ProfileStatusRejected:
+		return reject("identity verification was rejected")
+	default:
+		return reject("identity is not verified")
+	}
+
+	if p.DocumentExpiresAt == nil {
+		return reject("identity document expiry is not on file")
+	}
+	if !in.Now.Before(*p.DocumentExpiresAt) {
+		return reject(fmt.Sprintf("identity document expired on %s",
+			p.DocumentExpiresAt.UTC().Format(time.DateOnly),
+		))
+	}
+
+	if in.SkipTierLimit {
+		return Decision{Status: identity.IdentityStatusVerified}
+	}
+	if in.TierLimit == nil {
+		return reject(fmt.Sprintf("kyc tier %d may not withdraw %s", p.KYCTier, in.Asset))
+	}
+	if in.AmountMinor > *in.TierLimit {
+		return reject(fmt.Sprintf("amount_minor %d exceeds the kyc tier %d limit of %d for %s",
+			in.AmountMinor,
+			p.KYCTier,
+			*in.TierLimit,
+			in.Asset,
+		))
+	}
+
+	return Decision{Status: identity.IdentityStatusVerified}
+}
+
+func reject(reason string) Decision {
+	return Decision{Status: identity.IdentityStatusRejected, Reason: &reason}
+}
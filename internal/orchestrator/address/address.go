@@ -0,0 +1,88 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrInvalidAddress = errors.New("invalid destination address")
+
+// Validator checks an address for one network and returns its canonical form, so equivalent
+// spellings (e.g. bech32 case, EIP-55 checksum case) compare equal.
+type Validator interface {
+	Canonicalize(addr string) (string, error)
+}
+
+type ValidatorFunc func(addr string) (string, error)
+
+func (f ValidatorFunc) Canonicalize(addr string) (string, error) {
+	return f(addr)
+}
+
+// Registry maps an asset network to its validator. Networks without a dedicated validator use
+// the fallback.
+type Registry struct {
+	validators map[string]Validator
+	fallback   Validator
+}
+
+func NewRegistry(fallback Validator) *Registry {
+	return &Registry{
+		validators: make(map[string]Validator),
+		fallback:   fallback,
+	}
+}
+
+func (r *Registry) Register(network string, v Validator) {
+	r.validators[strings.ToLower(network)] = v
+}
+
+func (r *Registry) Canonicalize(network string, addr string) (string, error) {
+	v, ok := r.validators[strings.ToLower(network)]
+	if !ok {
+		v = r.fallback
+	}
+
+	canonical, err := v.Canonicalize(strings.TrimSpace(addr))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	return canonical, nil
+}
+
+// Pattern accepts any address matching re unchanged. It is the fallback for networks without
+// a checksum-aware validator.
+func Pattern(re *regexp.Regexp) Validator {
+	return ValidatorFunc(func(addr string) (string, error) {
+		if !re.MatchString(addr) {
+			return "", fmt.Errorf("does not match %s", re)
+		}
+		return addr, nil
+	})
+}
+
+type Options struct {
+	// BitcoinChain is mainnet, testnet or regtest.
+	BitcoinChain    string
+	FallbackPattern string
+}
+
+// NewDefaultRegistry registers the bitcoin and ethereum validators and a pattern fallback.
+func NewDefaultRegistry(opts Options) (*Registry, error) {
+	re, err := regexp.Compile(opts.FallbackPattern)
+	if err != nil {
+		return nil, fmt.Errorf("address fallback pattern: %w", err)
+	}
+
+	btc, err := NewBitcoin(opts.BitcoinChain)
+	if err != nil {
+		return nil, err
+	}
+
+	r := NewRegistry(Pattern(re))
+	r.Register(NetworkBitcoin, btc)
+	r.Register(NetworkEthereum, Ethereum())
+	return r, nil
+}
@@ -0,0 +1,62 @@
+package address
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var base58Index = func() [256]int {
+	var idx [256]int
+	for i := range idx {
+		idx[i] = -1
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		idx[base58Alphabet[i]] = i
+	}
+	return idx
+}()
+
+func base58Decode(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i := 0; i < len(s); i++ {
+		d := base58Index[s[i]]
+		if d < 0 {
+			return nil, errors.New("invalid base58 character")
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == '1' {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// base58CheckDecode returns the version byte and payload after verifying the double-SHA256
+// checksum.
+func base58CheckDecode(s string) (byte, []byte, error) {
+	b, err := base58Decode(s)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(b) < 5 {
+		return 0, nil, errors.New("base58check too short")
+	}
+
+	body, checksum := b[:len(b)-4], b[len(b)-4:]
+	first := sha256.Sum256(body)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], checksum) {
+		return 0, nil, errors.New("base58check checksum mismatch")
+	}
+
+	return body[0], body[1:], nil
+}
@@ -0,0 +1,144 @@
+package address
+
+import (
+	"errors"
+	"strings"
+)
+
+// Segwit address decoding per BIP-173 (bech32) and BIP-350 (bech32m).
+
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+func bech32Polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// bech32Decode returns the lower-cased hrp, the data part without checksum, and the checksum
+// constant it verified against.
+func bech32Decode(s string) (string, []byte, uint32, error) {
+	if len(s) > 90 {
+		return "", nil, 0, errors.New("bech32 string too long")
+	}
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, 0, errors.New("bech32 string has mixed case")
+	}
+	s = strings.ToLower(s)
+
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, 0, errors.New("bech32 separator misplaced")
+	}
+
+	hrp := s[:pos]
+	for i := 0; i < len(hrp); i++ {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, errors.New("bech32 hrp has invalid character")
+		}
+	}
+
+	data := make([]byte, 0, len(s)-pos-1)
+	for i := pos + 1; i < len(s); i++ {
+		d := strings.IndexByte(bech32Charset, s[i])
+		if d < 0 {
+			return "", nil, 0, errors.New("bech32 data has invalid character")
+		}
+		data = append(data, byte(d))
+	}
+
+	c := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	if c != bech32Const && c != bech32mConst {
+		return "", nil, 0, errors.New("bech32 checksum mismatch")
+	}
+
+	return hrp, data[:len(data)-6], c, nil
+}
+
+func convertBits(data []byte, from, to uint, pad bool) ([]byte, error) {
+	var (
+		acc  uint32
+		bits uint
+		out  []byte
+	)
+	maxv := uint32(1)<<to - 1
+	for _, v := range data {
+		if uint32(v)>>from != 0 {
+			return nil, errors.New("invalid data range")
+		}
+		acc = acc<<from | uint32(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return out, nil
+}
+
+// decodeSegwit validates a segwit address for hrp and returns it lower-cased.
+func decodeSegwit(hrp string, addr string) (string, error) {
+	gotHRP, data, c, err := bech32Decode(addr)
+	if err != nil {
+		return "", err
+	}
+	if gotHRP != hrp {
+		return "", errors.New("segwit address for a different chain")
+	}
+	if len(data) < 1 {
+		return "", errors.New("segwit address missing witness version")
+	}
+
+	version := data[0]
+	if version > 16 {
+		return "", errors.New("invalid witness version")
+	}
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return "", err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return "", errors.New("invalid witness program length")
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return "", errors.New("invalid v0 witness program length")
+	}
+	if (version == 0 && c != bech32Const) || (version != 0 && c != bech32mConst) {
+		return "", errors.New("wrong bech32 variant for witness version")
+	}
+
+	return strings.ToLower(addr), nil
+}
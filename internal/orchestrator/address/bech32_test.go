@@ -0,0 +1,56 @@
+package address
+
+import "testing"
+
+// Test vectors from BIP-173 and BIP-350.
+
+func TestBech32Decode(t *testing.T) {
+	valid := []struct {
+		in      string
+		variant uint32
+	}{
+		{"A12UEL5L", bech32Const},
+		{"a12uel5l", bech32Const},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", bech32Const},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", bech32Const},
+		{"?1ezyfcl", bech32Const},
+		{"A1LQFN3A", bech32mConst},
+		{"a1lqfn3a", bech32mConst},
+		{"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", bech32mConst},
+		{"split1checkupstagehandshakeupstreamerranterredcaperredlc445v", bech32mConst},
+		{"?1v759aa", bech32mConst},
+	}
+	for _, tc := range valid {
+		_, _, c, err := bech32Decode(tc.in)
+		if err != nil {
+			t.Errorf("bech32Decode(%q): %v", tc.in, err)
+			continue
+		}
+		if c != tc.variant {
+			t.Errorf("bech32Decode(%q) constant = %#x, want %#x", tc.in, c, tc.variant)
+		}
+	}
+
+	invalid := []struct {
+		name string
+		in   string
+	}{
+		{"hrp character out of range", "\x201nwldj5"},
+		{"hrp character out of range", "\x7f1axkwrx"},
+		{"no separator", "pzry9x0s0muk"},
+		{"empty hrp", "1pzry9x0s0muk"},
+		{"invalid data character", "x1b4n0q5v"},
+		{"checksum too short", "li1dgmt3"},
+		{"empty hrp", "10a06t8"},
+		{"empty hrp", "1qzzfhee"},
+		{"checksum computed with uppercase hrp", "A1G7SGD8"},
+		{"mixed case", "a12UEL5L"},
+		{"invalid checksum", "a12uel5m"},
+		{"invalid checksum", "a1lqfn3q"},
+	}
+	for _, tc := range invalid {
+		if _, _, _, err := bech32Decode(tc.in); err == nil {
+			t.Errorf("bech32Decode(%q) accepted, want error: %s", tc.in, tc.name)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	NetworkBitcoin  = "bitcoin"
+	NetworkEthereum = "ethereum"
+)
+
+type bitcoinChain struct {
+	hrp   string
+	p2pkh byte
+	p2sh  byte
+}
+
+var bitcoinChains = map[string]bitcoinChain{
+	"mainnet": {hrp: "bc", p2pkh: 0x00, p2sh: 0x05},
+	"testnet": {hrp: "tb", p2pkh: 0x6f, p2sh: 0xc4},
+	"regtest": {hrp: "bcrt", p2pkh: 0x6f, p2sh: 0xc4},
+}
+
+// NewBitcoin validates legacy base58check (P2PKH/P2SH) and segwit bech32/bech32m addresses for
+// chain. Segwit addresses are canonicalized to lower case; base58 is case-sensitive and kept.
+func NewBitcoin(chain string) (Validator, error) {
+	c, ok := bitcoinChains[chain]
+	if !ok {
+		return nil, fmt.Errorf("unknown bitcoin chain %q", chain)
+	}
+
+	return ValidatorFunc(func(addr string) (string, error) {
+		if strings.HasPrefix(strings.ToLower(addr), c.hrp+"1") {
+			return decodeSegwit(c.hrp, addr)
+		}
+
+		version, payload, err := base58CheckDecode(addr)
+		if err != nil {
+			return "", err
+		}
+		if len(payload) != 20 {
+			return "", errors.New("invalid base58 payload length")
+		}
+		if version != c.p2pkh && version != c.p2sh {
+			return "", errors.New("base58 address for a different chain")
+		}
+		return addr, nil
+	}), nil
+}
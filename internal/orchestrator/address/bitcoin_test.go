@@ -0,0 +1,193 @@
+package address
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBitcoinSegwit(t *testing.T) {
+	valid := []struct {
+		chain string
+		in    string
+	}{
+		// BIP-173
+		{"mainnet", "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4"},
+		{"testnet", "tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sl5k7"},
+		{"testnet", "tb1qqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesrxh6hy"},
+		// BIP-350
+		{"mainnet", "bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kt5nd6y"},
+		{"mainnet", "BC1SW50QGDZ25J"},
+		{"mainnet", "bc1zw508d6qejxtdg4y5r3zarvaryvaxxpcs"},
+		{"testnet", "tb1pqqqqp399et2xygdj5xreqhjjvcmzhxw4aywxecjdzew6hylgvsesf3hn0c"},
+		{"mainnet", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0"},
+	}
+	for _, tc := range valid {
+		v, err := NewBitcoin(tc.chain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := v.Canonicalize(tc.in)
+		if err != nil {
+			t.Errorf("%s %q: %v", tc.chain, tc.in, err)
+			continue
+		}
+		if got != strings.ToLower(tc.in) {
+			t.Errorf("%s %q canonical = %q, want lower case", tc.chain, tc.in, got)
+		}
+	}
+
+	invalid := []struct {
+		name  string
+		chain string
+		in    string
+	}{
+		// BIP-173
+		{"invalid hrp", "mainnet", "tc1qw508d6qejxtdg4y5r3zarvary0c5xw7kg3g4ty"},
+		{"invalid checksum", "mainnet", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5"},
+		{"invalid witness version", "mainnet", "BC13W508D6QEJXTDG4Y5R3ZARVARY0C5XW7KN40WF2"},
+		{"invalid program length", "mainnet", "bc1rw5uspcuh"},
+		{
+			"invalid program length",
+			"mainnet",
+			"bc10w508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7kw5rljs90",
+		},
+		{"invalid v0 program length", "mainnet", "BC1QR508D6QEJXTDG4Y5R3ZARVARYV98GJ9P"},
+		{
+			"mixed case",
+			"testnet",
+			"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3q0sL5k7",
+		},
+		{"zero padding of more than 4 bits", "mainnet", "bc1zw508d6qejxtdg4y5r3zarvaryvqyzf3du"},
+		{
+			"non-zero padding",
+			"testnet",
+			"tb1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3pjxtptv",
+		},
+		{"empty data", "mainnet", "bc1gmk9yu"},
+		{"bech32 checksum for witness v2", "mainnet", "bc1zw508d6qejxtdg4y5r3zarvaryvg6kdaj"},
+		// BIP-350
+		{
+			"invalid hrp",
+			"testnet",
+			"tc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq5zuyut",
+		},
+		{
+			"bech32 checksum for witness v1",
+			"mainnet",
+			"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqh2y7hd",
+		},
+		{
+			"bech32 checksum for witness v1",
+			"testnet",
+			"tb1z0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqglt7rf",
+		},
+		{
+			"bech32 checksum for witness v16",
+			"mainnet",
+			"BC1S0XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ54WELL",
+		},
+		{
+			"bech32m checksum for witness v0",
+			"mainnet",
+			"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh",
+		},
+		{
+			"bech32m checksum for witness v0",
+			"testnet",
+			"tb1q0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq24jc47",
+		},
+		{
+			"invalid data character",
+			"mainnet",
+			"bc1p38j9r5y49hruaue7wxjce0updqjuyyx0kh56v8s25huc6995vvpql3jow4",
+		},
+		{
+			"invalid witness version",
+			"mainnet",
+			"BC130XLXVLHEMJA6C4DQV22UAPCTQUPFHLXM9H8Z3K2E72Q4K9HCZ7VQ7ZWS8R",
+		},
+		{"invalid program length", "mainnet", "bc1pw5dgrnzv"},
+		{
+			"invalid program length",
+			"mainnet",
+			"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v8n0nx0muaewav253zgeav",
+		},
+		{
+			"mixed case",
+			"testnet",
+			"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vq47Zagq",
+		},
+		{
+			"zero padding of more than 4 bits",
+			"mainnet",
+			"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7v07qwwzcrf",
+		},
+		{
+			"non-zero padding",
+			"testnet",
+			"tb1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vpggkg4j",
+		},
+		// Valid, but for another chain.
+		{"mainnet address on testnet", "testnet", "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4"},
+	}
+	for _, tc := range invalid {
+		v, err := NewBitcoin(tc.chain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := v.Canonicalize(tc.in); err == nil {
+			t.Errorf("%s %q accepted as %q, want error: %s", tc.chain, tc.in, got, tc.name)
+		}
+	}
+}
+
+func TestBitcoinBase58Check(t *testing.T) {
+	valid := []struct {
+		chain string
+		in    string
+	}{
+		{"mainnet", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+		{"mainnet", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"},
+		{"mainnet", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"},
+		{"testnet", "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn"},
+		{"testnet", "2MzQwSSnBHWHqSAqtTVQ6v47XtaisrJa1Vc"},
+		{"regtest", "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn"},
+	}
+	for _, tc := range valid {
+		v, err := NewBitcoin(tc.chain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := v.Canonicalize(tc.in)
+		if err != nil {
+			t.Errorf("%s %q: %v", tc.chain, tc.in, err)
+			continue
+		}
+		if got != tc.in {
+			t.Errorf("%s %q canonical = %q, want unchanged", tc.chain, tc.in, got)
+		}
+	}
+
+	invalid := []struct {
+		name  string
+		chain string
+		in    string
+	}{
+		{"invalid checksum", "mainnet", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN3"},
+		{"invalid checksum", "mainnet", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLz"},
+		{"case changed", "mainnet", "1bvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"},
+		{"invalid character", "mainnet", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN0"},
+		{"too short", "mainnet", "1111"},
+		{"testnet address on mainnet", "mainnet", "mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn"},
+		{"mainnet address on testnet", "testnet", "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"},
+	}
+	for _, tc := range invalid {
+		v, err := NewBitcoin(tc.chain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := v.Canonicalize(tc.in); err == nil {
+			t.Errorf("%s %q accepted as %q, want error: %s", tc.chain, tc.in, got, tc.name)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package address
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Ethereum validates 0x-prefixed 20-byte hex addresses. Mixed-case input must carry a valid
+// EIP-55 checksum; all-lower or all-upper input carries none. The canonical form is checksummed.
+func Ethereum() Validator {
+	return ValidatorFunc(func(addr string) (string, error) {
+		if len(addr) != 42 || !strings.HasPrefix(addr, "0x") {
+			return "", errors.New("ethereum address must be 0x followed by 40 hex characters")
+		}
+
+		body := addr[2:]
+		if _, err := hex.DecodeString(body); err != nil {
+			return "", errors.New("ethereum address is not hex")
+		}
+
+		checksummed := eip55(body)
+		if body != strings.ToLower(body) && body != strings.ToUpper(body) && body != checksummed[2:] {
+			return "", errors.New("ethereum address checksum mismatch")
+		}
+		return checksummed, nil
+	})
+}
+
+func eip55(body string) string {
+	lower := strings.ToLower(body)
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(lower))
+	sum := h.Sum(nil)
+
+	out := []byte(lower)
+	for i, c := range out {
+		if c < 'a' || c > 'f' {
+			continue
+		}
+		nibble := sum[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
@@ -0,0 +1,62 @@
+package address
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test vectors from EIP-55.
+var eip55Vectors = []string{
+	// All caps
+	"0x52908400098527886E0F7030069857D2E4169EE7",
+	"0x8617E340B3D01FA5F11F306F4090FD50E238070D",
+	// All lower
+	"0xde709f2102306220921060314715629080e2fb77",
+	"0x27b1fdb04752bbc536007a920d24acb045561c26",
+	// Normal
+	"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+	"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+	"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+}
+
+func TestEthereum(t *testing.T) {
+	v := Ethereum()
+	for _, want := range eip55Vectors {
+		if got := eip55(strings.ToLower(want[2:])); got != want {
+			t.Errorf("eip55(%q) = %q, want %q", strings.ToLower(want), got, want)
+		}
+
+		body := want[2:]
+		for _, in := range []string{want, "0x" + strings.ToLower(body), "0x" + strings.ToUpper(body)} {
+			got, err := v.Canonicalize(in)
+			if err != nil {
+				t.Errorf("%q: %v", in, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("%q canonical = %q, want %q", in, got, want)
+			}
+		}
+	}
+
+	invalid := []struct {
+		name string
+		in   string
+	}{
+		{"invalid checksum", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"},
+		{"invalid checksum", "0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{"invalid checksum", "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d35A"},
+		{"invalid checksum", "0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9adb"},
+		{"no prefix", "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{"uppercase prefix", "0X5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{"too short", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe"},
+		{"too long", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed0"},
+		{"not hex", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg"},
+	}
+	for _, tc := range invalid {
+		if got, err := v.Canonicalize(tc.in); err == nil {
+			t.Errorf("%q accepted as %q, want error: %s", tc.in, got, tc.name)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	orchestratorv1 "github.com/cicconee/cbsaga/gen/orchestrator/v1"
+	"github.com/cicconee/cbsaga/internal/orchestrator/app"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AddressBookHandler struct {
+	orchestratorv1.UnimplementedAddressBookServiceServer
+	svc *app.Service
+	log *logging.Logger
+}
+
+func NewAddressBookHandler(svc *app.Service, log *logging.Logger) *AddressBookHandler {
+	return &AddressBookHandler{svc: svc, log: log}
+}
+
+func (h *AddressBookHandler) AddWithdrawalAddress(
+	ctx context.Context,
+	req *orchestratorv1.AddWithdrawalAddressRequest,
+) (*orchestratorv1.AddWithdrawalAddressResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	a, err := h.svc.AddWithdrawalAddress(ctx, app.AddWithdrawalAddressParams{
+		UserID:    req.GetUserId(),
+		Asset:     req.GetAsset(),
+		Address:   req.GetAddress(),
+		Label:     req.GetLabel(),
+		Principal: principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "AddWithdrawalAddress", err)
+	}
+
+	return &orchestratorv1.AddWithdrawalAddressResponse{Address: toWithdrawalAddressPB(a)}, nil
+}
+
+func (h *AddressBookHandler) ListWithdrawalAddresses(
+	ctx context.Context,
+	req *orchestratorv1.ListWithdrawalAddressesRequest,
+) (*orchestratorv1.ListWithdrawalAddressesResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	addrs, err := h.svc.ListWithdrawalAddresses(ctx, app.ListWithdrawalAddressesParams{
+		UserID:    req.GetUserId(),
+		Principal: principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "ListWithdrawalAddresses", err)
+	}
+
+	resp := &orchestratorv1.ListWithdrawalAddressesResponse{}
+	for _, a := range addrs {
+		resp.Addresses = append(resp.Addresses, toWithdrawalAddressPB(a))
+	}
+	return resp, nil
+}
+
+func (h *AddressBookHandler) RemoveWithdrawalAddress(
+	ctx context.Context,
+	req *orchestratorv1.RemoveWithdrawalAddressRequest,
+) (*orchestratorv1.RemoveWithdrawalAddressResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	err := h.svc.RemoveWithdrawalAddress(ctx, app.RemoveWithdrawalAddressParams{
+		UserID:    req.GetUserId(),
+		AddressID: req.GetAddressId(),
+		Principal: principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "RemoveWithdrawalAddress", err)
+	}
+
+	return &orchestratorv1.RemoveWithdrawalAddressResponse{}, nil
+}
+
+func (h *AddressBookHandler) toStatus(ctx context.Context, method string, err error) error {
+	switch {
+	case errors.Is(err, app.ErrPrincipalMismatch):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, app.ErrInvalidInput),
+		errors.Is(err, app.ErrInvalidDestination),
+		errors.Is(err, app.ErrUnknownAsset):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, app.ErrWithdrawalAddressExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, app.ErrWithdrawalAddressNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		h.log.ErrorContext(ctx, method+" failed", "err", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func toWithdrawalAddressPB(a app.WithdrawalAddress) *orchestratorv1.WithdrawalAddress {
+	return &orchestratorv1.WithdrawalAddress{
+		AddressId: a.ID,
+		UserId:    a.UserID,
+		Network:   a.Network,
+		Address:   a.Address,
+		Label:     a.Label,
+		UsableAt:  a.UsableAt.Format(time.RFC3339Nano),
+		CreatedAt: a.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
@@ -0,0 +1,237 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	orchestratorv1 "github.com/cicconee/cbsaga/gen/orchestrator/v1"
+	"github.com/cicconee/cbsaga/internal/orchestrator/app"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ApprovalHandler struct {
+	orchestratorv1.UnimplementedApprovalServiceServer
+	svc *app.Service
+	log *logging.Logger
+}
+
+func NewApprovalHandler(svc *app.Service, log *logging.Logger) *ApprovalHandler {
+	return &ApprovalHandler{svc: svc, log: log}
+}
+
+func (h *ApprovalHandler) UpsertApprovalPolicy(
+	ctx context.Context,
+	req *orchestratorv1.UpsertApprovalPolicyRequest,
+) (*orchestratorv1.UpsertApprovalPolicyResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	p, err := h.svc.UpsertApprovalPolicy(ctx, app.UpsertApprovalPolicyParams{
+		Asset:             req.GetAsset(),
+		MinAmountMinor:    req.GetMinAmountMinor(),
+		RequiredApprovals: int(req.GetRequiredApprovals()),
+		ApproverRole:      req.GetApproverRole(),
+		Principal:         principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "UpsertApprovalPolicy", err)
+	}
+
+	return &orchestratorv1.UpsertApprovalPolicyResponse{Policy: toApprovalPolicyPB(p)}, nil
+}
+
+func (h *ApprovalHandler) ListApprovalPolicies(
+	ctx context.Context,
+	_ *orchestratorv1.ListApprovalPoliciesRequest,
+) (*orchestratorv1.ListApprovalPoliciesResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	policies, err := h.svc.ListApprovalPolicies(ctx, principal)
+	if err != nil {
+		return nil, h.toStatus(ctx, "ListApprovalPolicies", err)
+	}
+
+	resp := &orchestratorv1.ListApprovalPoliciesResponse{}
+	for _, p := range policies {
+		resp.Policies = append(resp.Policies, toApprovalPolicyPB(p))
+	}
+	return resp, nil
+}
+
+func (h *ApprovalHandler) DeleteApprovalPolicy(
+	ctx context.Context,
+	req *orchestratorv1.DeleteApprovalPolicyRequest,
+) (*orchestratorv1.DeleteApprovalPolicyResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	p, err := h.svc.DeleteApprovalPolicy(ctx, req.GetPolicyId(), principal)
+	if err != nil {
+		return nil, h.toStatus(ctx, "DeleteApprovalPolicy", err)
+	}
+
+	return &orchestratorv1.DeleteApprovalPolicyResponse{Policy: toApprovalPolicyPB(p)}, nil
+}
+
+func (h *ApprovalHandler) ListPendingApprovals(
+	ctx context.Context,
+	req *orchestratorv1.ListPendingApprovalsRequest,
+) (*orchestratorv1.ListPendingApprovalsResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	approvals, err := h.svc.ListPendingApprovals(ctx, app.ListApprovalsParams{
+		PageSize:  int(req.GetPageSize()),
+		Principal: principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "ListPendingApprovals", err)
+	}
+
+	resp := &orchestratorv1.ListPendingApprovalsResponse{}
+	for _, a := range approvals {
+		resp.Approvals = append(resp.Approvals, toApprovalPB(a))
+	}
+	return resp, nil
+}
+
+func (h *ApprovalHandler) GetApproval(
+	ctx context.Context,
+	req *orchestratorv1.GetApprovalRequest,
+) (*orchestratorv1.GetApprovalResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	a, err := h.svc.GetApproval(ctx, req.GetWithdrawalId(), principal)
+	if err != nil {
+		return nil, h.toStatus(ctx, "GetApproval", err)
+	}
+
+	return &orchestratorv1.GetApprovalResponse{Approval: toApprovalPB(a)}, nil
+}
+
+func (h *ApprovalHandler) ApproveWithdrawal(
+	ctx context.Context,
+	req *orchestratorv1.ApproveWithdrawalRequest,
+) (*orchestratorv1.ApproveWithdrawalResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	a, err := h.svc.ApproveWithdrawal(ctx, app.DecideApprovalParams{
+		WithdrawalID: req.GetWithdrawalId(),
+		Notes:        req.GetNotes(),
+		TraceID:      tracing.TraceID(ctx),
+		Principal:    principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "ApproveWithdrawal", err)
+	}
+
+	return &orchestratorv1.ApproveWithdrawalResponse{Approval: toApprovalPB(a)}, nil
+}
+
+func (h *ApprovalHandler) RejectWithdrawal(
+	ctx context.Context,
+	req *orchestratorv1.RejectWithdrawalRequest,
+) (*orchestratorv1.RejectWithdrawalResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	a, err := h.svc.RejectWithdrawal(ctx, app.DecideApprovalParams{
+		WithdrawalID: req.GetWithdrawalId(),
+		Notes:        req.GetNotes(),
+		TraceID:      tracing.TraceID(ctx),
+		Principal:    principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "RejectWithdrawal", err)
+	}
+
+	return &orchestratorv1.RejectWithdrawalResponse{Approval: toApprovalPB(a)}, nil
+}
+
+func (h *ApprovalHandler) toStatus(ctx context.Context, method string, err error) error {
+	switch {
+	case errors.Is(err, app.ErrAdminRequired),
+		errors.Is(err, app.ErrApproverRequired),
+		errors.Is(err, app.ErrSelfApproval):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, app.ErrInvalidInput),
+		errors.Is(err, app.ErrUnknownAsset):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, app.ErrApprovalNotFound),
+		errors.Is(err, app.ErrApprovalPolicyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, app.ErrApprovalClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, app.ErrApprovalVoteConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		h.log.ErrorContext(ctx, method+" failed", "err", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func toApprovalPolicyPB(p app.ApprovalPolicy) *orchestratorv1.ApprovalPolicy {
+	return &orchestratorv1.ApprovalPolicy{
+		PolicyId:          p.PolicyID,
+		Asset:             p.Asset,
+		MinAmountMinor:    p.MinAmountMinor,
+		RequiredApprovals: int32(p.RequiredApprovals),
+		ApproverRole:      p.ApproverRole,
+		UpdatedBy:         p.UpdatedBy,
+		CreatedAt:         p.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:         p.UpdatedAt.Format(time.RFC3339Nano),
+	}
+}
+
+func toApprovalPB(a app.Approval) *orchestratorv1.Approval {
+	pb := &orchestratorv1.Approval{
+		WithdrawalId:      a.WithdrawalID,
+		UserId:            a.UserID,
+		Asset:             a.Asset,
+		AmountMinor:       a.AmountMinor,
+		DestinationAddr:   a.DestinationAddr,
+		PolicyId:          a.PolicyID,
+		RequiredApprovals: int32(a.RequiredApprovals),
+		ApproverRole:      a.ApproverRole,
+		Status:            a.Status,
+		CreatedAt:         a.CreatedAt.Format(time.RFC3339Nano),
+	}
+	if a.DecidedAt != nil {
+		pb.DecidedAt = a.DecidedAt.Format(time.RFC3339Nano)
+	}
+	for _, v := range a.Votes {
+		vote := &orchestratorv1.ApprovalVote{
+			Approver:  v.Approver,
+			Decision:  v.Decision,
+			Roles:     v.Roles,
+			CreatedAt: v.CreatedAt.Format(time.RFC3339Nano),
+		}
+		if v.Notes != nil {
+			vote.Notes = *v.Notes
+		}
+		pb.Votes = append(pb.Votes, vote)
+	}
+	return pb
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	orchestratorv1 "github.com/cicconee/cbsaga/gen/orchestrator/v1"
+	"github.com/cicconee/cbsaga/internal/orchestrator/app"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AssetAdminHandler struct {
+	orchestratorv1.UnimplementedAssetAdminServiceServer
+	svc *app.Service
+	log *logging.Logger
+}
+
+func NewAssetAdminHandler(svc *app.Service, log *logging.Logger) *AssetAdminHandler {
+	return &AssetAdminHandler{svc: svc, log: log}
+}
+
+func (h *AssetAdminHandler) UpsertAsset(
+	ctx context.Context,
+	req *orchestratorv1.UpsertAssetRequest,
+) (*orchestratorv1.UpsertAssetResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	a, err := h.svc.UpsertAsset(ctx, app.UpsertAssetParams{
+		Asset:                   req.GetAsset(),
+		Network:                 req.GetNetwork(),
+		Decimals:                req.GetDecimals(),
+		MinAmountMinor:          req.GetMinAmountMinor(),
+		MaxAmountMinor:          req.GetMaxAmountMinor(),
+		ChallengeThresholdMinor: req.GetChallengeThresholdMinor(),
+		Confirmations:           req.GetConfirmations(),
+		Principal:               principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "UpsertAsset", err)
+	}
+
+	return &orchestratorv1.UpsertAssetResponse{Asset: toAssetPB(a)}, nil
+}
+
+func (h *AssetAdminHandler) SetAssetStatus(
+	ctx context.Context,
+	req *orchestratorv1.SetAssetStatusRequest,
+) (*orchestratorv1.SetAssetStatusResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	a, err := h.svc.SetAssetStatus(ctx, app.SetAssetStatusParams{
+		Asset:     req.GetAsset(),
+		Status:    req.GetStatus(),
+		Principal: principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "SetAssetStatus", err)
+	}
+
+	return &orchestratorv1.SetAssetStatusResponse{Asset: toAssetPB(a)}, nil
+}
+
+func (h *AssetAdminHandler) ListAssets(
+	ctx context.Context,
+	_ *orchestratorv1.ListAssetsRequest,
+) (*orchestratorv1.ListAssetsResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	assets, err := h.svc.ListAssets(ctx, principal)
+	if err != nil {
+		return nil, h.toStatus(ctx, "ListAssets", err)
+	}
+
+	resp := &orchestratorv1.ListAssetsResponse{}
+	for _, a := range assets {
+		resp.Assets = append(resp.Assets, toAssetPB(a))
+	}
+	return resp, nil
+}
+
+func (h *AssetAdminHandler) toStatus(ctx context.Context, method string, err error) error {
+	switch {
+	case errors.Is(err, app.ErrAdminRequired):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, app.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, app.ErrUnknownAsset):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, app.ErrAssetNetworkChanged):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		h.log.ErrorContext(ctx, method+" failed", "err", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func toAssetPB(a app.Asset) *orchestratorv1.Asset {
+	return &orchestratorv1.Asset{
+		Asset:                   a.Asset,
+		Network:                 a.Network,
+		Decimals:                a.Decimals,
+		MinAmountMinor:          a.MinAmountMinor,
+		MaxAmountMinor:          a.MaxAmountMinor,
+		Status:                  a.Status,
+		CreatedAt:               a.CreatedAt.Format(time.RFC3339Nano),
+		UpdatedAt:               a.UpdatedAt.Format(time.RFC3339Nano),
+		ChallengeThresholdMinor: a.ChallengeThresholdMinor,
+		Confirmations:           a.Confirmations,
+	}
+}
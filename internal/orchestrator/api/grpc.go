@@ -11,6 +11,10 @@ import (
 
 func Register(gs *grpc.Server, svc *app.Service, log *logging.Logger) {
 	orchestratorv1.RegisterOrchestratorServiceServer(gs, NewHandler(svc, log))
+	orchestratorv1.RegisterAssetAdminServiceServer(gs, NewAssetAdminHandler(svc, log))
+	orchestratorv1.RegisterAddressBookServiceServer(gs, NewAddressBookHandler(svc, log))
+	orchestratorv1.RegisterReviewServiceServer(gs, NewReviewHandler(svc, log))
+	orchestratorv1.RegisterApprovalServiceServer(gs, NewApprovalHandler(svc, log))
 
 	gs.RegisterService(&grpc.ServiceDesc{
 		ServiceName: "cbsaga.orchestrator.v1.DevTools",
@@ -3,16 +3,22 @@ package api
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	orchestratorv1 "github.com/cicconee/cbsaga/gen/orchestrator/v1"
 	"github.com/cicconee/cbsaga/internal/orchestrator/app"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
 	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
 	"github.com/jackc/pgx/v5"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+const MetadataIdempotencyKey = "idempotency-key"
+
 type Handler struct {
 	orchestratorv1.UnimplementedOrchestratorServiceServer
 	svc *app.Service
@@ -27,44 +33,87 @@ func (h *Handler) CreateWithdrawal(
 	ctx context.Context,
 	req *orchestratorv1.CreateWithdrawalRequest,
 ) (*orchestratorv1.CreateWithdrawalResponse, error) {
-	h.log.Info("CreateWithdrawal called",
+	idemKey := req.GetIdempotencyKey()
+	if mdKey := idempotencyKeyFromMetadata(ctx); mdKey != "" {
+		// Either place is fine, but two different keys leave no way to tell which one the
+		// client retries with.
+		if idemKey != "" && strings.TrimSpace(idemKey) != strings.TrimSpace(mdKey) {
+			return nil, status.Error(
+				codes.InvalidArgument,
+				"idempotency_key in the body does not match the Idempotency-Key header",
+			)
+		}
+		idemKey = mdKey
+	}
+
+	h.log.InfoContext(ctx, "CreateWithdrawal called",
 		"user_id", req.GetUserId(),
 		"asset", req.GetAsset(),
 		"amount_miner", req.GetAmountMinor(),
-		"idem", req.GetIdempotencyKey(),
+		"idem", idemKey,
 	)
 
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
 	res, err := h.svc.CreateWithdrawal(ctx, app.CreateWithdrawalParams{
 		UserID:          req.GetUserId(),
 		Asset:           req.GetAsset(),
 		AmountMinor:     req.GetAmountMinor(),
 		DestinationAddr: req.GetDestinationAddr(),
-		IdempotencyKey:  req.GetIdempotencyKey(),
-		TraceID:         "local-trace-id",
+		IdempotencyKey:  idemKey,
+		TraceID:         tracing.TraceID(ctx),
+		Principal:       principal,
 	})
 	if err != nil {
+		var prevErr app.PreviousAttemptFailedError
 		switch {
+		case errors.Is(err, app.ErrPrincipalMismatch):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+
+		case errors.Is(err, app.ErrInvalidInput),
+			errors.Is(err, app.ErrUnknownAsset),
+			errors.Is(err, app.ErrAmountOutOfRange),
+			errors.Is(err, app.ErrInvalidDestination):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+
+		case errors.Is(err, app.ErrAssetNotEnabled),
+			errors.Is(err, app.ErrDestinationNotAllowlisted),
+			errors.Is(err, app.ErrDestinationCoolingOff):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+
 		case errors.Is(err, app.ErrInvalidIdempotencyKeyReuse):
-			h.log.Error("CreateWithdrawal failed: idempotency key reuse", "err", err)
+			h.log.ErrorContext(ctx, "CreateWithdrawal failed: idempotency key reuse", "err", err)
 			return nil, status.Error(
 				codes.FailedPrecondition,
 				"idempotency_key already used for a different request",
 			)
 
+		case errors.Is(err, app.ErrVelocityLimitExceeded):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+
+		case errors.As(err, &prevErr) && codes.Code(prevErr.GRPCCode) == codes.FailedPrecondition:
+			return nil, status.Error(
+				codes.FailedPrecondition,
+				"idempotency_key was used for a rejected request; use a new key",
+			)
+
 		case errors.Is(err, app.ErrIdempotencyInProgress):
-			h.log.Info("CreateWithdrawal in progress replay", "withdrawal_id", res.WithdrawalID)
+			h.log.InfoContext(ctx, "CreateWithdrawal in progress replay", "withdrawal_id", res.WithdrawalID)
 			return nil, status.Error(
 				codes.Aborted,
 				"request in progress; retry later; withdrawal_id="+res.WithdrawalID,
 			)
 
 		default:
-			h.log.Error("CreateWithdrawal failed", "err", err)
+			h.log.ErrorContext(ctx, "CreateWithdrawal failed", "err", err)
 			return nil, status.Error(codes.Internal, "internal error")
 		}
 	}
 
-	h.log.Info("CreateWithdrawal success",
+	h.log.InfoContext(ctx, "CreateWithdrawal success",
 		"withdrawal_id", res.WithdrawalID,
 		"status", res.Status,
 	)
@@ -79,16 +128,25 @@ func (h *Handler) GetWithdrawal(
 	ctx context.Context,
 	req *orchestratorv1.GetWithdrawalRequest,
 ) (*orchestratorv1.GetWithdrawalResponse, error) {
-	h.log.Info("GetWithdrawal called", "withdrawal_id", req.GetWithdrawalId())
+	h.log.InfoContext(ctx, "GetWithdrawal called", "withdrawal_id", req.GetWithdrawalId())
+
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
 
 	res, err := h.svc.GetWithdrawal(ctx, app.GetWithdrawalParams{
 		WithdrawalID: req.GetWithdrawalId(),
+		Principal:    principal,
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, "withdrawal not found")
 		}
-		h.log.Error("GetWithdrawal failed", "err", err)
+		if errors.Is(err, app.ErrWithdrawalAccessDenied) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		h.log.ErrorContext(ctx, "GetWithdrawal failed", "err", err)
 		return nil, status.Error(codes.Internal, "internal error")
 	}
 
@@ -105,6 +163,76 @@ func (h *Handler) GetWithdrawal(
 	if res.FailureReason != nil {
 		resp.FailureReason = *res.FailureReason
 	}
+	if res.TxHash != nil {
+		resp.TxHash = *res.TxHash
+	}
+	if res.FromAddr != nil {
+		resp.FromAddr = *res.FromAddr
+	}
+	if res.BroadcastAt != nil {
+		resp.BroadcastAt = res.BroadcastAt.Format(time.RFC3339Nano)
+	}
+	if res.BlockHeight != nil {
+		resp.BlockHeight = *res.BlockHeight
+	}
+	if res.BlockHash != nil {
+		resp.BlockHash = *res.BlockHash
+	}
+	if res.Confirmations != nil {
+		resp.Confirmations = *res.Confirmations
+	}
+	if res.CompletedAt != nil {
+		resp.CompletedAt = res.CompletedAt.Format(time.RFC3339Nano)
+	}
 
 	return resp, nil
 }
+
+func (h *Handler) ConfirmWithdrawal(
+	ctx context.Context,
+	req *orchestratorv1.ConfirmWithdrawalRequest,
+) (*orchestratorv1.ConfirmWithdrawalResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	st, err := h.svc.ConfirmWithdrawal(ctx, app.ConfirmWithdrawalParams{
+		WithdrawalID: req.GetWithdrawalId(),
+		Code:         req.GetCode(),
+		TraceID:      tracing.TraceID(ctx),
+		Principal:    principal,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, app.ErrInvalidInput),
+			errors.Is(err, app.ErrChallengeCodeInvalid):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, app.ErrChallengeNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, app.ErrChallengeClosed),
+			errors.Is(err, app.ErrChallengeExpired):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, app.ErrChallengeAttemptsExhausted):
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		default:
+			h.log.ErrorContext(ctx, "ConfirmWithdrawal failed", "err", err)
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+	}
+
+	return &orchestratorv1.ConfirmWithdrawalResponse{
+		WithdrawalId: req.GetWithdrawalId(),
+		Status:       st,
+	}, nil
+}
+
+// idempotencyKeyFromMetadata reads the key sent as metadata rather than in the request body,
+// which is how the HTTP gateway forwards the Idempotency-Key header.
+func idempotencyKeyFromMetadata(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(MetadataIdempotencyKey); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
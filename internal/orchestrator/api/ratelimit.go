@@ -0,0 +1,44 @@
+package api
+
+import (
+	orchestratorv1 "github.com/cicconee/cbsaga/gen/orchestrator/v1"
+	"github.com/cicconee/cbsaga/internal/platform/ratelimit"
+)
+
+// writeMethods are the RPCs besides CreateWithdrawal that change state. They are called far less
+// often, so a per-user bucket is enough: it keeps one caller, e.g. a script guessing challenge
+// codes or replaying votes, from hammering the database.
+var writeMethods = []string{
+	orchestratorv1.OrchestratorService_ConfirmWithdrawal_FullMethodName,
+	orchestratorv1.AddressBookService_AddWithdrawalAddress_FullMethodName,
+	orchestratorv1.AddressBookService_RemoveWithdrawalAddress_FullMethodName,
+	orchestratorv1.AssetAdminService_UpsertAsset_FullMethodName,
+	orchestratorv1.AssetAdminService_SetAssetStatus_FullMethodName,
+	orchestratorv1.ReviewService_ClaimReview_FullMethodName,
+	orchestratorv1.ReviewService_DecideReview_FullMethodName,
+	orchestratorv1.ApprovalService_UpsertApprovalPolicy_FullMethodName,
+	orchestratorv1.ApprovalService_DeleteApprovalPolicy_FullMethodName,
+	orchestratorv1.ApprovalService_ApproveWithdrawal_FullMethodName,
+	orchestratorv1.ApprovalService_RejectWithdrawal_FullMethodName,
+}
+
+// RateLimitPolicies limits the RPCs that write. CreateWithdrawal gets a per-user and a shared
+// bucket, since each accepted call with a fresh idempotency key creates a withdrawal, a saga and
+// outbox rows; every other write gets a per-user bucket of writeUser.
+func RateLimitPolicies(
+	perUser, perMethod ratelimit.Limit,
+	methodShards int,
+	writeUser ratelimit.Limit,
+) map[string]ratelimit.Policy {
+	policies := map[string]ratelimit.Policy{
+		orchestratorv1.OrchestratorService_CreateWithdrawal_FullMethodName: {
+			PerUser:      perUser,
+			PerMethod:    perMethod,
+			MethodShards: methodShards,
+		},
+	}
+	for _, m := range writeMethods {
+		policies[m] = ratelimit.Policy{PerUser: writeUser}
+	}
+	return policies
+}
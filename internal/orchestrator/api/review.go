@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	orchestratorv1 "github.com/cicconee/cbsaga/gen/orchestrator/v1"
+	"github.com/cicconee/cbsaga/internal/orchestrator/app"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ReviewHandler struct {
+	orchestratorv1.UnimplementedReviewServiceServer
+	svc *app.Service
+	log *logging.Logger
+}
+
+func NewReviewHandler(svc *app.Service, log *logging.Logger) *ReviewHandler {
+	return &ReviewHandler{svc: svc, log: log}
+}
+
+func (h *ReviewHandler) ListReviews(
+	ctx context.Context,
+	req *orchestratorv1.ListReviewsRequest,
+) (*orchestratorv1.ListReviewsResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	reviews, next, err := h.svc.ListReviews(ctx, app.ListReviewsParams{
+		Status:    req.GetStatus(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+		Principal: principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "ListReviews", err)
+	}
+
+	resp := &orchestratorv1.ListReviewsResponse{NextPageToken: next}
+	for _, r := range reviews {
+		resp.Reviews = append(resp.Reviews, toReviewPB(r))
+	}
+	return resp, nil
+}
+
+func (h *ReviewHandler) ClaimReview(
+	ctx context.Context,
+	req *orchestratorv1.ClaimReviewRequest,
+) (*orchestratorv1.ClaimReviewResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	r, err := h.svc.ClaimReview(ctx, app.ClaimReviewParams{
+		WithdrawalID: req.GetWithdrawalId(),
+		Principal:    principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "ClaimReview", err)
+	}
+
+	return &orchestratorv1.ClaimReviewResponse{Review: toReviewPB(r)}, nil
+}
+
+func (h *ReviewHandler) DecideReview(
+	ctx context.Context,
+	req *orchestratorv1.DecideReviewRequest,
+) (*orchestratorv1.DecideReviewResponse, error) {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+
+	r, err := h.svc.DecideReview(ctx, app.DecideReviewParams{
+		WithdrawalID: req.GetWithdrawalId(),
+		Decision:     req.GetDecision(),
+		Notes:        req.GetNotes(),
+		TraceID:      tracing.TraceID(ctx),
+		Principal:    principal,
+	})
+	if err != nil {
+		return nil, h.toStatus(ctx, "DecideReview", err)
+	}
+
+	return &orchestratorv1.DecideReviewResponse{Review: toReviewPB(r)}, nil
+}
+
+func (h *ReviewHandler) toStatus(ctx context.Context, method string, err error) error {
+	switch {
+	case errors.Is(err, app.ErrReviewerRequired),
+		errors.Is(err, app.ErrSelfReview):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, app.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, app.ErrReviewNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, app.ErrReviewClaimed),
+		errors.Is(err, app.ErrReviewNotClaimed),
+		errors.Is(err, app.ErrReviewClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		h.log.ErrorContext(ctx, method+" failed", "err", err)
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func toReviewPB(r app.ManualReview) *orchestratorv1.Review {
+	pb := &orchestratorv1.Review{
+		WithdrawalId:    r.WithdrawalID,
+		UserId:          r.UserID,
+		Asset:           r.Asset,
+		AmountMinor:     r.AmountMinor,
+		DestinationAddr: r.DestinationAddr,
+		Status:          r.Status,
+		RiskScore:       int32(r.RiskScore),
+		TriggeredRules:  r.TriggeredRules,
+		RulesVersion:    r.RulesVersion,
+		DueAt:           r.DueAt.Format(time.RFC3339Nano),
+		CreatedAt:       r.CreatedAt.Format(time.RFC3339Nano),
+	}
+	if r.RiskReason != nil {
+		pb.RiskReason = *r.RiskReason
+	}
+	if r.ClaimedBy != nil {
+		pb.ClaimedBy = *r.ClaimedBy
+	}
+	if r.ClaimedAt != nil {
+		pb.ClaimedAt = r.ClaimedAt.Format(time.RFC3339Nano)
+	}
+	if r.DecidedBy != nil {
+		pb.DecidedBy = *r.DecidedBy
+	}
+	if r.DecidedAt != nil {
+		pb.DecidedAt = r.DecidedAt.Format(time.RFC3339Nano)
+	}
+	if r.Notes != nil {
+		pb.Notes = *r.Notes
+	}
+	return pb
+}
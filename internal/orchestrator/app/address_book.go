@@ -0,0 +1,198 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type WithdrawalAddress = repo.WithdrawalAddress
+
+// checkAllowlist requires dest to be a registered address of the user that has finished its
+// cooling-off period. In audit mode violations are only logged.
+func (s *Service) checkAllowlist(
+	ctx context.Context,
+	userID string,
+	network string,
+	dest string,
+	now time.Time,
+) error {
+	if s.cfg.AllowlistMode == "" || s.cfg.AllowlistMode == AllowlistOff {
+		return nil
+	}
+
+	var violation error
+	entry, err := s.repo.GetActiveWithdrawalAddress(ctx, s.db, userID, network, dest)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		violation = ErrDestinationNotAllowlisted
+	case err != nil:
+		return err
+	case now.Before(entry.UsableAt):
+		violation = fmt.Errorf("%w: usable at %s",
+			ErrDestinationCoolingOff,
+			entry.UsableAt.UTC().Format(time.RFC3339),
+		)
+	default:
+		return nil
+	}
+
+	if s.cfg.AllowlistMode == AllowlistAudit {
+		s.log.WarnContext(ctx, "allowlist violation (audit mode)",
+			"user_id", userID,
+			"network", network,
+			"err", violation,
+		)
+		return nil
+	}
+	return violation
+}
+
+type AddWithdrawalAddressParams struct {
+	UserID    string
+	Asset     string
+	Address   string
+	Label     string
+	Principal auth.Principal
+}
+
+// AddWithdrawalAddress registers a destination for the user on the asset's network. The address
+// is stored canonicalized and becomes usable after the cooling-off period.
+func (s *Service) AddWithdrawalAddress(
+	ctx context.Context,
+	p AddWithdrawalAddressParams,
+) (WithdrawalAddress, error) {
+	userID := strings.TrimSpace(p.UserID)
+	if _, err := uuid.Parse(userID); err != nil {
+		return WithdrawalAddress{}, fmt.Errorf("%w: user_id must be a uuid", ErrInvalidInput)
+	}
+	// Support may read address books but not add to them: a new destination starts its
+	// cooling-off on the user's behalf.
+	if userID != p.Principal.Subject && !p.Principal.HasRole(auth.RoleAdmin) {
+		return WithdrawalAddress{}, ErrPrincipalMismatch
+	}
+
+	assetCode := strings.ToUpper(strings.TrimSpace(p.Asset))
+	if assetCode == "" || strings.TrimSpace(p.Address) == "" {
+		return WithdrawalAddress{}, fmt.Errorf("%w: asset and address are required", ErrInvalidInput)
+	}
+
+	asset, err := s.repo.GetAsset(ctx, s.db, assetCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return WithdrawalAddress{}, fmt.Errorf("%w: %s", ErrUnknownAsset, assetCode)
+		}
+		return WithdrawalAddress{}, err
+	}
+
+	addr, err := s.cfg.Addresses.Canonicalize(asset.Network, p.Address)
+	if err != nil {
+		return WithdrawalAddress{}, fmt.Errorf("%w: %v", ErrInvalidDestination, err)
+	}
+
+	entry, err := s.repo.AddWithdrawalAddress(ctx, s.db, repo.AddWithdrawalAddressParams{
+		ID:       uuid.NewString(),
+		UserID:   userID,
+		Network:  asset.Network,
+		Address:  addr,
+		Label:    strings.TrimSpace(p.Label),
+		UsableAt: time.Now().UTC().Add(s.cfg.CoolingOff),
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrWithdrawalAddressExists) {
+			return WithdrawalAddress{}, ErrWithdrawalAddressExists
+		}
+		return WithdrawalAddress{}, err
+	}
+
+	s.log.InfoContext(ctx, "audit: withdrawal address added",
+		"principal", p.Principal.Subject,
+		"user_id", userID,
+		"address_id", entry.ID,
+		"network", entry.Network,
+		"usable_at", entry.UsableAt,
+	)
+
+	return entry, nil
+}
+
+type ListWithdrawalAddressesParams struct {
+	UserID    string
+	Principal auth.Principal
+}
+
+func (s *Service) ListWithdrawalAddresses(
+	ctx context.Context,
+	p ListWithdrawalAddressesParams,
+) ([]WithdrawalAddress, error) {
+	userID := strings.TrimSpace(p.UserID)
+	if err := s.authorizeAddressBook(ctx, userID, p.Principal); err != nil {
+		return nil, err
+	}
+	return s.repo.ListWithdrawalAddresses(ctx, s.db, userID)
+}
+
+type RemoveWithdrawalAddressParams struct {
+	UserID    string
+	AddressID string
+	Principal auth.Principal
+}
+
+func (s *Service) RemoveWithdrawalAddress(
+	ctx context.Context,
+	p RemoveWithdrawalAddressParams,
+) error {
+	userID := strings.TrimSpace(p.UserID)
+	if err := s.authorizeAddressBook(ctx, userID, p.Principal); err != nil {
+		return err
+	}
+	if _, err := uuid.Parse(p.AddressID); err != nil {
+		return fmt.Errorf("%w: address_id must be a uuid", ErrInvalidInput)
+	}
+
+	err := s.repo.RemoveWithdrawalAddress(ctx, s.db, userID, p.AddressID, time.Now().UTC())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrWithdrawalAddressNotFound
+		}
+		return err
+	}
+
+	s.log.InfoContext(ctx, "audit: withdrawal address removed",
+		"principal", p.Principal.Subject,
+		"user_id", userID,
+		"address_id", p.AddressID,
+	)
+	return nil
+}
+
+// authorizeAddressBook lets users manage their own address book, and admin/support act on any.
+func (s *Service) authorizeAddressBook(
+	ctx context.Context,
+	userID string,
+	principal auth.Principal,
+) error {
+	if _, err := uuid.Parse(userID); err != nil {
+		return fmt.Errorf("%w: user_id must be a uuid", ErrInvalidInput)
+	}
+	if userID == principal.Subject {
+		return nil
+	}
+	if !principal.IsPrivileged() {
+		return ErrPrincipalMismatch
+	}
+
+	s.log.InfoContext(ctx, "audit: address book accessed by privileged principal",
+		"principal", principal.Subject,
+		"roles", principal.Roles,
+		"user_id", userID,
+	)
+	return nil
+}
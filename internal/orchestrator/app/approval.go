@@ -0,0 +1,428 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ApprovalDecisionApprove = "APPROVE"
+	ApprovalDecisionReject  = "REJECT"
+)
+
+const (
+	defaultApprovalPageSize = 50
+	maxApprovalPageSize     = 200
+	maxRequiredApprovals    = 10
+)
+
+type ApprovalPolicy = repo.ApprovalPolicy
+
+type ApprovalVote = repo.ApprovalVote
+
+// Approval is an approval request together with the votes cast on it so far.
+type Approval struct {
+	repo.ApprovalRequest
+	Votes []ApprovalVote
+}
+
+type UpsertApprovalPolicyParams struct {
+	Asset string
+	// MinAmountMinor is the smallest amount the policy applies to. The policy with the highest
+	// threshold a withdrawal reaches wins.
+	MinAmountMinor    int64
+	RequiredApprovals int
+	ApproverRole      string
+	Principal         auth.Principal
+}
+
+func (s *Service) UpsertApprovalPolicy(
+	ctx context.Context,
+	p UpsertApprovalPolicyParams,
+) (ApprovalPolicy, error) {
+	if !p.Principal.HasRole(auth.RoleAdmin) {
+		return ApprovalPolicy{}, ErrAdminRequired
+	}
+
+	asset := strings.ToUpper(strings.TrimSpace(p.Asset))
+	role := strings.TrimSpace(p.ApproverRole)
+	switch {
+	case asset == "" || role == "":
+		return ApprovalPolicy{}, fmt.Errorf("%w: asset and approver_role are required",
+			ErrInvalidInput,
+		)
+	case p.MinAmountMinor <= 0:
+		return ApprovalPolicy{}, fmt.Errorf("%w: min_amount_minor must be positive",
+			ErrInvalidInput,
+		)
+	case p.RequiredApprovals < 1 || p.RequiredApprovals > maxRequiredApprovals:
+		return ApprovalPolicy{}, fmt.Errorf("%w: required_approvals must be between 1 and %d",
+			ErrInvalidInput,
+			maxRequiredApprovals,
+		)
+	}
+
+	if _, err := s.repo.GetAsset(ctx, s.db, asset); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ApprovalPolicy{}, fmt.Errorf("%w: %s", ErrUnknownAsset, asset)
+		}
+		return ApprovalPolicy{}, err
+	}
+
+	policy, err := s.repo.UpsertApprovalPolicy(ctx, s.db, repo.UpsertApprovalPolicyParams{
+		Asset:             asset,
+		MinAmountMinor:    p.MinAmountMinor,
+		RequiredApprovals: p.RequiredApprovals,
+		ApproverRole:      role,
+		UpdatedBy:         p.Principal.Subject,
+	})
+	if err != nil {
+		return ApprovalPolicy{}, err
+	}
+
+	s.log.InfoContext(ctx, "audit: approval policy upserted",
+		"principal", p.Principal.Subject,
+		"policy_id", policy.PolicyID,
+		"asset", policy.Asset,
+		"min_amount_minor", policy.MinAmountMinor,
+		"required_approvals", policy.RequiredApprovals,
+		"approver_role", policy.ApproverRole,
+	)
+	return policy, nil
+}
+
+func (s *Service) ListApprovalPolicies(
+	ctx context.Context,
+	principal auth.Principal,
+) ([]ApprovalPolicy, error) {
+	if !principal.HasRole(auth.RoleAdmin) {
+		return nil, ErrAdminRequired
+	}
+	return s.repo.ListApprovalPolicies(ctx, s.db)
+}
+
+// DeleteApprovalPolicy removes a policy. Withdrawals already waiting on it keep the
+// requirements they were opened with.
+func (s *Service) DeleteApprovalPolicy(
+	ctx context.Context,
+	policyID string,
+	principal auth.Principal,
+) (ApprovalPolicy, error) {
+	if !principal.HasRole(auth.RoleAdmin) {
+		return ApprovalPolicy{}, ErrAdminRequired
+	}
+	if _, err := uuid.Parse(policyID); err != nil {
+		return ApprovalPolicy{}, fmt.Errorf("%w: policy_id must be a uuid", ErrInvalidInput)
+	}
+
+	policy, err := s.repo.DeleteApprovalPolicy(ctx, s.db, policyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ApprovalPolicy{}, ErrApprovalPolicyNotFound
+	}
+	if err != nil {
+		return ApprovalPolicy{}, err
+	}
+
+	s.log.InfoContext(ctx, "audit: approval policy deleted",
+		"principal", principal.Subject,
+		"policy_id", policy.PolicyID,
+		"asset", policy.Asset,
+		"min_amount_minor", policy.MinAmountMinor,
+	)
+	return policy, nil
+}
+
+type ListApprovalsParams struct {
+	PageSize  int
+	Principal auth.Principal
+}
+
+// ListPendingApprovals returns the oldest open requests the caller may vote on; admin sees all.
+func (s *Service) ListPendingApprovals(
+	ctx context.Context,
+	p ListApprovalsParams,
+) ([]Approval, error) {
+	var roles []string
+	if !p.Principal.HasRole(auth.RoleAdmin) {
+		roles = p.Principal.Roles
+		if len(roles) == 0 {
+			return nil, nil
+		}
+	}
+
+	size := p.PageSize
+	if size <= 0 {
+		size = defaultApprovalPageSize
+	}
+	size = min(size, maxApprovalPageSize)
+
+	reqs, err := s.repo.ListPendingApprovalRequests(ctx, s.db, roles, size)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Approval, 0, len(reqs))
+	for _, a := range reqs {
+		votes, err := s.repo.ListApprovalVotes(ctx, s.db, a.WithdrawalID)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Approval{ApprovalRequest: a, Votes: votes})
+	}
+	return out, nil
+}
+
+// GetApproval returns a withdrawal's approval request and its audit trail of votes. Only admin
+// and holders of the request's approver role may read it.
+func (s *Service) GetApproval(
+	ctx context.Context,
+	withdrawalID string,
+	principal auth.Principal,
+) (Approval, error) {
+	if _, err := uuid.Parse(withdrawalID); err != nil {
+		return Approval{}, fmt.Errorf("%w: withdrawal_id must be a uuid", ErrInvalidInput)
+	}
+
+	a, err := s.repo.GetApprovalRequest(ctx, s.db, withdrawalID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Approval{}, ErrApprovalNotFound
+	}
+	if err != nil {
+		return Approval{}, err
+	}
+	if !principal.HasRole(auth.RoleAdmin, a.ApproverRole) {
+		return Approval{}, fmt.Errorf("%w: %s", ErrApproverRequired, a.ApproverRole)
+	}
+
+	votes, err := s.repo.ListApprovalVotes(ctx, s.db, withdrawalID)
+	if err != nil {
+		return Approval{}, err
+	}
+	return Approval{ApprovalRequest: a, Votes: votes}, nil
+}
+
+type DecideApprovalParams struct {
+	WithdrawalID string
+	// Notes are optional on approval and required on rejection.
+	Notes     string
+	TraceID   string
+	Principal auth.Principal
+}
+
+// ApproveWithdrawal records the caller's approval. The vote that reaches the policy's required
+// count resumes the saga. Approving again is a no-op.
+func (s *Service) ApproveWithdrawal(ctx context.Context, p DecideApprovalParams) (Approval, error) {
+	return s.decideApproval(ctx, p, ApprovalDecisionApprove)
+}
+
+// RejectWithdrawal records the caller's rejection, which fails the withdrawal at once.
+// Rejecting again is a no-op.
+func (s *Service) RejectWithdrawal(ctx context.Context, p DecideApprovalParams) (Approval, error) {
+	return s.decideApproval(ctx, p, ApprovalDecisionReject)
+}
+
+func (s *Service) decideApproval(
+	ctx context.Context,
+	p DecideApprovalParams,
+	decision string,
+) (Approval, error) {
+	if _, err := uuid.Parse(p.WithdrawalID); err != nil {
+		return Approval{}, fmt.Errorf("%w: withdrawal_id must be a uuid", ErrInvalidInput)
+	}
+
+	notes := strings.TrimSpace(p.Notes)
+	if decision == ApprovalDecisionReject && notes == "" {
+		return Approval{}, fmt.Errorf("%w: notes are required to reject", ErrInvalidInput)
+	}
+	if len(notes) > maxReviewNotesLen {
+		return Approval{}, fmt.Errorf("%w: notes exceed %d bytes",
+			ErrInvalidInput,
+			maxReviewNotesLen,
+		)
+	}
+
+	traceID := p.TraceID
+	if traceID == "" {
+		traceID = uuid.NewString()
+	}
+	approver := p.Principal.Subject
+	now := time.Now().UTC()
+
+	var out Approval
+	var voted, stale bool
+	var resolved string
+	err := postgres.WithTx(ctx, s.db, pgx.TxOptions{}, "approval_decide",
+		func(ctx context.Context, tx pgx.Tx) error {
+			a, err := s.repo.LockApprovalRequestTx(ctx, tx, p.WithdrawalID)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrApprovalNotFound
+			}
+			if err != nil {
+				return err
+			}
+			if !p.Principal.HasRole(a.ApproverRole) {
+				return fmt.Errorf("%w: %s", ErrApproverRequired, a.ApproverRole)
+			}
+			if a.UserID == approver || deref(a.RequestedBy) == approver {
+				return ErrSelfApproval
+			}
+
+			votes, err := s.repo.ListApprovalVotes(ctx, tx, a.WithdrawalID)
+			if err != nil {
+				return err
+			}
+			approvals := 0
+			for _, v := range votes {
+				if v.Approver == approver {
+					if v.Decision != decision {
+						return fmt.Errorf("%w: already voted %s",
+							ErrApprovalVoteConflict,
+							v.Decision,
+						)
+					}
+					out = Approval{ApprovalRequest: a, Votes: votes}
+					return nil
+				}
+				if v.Decision == ApprovalDecisionApprove {
+					approvals++
+				}
+			}
+			if a.Status != orchestrator.ApprovalStatusPending {
+				return fmt.Errorf("%w: approval is %s", ErrApprovalClosed, a.Status)
+			}
+
+			vote := repo.ApprovalVote{
+				WithdrawalID: a.WithdrawalID,
+				Approver:     approver,
+				Decision:     decision,
+				Roles:        p.Principal.Roles,
+				CreatedAt:    now,
+			}
+			if notes != "" {
+				vote.Notes = &notes
+			}
+			if err := s.repo.InsertApprovalVoteTx(ctx, tx, vote); err != nil {
+				return err
+			}
+			voted = true
+
+			switch {
+			case decision == ApprovalDecisionReject:
+				resolved = orchestrator.ApprovalStatusRejected
+			case approvals+1 >= a.RequiredApprovals:
+				resolved = orchestrator.ApprovalStatusApproved
+			}
+			if resolved != "" {
+				resolve := repo.ResolveApprovalParams{
+					WithdrawalID: a.WithdrawalID,
+					Status:       resolved,
+					At:           now,
+					TraceID:      traceID,
+					Traceparent:  tracing.Traceparent(ctx),
+				}
+				if resolved == orchestrator.ApprovalStatusRejected {
+					resolve.Reason = fmt.Sprintf("approval rejected by %s: %s", approver, notes)
+				}
+				resolve.Outbox, err = approvalOutbox(a, resolve)
+				if err != nil {
+					return err
+				}
+				outcome, err := s.repo.ResolveApprovalTx(ctx, tx, resolve)
+				if err != nil {
+					return err
+				}
+				stale = outcome.Stale
+			}
+
+			a, err = s.repo.GetApprovalRequest(ctx, tx, a.WithdrawalID)
+			if err != nil {
+				return err
+			}
+			votes, err = s.repo.ListApprovalVotes(ctx, tx, a.WithdrawalID)
+			if err != nil {
+				return err
+			}
+			out = Approval{ApprovalRequest: a, Votes: votes}
+			return nil
+		})
+	if err != nil {
+		return Approval{}, err
+	}
+
+	if voted {
+		approvalDecisionsTotal.WithLabelValues(decision).Inc()
+		s.log.InfoContext(ctx, "audit: withdrawal approval vote",
+			"principal", approver,
+			"withdrawal_id", p.WithdrawalID,
+			"decision", decision,
+			"approvals", countApprovals(out.Votes),
+			"required_approvals", out.RequiredApprovals,
+		)
+	}
+	if stale {
+		s.log.WarnContext(ctx, "withdrawal approval closed as stale",
+			"principal", approver,
+			"withdrawal_id", p.WithdrawalID,
+		)
+		return Approval{}, fmt.Errorf("%w: approval is %s", ErrApprovalClosed, out.Status)
+	}
+	if resolved != "" {
+		s.log.InfoContext(ctx, "audit: withdrawal approval resolved",
+			"principal", approver,
+			"withdrawal_id", p.WithdrawalID,
+			"status", resolved,
+		)
+	}
+	return out, nil
+}
+
+func countApprovals(votes []ApprovalVote) int {
+	n := 0
+	for _, v := range votes {
+		if v.Decision == ApprovalDecisionApprove {
+			n++
+		}
+	}
+	return n
+}
+
+// approvalOutbox builds the event that resumes the saga from APPROVAL.
+func approvalOutbox(
+	a repo.ApprovalRequest,
+	p repo.ResolveApprovalParams,
+) (repo.OutboxEvent, error) {
+	eventType := orchestrator.EventTypeWithdrawalApproved
+	var reason *string
+	if p.Status != orchestrator.ApprovalStatusApproved {
+		eventType = orchestrator.EventTypeWithdrawalFailed
+		reason = &p.Reason
+	}
+
+	payload, err := codec.EncodeValid(&orchestrator.WithdrawalEventPayload{
+		WithdrawalID: a.WithdrawalID,
+		UserID:       a.UserID,
+		Step:         orchestrator.SagaStepApproval,
+		Reason:       reason,
+	})
+	if err != nil {
+		return repo.OutboxEvent{}, err
+	}
+
+	return repo.OutboxEvent{
+		EventType: eventType,
+		Payload:   string(payload),
+		RouteKey:  orchestrator.RouteKeyWithdrawalEvt,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/jackc/pgx/v5"
+)
+
+type Asset = repo.Asset
+
+// lookupAsset returns the registry entry of a create request's asset.
+func (s *Service) lookupAsset(ctx context.Context, symbol string) (Asset, error) {
+	a, err := s.repo.GetAsset(ctx, s.db, symbol)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Asset{}, fmt.Errorf("%w: %s", ErrUnknownAsset, symbol)
+		}
+		return Asset{}, err
+	}
+	return a, nil
+}
+
+// checkAsset enforces the registry on a new create request. It runs before the idempotency key is
+// reserved, so a rejected request leaves nothing behind and can be retried as-is once fixed. A
+// retry of a request already seen skips it and gets its stored outcome.
+func checkAsset(a Asset, v validatedCreateWithdrawal) error {
+	if a.Status != orchestrator.AssetStatusEnabled {
+		return fmt.Errorf("%w: %s is %s", ErrAssetNotEnabled, a.Asset, a.Status)
+	}
+	if v.AmountMinor < a.MinAmountMinor || v.AmountMinor > a.MaxAmountMinor {
+		return fmt.Errorf("%w: %s amount_minor must be between %d and %d",
+			ErrAmountOutOfRange,
+			a.Asset,
+			a.MinAmountMinor,
+			a.MaxAmountMinor,
+		)
+	}
+	return nil
+}
+
+type UpsertAssetParams struct {
+	Asset          string
+	Network        string
+	Decimals       int32
+	MinAmountMinor int64
+	MaxAmountMinor int64
+	// ChallengeThresholdMinor is the smallest amount that needs a one-time code; 0 never does.
+	ChallengeThresholdMinor int64
+	// Confirmations is the depth at which a withdrawal's transaction is final; 0 means 1.
+	Confirmations int64
+	Principal     auth.Principal
+}
+
+// UpsertAsset defines or redefines an asset. New assets start paused so they can be reviewed
+// before withdrawals are accepted. An existing asset keeps its network.
+func (s *Service) UpsertAsset(ctx context.Context, p UpsertAssetParams) (Asset, error) {
+	if !p.Principal.HasRole(auth.RoleAdmin) {
+		return Asset{}, ErrAdminRequired
+	}
+
+	asset := strings.ToUpper(strings.TrimSpace(p.Asset))
+	network := strings.ToLower(strings.TrimSpace(p.Network))
+	switch {
+	case asset == "" || network == "":
+		return Asset{}, fmt.Errorf("%w: asset and network are required", ErrInvalidInput)
+	case p.Decimals < 0 || p.Decimals > 18:
+		return Asset{}, fmt.Errorf("%w: decimals must be between 0 and 18", ErrInvalidInput)
+	case p.MinAmountMinor <= 0 || p.MaxAmountMinor < p.MinAmountMinor:
+		return Asset{}, fmt.Errorf(
+			"%w: require 0 < min_amount_minor <= max_amount_minor",
+			ErrInvalidInput,
+		)
+	case p.ChallengeThresholdMinor < 0:
+		return Asset{}, fmt.Errorf("%w: challenge_threshold_minor cannot be negative",
+			ErrInvalidInput,
+		)
+	case p.Confirmations < 0:
+		return Asset{}, fmt.Errorf("%w: confirmations cannot be negative", ErrInvalidInput)
+	}
+	confirmations := max(p.Confirmations, 1)
+
+	a, err := s.repo.UpsertAsset(ctx, s.db, repo.UpsertAssetParams{
+		Asset:                   asset,
+		Network:                 network,
+		Decimals:                p.Decimals,
+		MinAmountMinor:          p.MinAmountMinor,
+		MaxAmountMinor:          p.MaxAmountMinor,
+		ChallengeThresholdMinor: p.ChallengeThresholdMinor,
+		Confirmations:           confirmations,
+		Status:                  orchestrator.AssetStatusPaused,
+	})
+	if errors.Is(err, repo.ErrAssetNetworkChanged) {
+		return Asset{}, ErrAssetNetworkChanged
+	}
+	if err != nil {
+		return Asset{}, err
+	}
+
+	s.log.InfoContext(ctx, "audit: asset upserted",
+		"principal", p.Principal.Subject,
+		"asset", a.Asset,
+		"network", a.Network,
+		"decimals", a.Decimals,
+		"min_amount_minor", a.MinAmountMinor,
+		"max_amount_minor", a.MaxAmountMinor,
+		"challenge_threshold_minor", a.ChallengeThresholdMinor,
+		"confirmations", a.Confirmations,
+	)
+
+	return a, nil
+}
+
+type SetAssetStatusParams struct {
+	Asset     string
+	Status    string
+	Principal auth.Principal
+}
+
+func (s *Service) SetAssetStatus(ctx context.Context, p SetAssetStatusParams) (Asset, error) {
+	if !p.Principal.HasRole(auth.RoleAdmin) {
+		return Asset{}, ErrAdminRequired
+	}
+
+	status := strings.ToUpper(strings.TrimSpace(p.Status))
+	if status != orchestrator.AssetStatusEnabled && status != orchestrator.AssetStatusPaused {
+		return Asset{}, fmt.Errorf("%w: status must be ENABLED or PAUSED", ErrInvalidInput)
+	}
+
+	asset := strings.ToUpper(strings.TrimSpace(p.Asset))
+	a, err := s.repo.SetAssetStatus(ctx, s.db, asset, status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Asset{}, fmt.Errorf("%w: %s", ErrUnknownAsset, asset)
+		}
+		return Asset{}, err
+	}
+
+	s.log.InfoContext(ctx, "audit: asset status changed",
+		"principal", p.Principal.Subject,
+		"asset", a.Asset,
+		"status", a.Status,
+	)
+
+	return a, nil
+}
+
+func (s *Service) ListAssets(ctx context.Context, principal auth.Principal) ([]Asset, error) {
+	if !principal.HasRole(auth.RoleAdmin) {
+		return nil, ErrAdminRequired
+	}
+	return s.repo.ListAssets(ctx, s.db)
+}
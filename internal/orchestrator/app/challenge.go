@@ -0,0 +1,272 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/challenge"
+	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const challengeExpireBatch = 100
+
+type ConfirmWithdrawalParams struct {
+	WithdrawalID string
+	Code         string
+	TraceID      string
+	Principal    auth.Principal
+}
+
+// ConfirmWithdrawal checks the one-time code of a challenged withdrawal. The right code resumes
+// the saga; each wrong one counts against the challenge, and the last allowed wrong code fails
+// the withdrawal, as does confirming after the challenge expired. Confirming an already confirmed
+// challenge again succeeds.
+func (s *Service) ConfirmWithdrawal(
+	ctx context.Context,
+	p ConfirmWithdrawalParams,
+) (string, error) {
+	if _, err := uuid.Parse(p.WithdrawalID); err != nil {
+		return "", fmt.Errorf("%w: withdrawal_id must be a uuid", ErrInvalidInput)
+	}
+	code := strings.TrimSpace(p.Code)
+	if code == "" {
+		return "", fmt.Errorf("%w: code is required", ErrInvalidInput)
+	}
+
+	traceID := p.TraceID
+	if traceID == "" {
+		traceID = uuid.NewString()
+	}
+	now := time.Now().UTC()
+
+	// A wrong or late code must still be recorded, so those outcomes commit and are reported
+	// through rejected rather than by failing the transaction.
+	var status string
+	var rejected error
+	var applied bool
+	err := postgres.WithTx(ctx, s.db, pgx.TxOptions{}, "challenge_confirm",
+		func(ctx context.Context, tx pgx.Tx) error {
+			c, err := s.repo.LockChallengeTx(ctx, tx, p.WithdrawalID)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrChallengeNotFound
+			}
+			if err != nil {
+				return err
+			}
+			// Nobody may confirm a code on the owner's behalf, not even admin, and another user's
+			// withdrawal looks like one without a challenge so its existence is not revealed.
+			if c.UserID != p.Principal.Subject {
+				return ErrChallengeNotFound
+			}
+
+			status = c.Status
+			switch c.Status {
+			case orchestrator.ChallengeStatusPending:
+			case orchestrator.ChallengeStatusConfirmed:
+				return nil
+			default:
+				return fmt.Errorf("%w: challenge is %s", ErrChallengeClosed, c.Status)
+			}
+
+			resolve := repo.ResolveChallengeParams{
+				WithdrawalID: c.WithdrawalID,
+				At:           now,
+				TraceID:      traceID,
+				Traceparent:  tracing.Traceparent(ctx),
+			}
+			switch {
+			case !c.ExpiresAt.After(now):
+				resolve.Status = orchestrator.ChallengeStatusExpired
+				resolve.Reason = "auth challenge expired"
+				rejected = ErrChallengeExpired
+
+			case challenge.Matches(c.CodeHash, code):
+				resolve.Status = orchestrator.ChallengeStatusConfirmed
+
+			default:
+				attempts, err := s.repo.RecordChallengeAttemptTx(ctx, tx, c.WithdrawalID, now)
+				if err != nil {
+					return err
+				}
+				if attempts < c.MaxAttempts {
+					challengeResultsTotal.WithLabelValues("invalid_code").Inc()
+					rejected = fmt.Errorf("%w: %d attempts left",
+						ErrChallengeCodeInvalid,
+						c.MaxAttempts-attempts,
+					)
+					return nil
+				}
+				resolve.Status = orchestrator.ChallengeStatusFailed
+				resolve.Reason = "auth challenge failed: too many wrong codes"
+				rejected = ErrChallengeAttemptsExhausted
+			}
+
+			resolve.Outbox, err = challengeOutbox(c, resolve)
+			if err != nil {
+				return err
+			}
+			outcome, err := s.repo.ResolveChallengeTx(ctx, tx, resolve)
+			if err != nil {
+				return err
+			}
+			if outcome.Stale {
+				status = orchestrator.ChallengeStatusStale
+				rejected = fmt.Errorf("%w: challenge is %s", ErrChallengeClosed, status)
+				return nil
+			}
+			status, applied = resolve.Status, outcome.Applied
+			return nil
+		})
+	if err != nil {
+		return "", err
+	}
+
+	if status == orchestrator.ChallengeStatusStale {
+		challengeResultsTotal.WithLabelValues("stale").Inc()
+		s.log.WarnContext(ctx, "auth challenge closed as stale",
+			"withdrawal_id", p.WithdrawalID,
+		)
+	}
+	if applied {
+		challengeResultsTotal.WithLabelValues(strings.ToLower(status)).Inc()
+		s.log.InfoContext(ctx, "audit: auth challenge resolved",
+			"principal", p.Principal.Subject,
+			"withdrawal_id", p.WithdrawalID,
+			"status", status,
+		)
+	}
+	if rejected != nil {
+		return "", rejected
+	}
+	return status, nil
+}
+
+// challengeOutbox builds the event that resumes the saga from AUTH_CHALLENGE.
+func challengeOutbox(
+	c repo.AuthChallenge,
+	p repo.ResolveChallengeParams,
+) (repo.OutboxEvent, error) {
+	eventType := orchestrator.EventTypeWithdrawalApproved
+	var reason *string
+	if p.Status != orchestrator.ChallengeStatusConfirmed {
+		eventType = orchestrator.EventTypeWithdrawalFailed
+		reason = &p.Reason
+	}
+
+	payload, err := codec.EncodeValid(&orchestrator.WithdrawalEventPayload{
+		WithdrawalID: c.WithdrawalID,
+		UserID:       c.UserID,
+		Step:         orchestrator.SagaStepAuthChallenge,
+		Reason:       reason,
+	})
+	if err != nil {
+		return repo.OutboxEvent{}, err
+	}
+
+	return repo.OutboxEvent{
+		EventType: eventType,
+		Payload:   string(payload),
+		RouteKey:  orchestrator.RouteKeyWithdrawalEvt,
+	}, nil
+}
+
+// ExpireChallenges fails every open challenge past its expiry and returns how many it expired.
+// Challenges whose saga already left AUTH_CHALLENGE are closed as STALE instead.
+func (s *Service) ExpireChallenges(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "app.ExpireChallenges")
+	defer span.End()
+
+	traceID := tracing.TraceID(ctx)
+	if traceID == "" {
+		traceID = uuid.NewString()
+	}
+
+	total := 0
+	for {
+		n, stale, seen := 0, 0, 0
+		now := time.Now().UTC()
+		err := postgres.WithTx(ctx, s.db, pgx.TxOptions{}, "challenge_expire",
+			func(ctx context.Context, tx pgx.Tx) error {
+				due, err := s.repo.ListDueChallengesTx(ctx, tx, now, challengeExpireBatch)
+				if err != nil {
+					return err
+				}
+				seen = len(due)
+
+				for _, c := range due {
+					resolve := repo.ResolveChallengeParams{
+						WithdrawalID: c.WithdrawalID,
+						Status:       orchestrator.ChallengeStatusExpired,
+						Reason: fmt.Sprintf("auth challenge expired at %s",
+							c.ExpiresAt.UTC().Format(time.RFC3339),
+						),
+						At:          now,
+						TraceID:     traceID,
+						Traceparent: tracing.Traceparent(ctx),
+					}
+					resolve.Outbox, err = challengeOutbox(c, resolve)
+					if err != nil {
+						return err
+					}
+
+					outcome, err := s.repo.ResolveChallengeTx(ctx, tx, resolve)
+					if err != nil {
+						return err
+					}
+					switch {
+					case outcome.Applied:
+						n++
+					case outcome.Stale:
+						stale++
+						s.log.WarnContext(ctx, "auth challenge closed as stale",
+							"withdrawal_id", c.WithdrawalID,
+						)
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			tracing.RecordError(span, err)
+			return total, err
+		}
+
+		total += n
+		challengeResultsTotal.WithLabelValues("expired").Add(float64(n))
+		challengeResultsTotal.WithLabelValues("stale").Add(float64(stale))
+		if seen < challengeExpireBatch {
+			return total, nil
+		}
+	}
+}
+
+// RunChallengeSweeper expires overdue challenges every interval until ctx is done.
+func (s *Service) RunChallengeSweeper(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			n, err := s.ExpireChallenges(ctx)
+			if err != nil {
+				s.log.ErrorContext(ctx, "expire auth challenges failed", "err", err)
+				continue
+			}
+			if n > 0 {
+				s.log.InfoContext(ctx, "auth challenges expired", "count", n)
+			}
+		}
+	}
+}
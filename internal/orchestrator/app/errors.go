@@ -1,11 +1,88 @@
 package app
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
+	ErrInvalidInput = errors.New("invalid input")
+
 	ErrInvalidIdempotencyKeyReuse = errors.New("idempotency key reused with different request")
 
 	ErrIdempotencyInProgress = errors.New("idempotent request in progress")
 
 	ErrCreateWithdrawalFailed = errors.New("could not create withdrawal request")
+
+	ErrPrincipalMismatch = errors.New("user_id does not match the authenticated caller")
+
+	ErrWithdrawalAccessDenied = errors.New("caller may not access this withdrawal")
+
+	ErrAdminRequired = errors.New("admin role required")
+
+	ErrUnknownAsset = errors.New("unknown asset")
+
+	ErrAssetNotEnabled = errors.New("asset is not enabled for withdrawals")
+
+	ErrAssetNetworkChanged = errors.New("an existing asset's network cannot be changed")
+
+	ErrAmountOutOfRange = errors.New("amount_minor out of range for asset")
+
+	ErrInvalidDestination = errors.New("invalid destination_addr for asset network")
+
+	ErrDestinationNotAllowlisted = errors.New("destination_addr is not in the address book")
+
+	ErrDestinationCoolingOff = errors.New("destination_addr is still in its cooling-off period")
+
+	ErrWithdrawalAddressExists = errors.New("withdrawal address already registered")
+
+	ErrWithdrawalAddressNotFound = errors.New("withdrawal address not found")
+
+	ErrVelocityLimitExceeded = errors.New("withdrawal velocity limit exceeded")
+
+	ErrReviewerRequired = errors.New("admin or risk_operator role required")
+
+	ErrReviewNotFound = errors.New("manual review not found")
+
+	ErrReviewClaimed = errors.New("manual review is claimed by another operator")
+
+	ErrReviewNotClaimed = errors.New("manual review must be claimed by the caller first")
+
+	ErrReviewClosed = errors.New("manual review is already closed")
+
+	ErrSelfReview = errors.New("operators may not review their own withdrawals")
+
+	ErrChallengeNotFound = errors.New("withdrawal has no auth challenge")
+
+	ErrChallengeClosed = errors.New("auth challenge is already closed")
+
+	ErrChallengeCodeInvalid = errors.New("wrong confirmation code")
+
+	ErrChallengeExpired = errors.New("auth challenge expired; the withdrawal has failed")
+
+	ErrChallengeAttemptsExhausted = errors.New(
+		"too many wrong confirmation codes; the withdrawal has failed",
+	)
+
+	ErrApprovalPolicyNotFound = errors.New("approval policy not found")
+
+	ErrApprovalNotFound = errors.New("withdrawal has no approval request")
+
+	ErrApproverRequired = errors.New("caller lacks the approver role for this withdrawal")
+
+	ErrSelfApproval = errors.New("approvers may not approve their own withdrawals")
+
+	ErrApprovalClosed = errors.New("approval request is already closed")
+
+	ErrApprovalVoteConflict = errors.New("approver already voted differently")
 )
+
+// PreviousAttemptFailedError is returned when replaying an idempotency key whose first attempt
+// was finalized as FAILED. GRPCCode is the code recorded for that attempt.
+type PreviousAttemptFailedError struct {
+	GRPCCode int
+}
+
+func (e PreviousAttemptFailedError) Error() string {
+	return fmt.Sprintf("previous attempt failed (grpc_code=%d)", e.GRPCCode)
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type createPath int
+
+const (
+	createPathCreated createPath = iota
+	createPathReconciled
+	createPathCommitUnknown
+)
+
+var (
+	createWithdrawalTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "withdrawal",
+		Name:      "create_total",
+		Help:      "CreateWithdrawal calls by outcome.",
+	}, []string{"outcome"})
+
+	idemLeaseStealsTotal = metrics.Factory.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "idempotency",
+		Name:      "lease_steals_total",
+		Help:      "Expired idempotency leases taken over by a new attempt.",
+	})
+
+	reviewDecisionsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "review",
+		Name:      "decisions_total",
+		Help:      "Manual reviews closed by outcome (APPROVED, REJECTED, EXPIRED).",
+	}, []string{"decision"})
+
+	challengeResultsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "auth_challenge",
+		Name:      "results_total",
+		Help:      "Auth challenge outcomes (confirmed, invalid_code, failed, expired).",
+	}, []string{"result"})
+
+	approvalDecisionsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "approval",
+		Name:      "decisions_total",
+		Help:      "Approval votes cast by decision (APPROVE, REJECT).",
+	}, []string{"decision"})
+)
+
+func createWithdrawalOutcome(path createPath, err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidIdempotencyKeyReuse):
+		return "reuse"
+	case errors.Is(err, ErrIdempotencyInProgress):
+		return "in_progress"
+	case isRejection(err):
+		return "rejected"
+	case errors.Is(err, ErrVelocityLimitExceeded):
+		return "velocity_limited"
+	case path == createPathCommitUnknown:
+		return "commit_unknown"
+	case err != nil:
+		return "error"
+	case path == createPathReconciled:
+		return "reconciled"
+	default:
+		return "created"
+	}
+}
+
+// isRejection reports errors returned before the idempotency key is reserved.
+func isRejection(err error) bool {
+	return errors.Is(err, ErrInvalidInput) ||
+		errors.Is(err, ErrPrincipalMismatch) ||
+		errors.Is(err, ErrUnknownAsset) ||
+		errors.Is(err, ErrAssetNotEnabled) ||
+		errors.Is(err, ErrAmountOutOfRange) ||
+		errors.Is(err, ErrInvalidDestination) ||
+		errors.Is(err, ErrDestinationNotAllowlisted) ||
+		errors.Is(err, ErrDestinationCoolingOff)
+}
@@ -10,6 +10,7 @@ import (
 
 func failIdemRetryPolicy() retry.Config {
 	cfg := retry.DefaultConfig()
+	cfg.Op = "idempotency/set_failed"
 	cfg.IsRetryable = isRetryableFailIdem
 	return cfg
 }
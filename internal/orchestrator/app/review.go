@@ -0,0 +1,464 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ReviewDecisionApprove = "APPROVE"
+	ReviewDecisionReject  = "REJECT"
+)
+
+const (
+	defaultReviewPageSize = 50
+	maxReviewPageSize     = 200
+	maxReviewNotesLen     = 2000
+	reviewExpireBatch     = 100
+)
+
+type ManualReview = repo.ManualReview
+
+func authorizeReviewer(p auth.Principal) error {
+	if !p.HasRole(auth.RoleAdmin, auth.RoleRiskOperator) {
+		return ErrReviewerRequired
+	}
+	return nil
+}
+
+type ListReviewsParams struct {
+	// Status filters by review status. Empty lists open reviews (PENDING and CLAIMED).
+	Status    string
+	PageSize  int
+	PageToken string
+	Principal auth.Principal
+}
+
+// ListReviews pages through the queue in SLA order, soonest due first.
+func (s *Service) ListReviews(
+	ctx context.Context,
+	p ListReviewsParams,
+) ([]ManualReview, string, error) {
+	if err := authorizeReviewer(p.Principal); err != nil {
+		return nil, "", err
+	}
+
+	statuses := []string{orchestrator.ReviewStatusPending, orchestrator.ReviewStatusClaimed}
+	if st := strings.ToUpper(strings.TrimSpace(p.Status)); st != "" {
+		switch st {
+		case orchestrator.ReviewStatusPending,
+			orchestrator.ReviewStatusClaimed,
+			orchestrator.ReviewStatusApproved,
+			orchestrator.ReviewStatusRejected,
+			orchestrator.ReviewStatusExpired,
+			orchestrator.ReviewStatusStale:
+		default:
+			return nil, "", fmt.Errorf("%w: unknown review status %q", ErrInvalidInput, p.Status)
+		}
+		statuses = []string{st}
+	}
+
+	size := p.PageSize
+	if size <= 0 {
+		size = defaultReviewPageSize
+	}
+	size = min(size, maxReviewPageSize)
+
+	after, err := decodeReviewCursor(p.PageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	reviews, err := s.repo.ListManualReviews(ctx, s.db, repo.ListManualReviewsParams{
+		Statuses: statuses,
+		After:    after,
+		Limit:    size + 1,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(reviews) > size {
+		reviews = reviews[:size]
+		last := reviews[size-1]
+		next = encodeReviewCursor(repo.ReviewCursor{
+			DueAt:        last.DueAt,
+			WithdrawalID: last.WithdrawalID,
+		})
+	}
+	return reviews, next, nil
+}
+
+type ClaimReviewParams struct {
+	WithdrawalID string
+	Principal    auth.Principal
+}
+
+// ClaimReview assigns a review to the calling operator. Claiming a review already held by the
+// caller is a no-op; a claim held by someone else can be taken over once it is older than the
+// configured claim TTL.
+func (s *Service) ClaimReview(ctx context.Context, p ClaimReviewParams) (ManualReview, error) {
+	if err := authorizeReviewer(p.Principal); err != nil {
+		return ManualReview{}, err
+	}
+	if _, err := uuid.Parse(p.WithdrawalID); err != nil {
+		return ManualReview{}, fmt.Errorf("%w: withdrawal_id must be a uuid", ErrInvalidInput)
+	}
+
+	now := time.Now().UTC()
+	var out ManualReview
+	err := postgres.WithTx(ctx, s.db, pgx.TxOptions{}, "review_claim",
+		func(ctx context.Context, tx pgx.Tx) error {
+			m, err := s.lockOpenReview(ctx, tx, p.WithdrawalID, p.Principal)
+			if err != nil {
+				return err
+			}
+
+			err = s.repo.ClaimManualReviewTx(ctx, tx, repo.ClaimManualReviewParams{
+				WithdrawalID: p.WithdrawalID,
+				Operator:     p.Principal.Subject,
+				Now:          now,
+				StaleBefore:  now.Add(-s.cfg.ReviewClaimTTL),
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("%w: held by %s", ErrReviewClaimed, deref(m.ClaimedBy))
+			}
+			if err != nil {
+				return err
+			}
+
+			out, err = s.repo.GetManualReview(ctx, tx, p.WithdrawalID)
+			return err
+		})
+	if err != nil {
+		return ManualReview{}, err
+	}
+
+	s.log.InfoContext(ctx, "audit: manual review claimed",
+		"principal", p.Principal.Subject,
+		"withdrawal_id", p.WithdrawalID,
+	)
+	return out, nil
+}
+
+type DecideReviewParams struct {
+	WithdrawalID string
+	Decision     string // APPROVE | REJECT
+	Notes        string
+	TraceID      string
+	Principal    auth.Principal
+}
+
+// DecideReview records the operator's decision and resumes the saga in the same transaction.
+// The caller must hold the claim. Repeating a decision already recorded by the caller returns
+// the review unchanged.
+func (s *Service) DecideReview(ctx context.Context, p DecideReviewParams) (ManualReview, error) {
+	if err := authorizeReviewer(p.Principal); err != nil {
+		return ManualReview{}, err
+	}
+	if _, err := uuid.Parse(p.WithdrawalID); err != nil {
+		return ManualReview{}, fmt.Errorf("%w: withdrawal_id must be a uuid", ErrInvalidInput)
+	}
+
+	var status, eventType string
+	switch strings.ToUpper(strings.TrimSpace(p.Decision)) {
+	case ReviewDecisionApprove:
+		status = orchestrator.ReviewStatusApproved
+		eventType = orchestrator.EventTypeWithdrawalApproved
+	case ReviewDecisionReject:
+		status = orchestrator.ReviewStatusRejected
+		eventType = orchestrator.EventTypeWithdrawalFailed
+	default:
+		return ManualReview{}, fmt.Errorf("%w: decision must be APPROVE or REJECT", ErrInvalidInput)
+	}
+
+	notes := strings.TrimSpace(p.Notes)
+	if notes == "" {
+		return ManualReview{}, fmt.Errorf("%w: notes are required", ErrInvalidInput)
+	}
+	if len(notes) > maxReviewNotesLen {
+		return ManualReview{}, fmt.Errorf("%w: notes exceed %d bytes",
+			ErrInvalidInput,
+			maxReviewNotesLen,
+		)
+	}
+
+	traceID := p.TraceID
+	if traceID == "" {
+		traceID = uuid.NewString()
+	}
+	operator := p.Principal.Subject
+	now := time.Now().UTC()
+
+	var out ManualReview
+	var applied, stale bool
+	var next string
+	err := postgres.WithTx(ctx, s.db, pgx.TxOptions{}, "review_decide",
+		func(ctx context.Context, tx pgx.Tx) error {
+			m, err := s.repo.LockManualReviewTx(ctx, tx, p.WithdrawalID)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return ErrReviewNotFound
+			}
+			if err != nil {
+				return err
+			}
+			if m.Status == status && deref(m.DecidedBy) == operator {
+				out = m
+				return nil
+			}
+			if err := checkReviewOpen(m, p.Principal); err != nil {
+				return err
+			}
+			if m.Status != orchestrator.ReviewStatusClaimed || deref(m.ClaimedBy) != operator {
+				return ErrReviewNotClaimed
+			}
+
+			reason := "manual review: " + notes
+			payload, err := codec.EncodeValid(&orchestrator.WithdrawalEventPayload{
+				WithdrawalID: m.WithdrawalID,
+				UserID:       m.UserID,
+				Step:         orchestrator.SagaStepManualReview,
+				Reason:       &reason,
+			})
+			if err != nil {
+				return err
+			}
+
+			resolve := repo.ResolveManualReviewParams{
+				WithdrawalID: m.WithdrawalID,
+				Status:       status,
+				DecidedBy:    &operator,
+				Notes:        &notes,
+				At:           now,
+				TraceID:      traceID,
+				Traceparent:  tracing.Traceparent(ctx),
+				Outbox: repo.OutboxEvent{
+					EventType: eventType,
+					Payload:   string(payload),
+					RouteKey:  orchestrator.RouteKeyWithdrawalEvt,
+				},
+			}
+			if status == orchestrator.ReviewStatusApproved {
+				ch, err := s.cfg.Challenges.Params(m.WithdrawalID, m.UserID, now)
+				if err != nil {
+					return err
+				}
+				resolve.Challenge = &ch
+			}
+
+			outcome, err := s.repo.ResolveManualReviewTx(ctx, tx, resolve)
+			if err != nil {
+				return err
+			}
+			applied, stale, next = outcome.Applied, outcome.Stale, outcome.Step
+
+			out, err = s.repo.GetManualReview(ctx, tx, p.WithdrawalID)
+			return err
+		})
+	if err != nil {
+		return ManualReview{}, err
+	}
+	if stale {
+		reviewDecisionsTotal.WithLabelValues(orchestrator.ReviewStatusStale).Inc()
+		s.log.WarnContext(ctx, "manual review closed as stale",
+			"principal", operator,
+			"withdrawal_id", p.WithdrawalID,
+		)
+		return ManualReview{}, fmt.Errorf("%w: review is %s", ErrReviewClosed, out.Status)
+	}
+
+	if applied {
+		reviewDecisionsTotal.WithLabelValues(status).Inc()
+		s.log.InfoContext(ctx, "audit: manual review decided",
+			"principal", operator,
+			"withdrawal_id", p.WithdrawalID,
+			"decision", status,
+			"next_step", next,
+		)
+	}
+	return out, nil
+}
+
+// lockOpenReview locks the review and checks the principal may act on it.
+func (s *Service) lockOpenReview(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	principal auth.Principal,
+) (ManualReview, error) {
+	m, err := s.repo.LockManualReviewTx(ctx, tx, withdrawalID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ManualReview{}, ErrReviewNotFound
+	}
+	if err != nil {
+		return ManualReview{}, err
+	}
+	if err := checkReviewOpen(m, principal); err != nil {
+		return ManualReview{}, err
+	}
+	return m, nil
+}
+
+func checkReviewOpen(m ManualReview, principal auth.Principal) error {
+	if m.UserID == principal.Subject {
+		return ErrSelfReview
+	}
+	switch m.Status {
+	case orchestrator.ReviewStatusPending, orchestrator.ReviewStatusClaimed:
+	default:
+		return fmt.Errorf("%w: review is %s", ErrReviewClosed, m.Status)
+	}
+	return nil
+}
+
+// ExpireReviews fails every open review past its SLA and returns how many it expired. Reviews
+// whose saga already left MANUAL_REVIEW are closed as STALE instead.
+func (s *Service) ExpireReviews(ctx context.Context) (int, error) {
+	ctx, span := s.tracer.Start(ctx, "app.ExpireReviews")
+	defer span.End()
+
+	traceID := tracing.TraceID(ctx)
+	if traceID == "" {
+		traceID = uuid.NewString()
+	}
+
+	total := 0
+	for {
+		n, stale, seen := 0, 0, 0
+		now := time.Now().UTC()
+		err := postgres.WithTx(ctx, s.db, pgx.TxOptions{}, "review_expire",
+			func(ctx context.Context, tx pgx.Tx) error {
+				due, err := s.repo.ListDueManualReviewsTx(ctx, tx, now, reviewExpireBatch)
+				if err != nil {
+					return err
+				}
+				seen = len(due)
+
+				for _, m := range due {
+					reason := fmt.Sprintf("manual review SLA expired at %s",
+						m.DueAt.UTC().Format(time.RFC3339),
+					)
+					payload, err := codec.EncodeValid(&orchestrator.WithdrawalEventPayload{
+						WithdrawalID: m.WithdrawalID,
+						UserID:       m.UserID,
+						Step:         orchestrator.SagaStepManualReview,
+						Reason:       &reason,
+					})
+					if err != nil {
+						return err
+					}
+
+					outcome, err := s.repo.ResolveManualReviewTx(ctx, tx,
+						repo.ResolveManualReviewParams{
+							WithdrawalID: m.WithdrawalID,
+							Status:       orchestrator.ReviewStatusExpired,
+							At:           now,
+							TraceID:      traceID,
+							Traceparent:  tracing.Traceparent(ctx),
+							Outbox: repo.OutboxEvent{
+								EventType: orchestrator.EventTypeWithdrawalFailed,
+								Payload:   string(payload),
+								RouteKey:  orchestrator.RouteKeyWithdrawalEvt,
+							},
+						},
+					)
+					if err != nil {
+						return err
+					}
+					switch {
+					case outcome.Applied:
+						n++
+					case outcome.Stale:
+						stale++
+						s.log.WarnContext(ctx, "manual review closed as stale",
+							"withdrawal_id", m.WithdrawalID,
+						)
+					}
+				}
+				return nil
+			})
+		if err != nil {
+			tracing.RecordError(span, err)
+			return total, err
+		}
+
+		total += n
+		reviewDecisionsTotal.WithLabelValues(orchestrator.ReviewStatusExpired).Add(float64(n))
+		reviewDecisionsTotal.WithLabelValues(orchestrator.ReviewStatusStale).Add(float64(stale))
+		if seen < reviewExpireBatch {
+			return total, nil
+		}
+	}
+}
+
+// RunReviewSweeper expires overdue reviews every interval until ctx is done.
+func (s *Service) RunReviewSweeper(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			n, err := s.ExpireReviews(ctx)
+			if err != nil {
+				s.log.ErrorContext(ctx, "expire manual reviews failed", "err", err)
+				continue
+			}
+			if n > 0 {
+				s.log.InfoContext(ctx, "manual reviews expired", "count", n)
+			}
+		}
+	}
+}
+
+func encodeReviewCursor(c repo.ReviewCursor) string {
+	raw := c.DueAt.UTC().Format(time.RFC3339Nano) + "|" + c.WithdrawalID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeReviewCursor(token string) (*repo.ReviewCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid page_token", ErrInvalidInput)
+	}
+	due, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid page_token", ErrInvalidInput)
+	}
+	dueAt, err := time.Parse(time.RFC3339Nano, due)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid page_token", ErrInvalidInput)
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("%w: invalid page_token", ErrInvalidInput)
+	}
+
+	return &repo.ReviewCursor{DueAt: dueAt, WithdrawalID: id}, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -3,7 +3,6 @@ package app
 import (
 	"crypto/sha256"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"strings"
 
@@ -28,24 +27,15 @@ func NewValidatedCreateWithdrawal(p CreateWithdrawalParams) (validatedCreateWith
 	traceID := p.TraceID
 
 	if userID == "" || asset == "" || dest == "" || idemKey == "" {
-		return validatedCreateWithdrawal{}, errors.New("invalid input: missing required fields")
+		return validatedCreateWithdrawal{}, fmt.Errorf("%w: missing required fields", ErrInvalidInput)
 	}
 	if p.AmountMinor <= 0 {
-		return validatedCreateWithdrawal{}, errors.New("invalid input: amount_minor must be > 0")
+		return validatedCreateWithdrawal{}, fmt.Errorf("%w: amount_minor must be > 0", ErrInvalidInput)
 	}
 	if traceID == "" {
 		traceID = uuid.NewString()
 	}
 
-	canonical := fmt.Sprintf("user_id=%s|asset=%s|amount_minor=%d|destination_addr=%s",
-		userID,
-		asset,
-		p.AmountMinor,
-		dest,
-	)
-	sum := sha256.Sum256([]byte(canonical))
-	reqHash := hex.EncodeToString(sum[:])
-
 	return validatedCreateWithdrawal{
 		UserID:          userID,
 		Asset:           asset,
@@ -53,6 +43,25 @@ func NewValidatedCreateWithdrawal(p CreateWithdrawalParams) (validatedCreateWith
 		DestinationAddr: dest,
 		IdempotencyKey:  idemKey,
 		TraceID:         traceID,
-		RequestHash:     reqHash,
+		RequestHash:     requestHash(userID, asset, p.AmountMinor, dest),
 	}, nil
 }
+
+// withDestination replaces the destination with its canonical form and recomputes the request
+// hash, so equivalent spellings of one address replay the same idempotent request.
+func (v validatedCreateWithdrawal) withDestination(dest string) validatedCreateWithdrawal {
+	v.DestinationAddr = dest
+	v.RequestHash = requestHash(v.UserID, v.Asset, v.AmountMinor, dest)
+	return v
+}
+
+func requestHash(userID string, asset string, amountMinor int64, dest string) string {
+	canonical := fmt.Sprintf("user_id=%s|asset=%s|amount_minor=%d|destination_addr=%s",
+		userID,
+		asset,
+		amountMinor,
+		dest,
+	)
+	sum := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
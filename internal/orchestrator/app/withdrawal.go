@@ -6,29 +6,62 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/cicconee/cbsaga/internal/orchestrator/address"
+	"github.com/cicconee/cbsaga/internal/orchestrator/challenge"
 	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
+	"github.com/cicconee/cbsaga/internal/platform/auth"
 	"github.com/cicconee/cbsaga/internal/platform/codec"
 	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
 	"github.com/cicconee/cbsaga/internal/platform/logging"
 	"github.com/cicconee/cbsaga/internal/platform/retry"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
 	"github.com/cicconee/cbsaga/internal/shared/identity"
 	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "github.com/cicconee/cbsaga/internal/orchestrator/app"
+
+const (
+	AllowlistOff     = "off"
+	AllowlistAudit   = "audit"
+	AllowlistEnforce = "enforce"
+)
+
+type Config struct {
+	Addresses *address.Registry
+
+	// AllowlistMode is off, audit (log destinations that would be rejected) or enforce.
+	AllowlistMode string
+	// CoolingOff is how long a newly added withdrawal address waits before it is usable.
+	CoolingOff time.Duration
+	// VelocityDefaultTier is the velocity limits tier for users without an assigned one.
+	VelocityDefaultTier string
+	// ReviewClaimTTL is how long a review claim is held before another operator may take it.
+	ReviewClaimTTL time.Duration
+	// Challenges issues the one-time codes for approvals that reach an asset's threshold.
+	Challenges challenge.Policy
+}
+
 type Service struct {
-	db   *pgxpool.Pool
-	repo *repo.Repo
-	log  *logging.Logger
+	db     *pgxpool.Pool
+	repo   *repo.Repo
+	log    *logging.Logger
+	tracer trace.Tracer
+	cfg    Config
 }
 
-func NewService(db *pgxpool.Pool, log *logging.Logger) *Service {
+func NewService(db *pgxpool.Pool, log *logging.Logger, cfg Config) *Service {
 	return &Service{
-		db:   db,
-		repo: repo.New(),
-		log:  log,
+		db:     db,
+		repo:   repo.New(),
+		log:    log,
+		tracer: tracing.Tracer(tracerName),
+		cfg:    cfg,
 	}
 }
 
@@ -39,6 +72,7 @@ type CreateWithdrawalParams struct {
 	DestinationAddr string
 	IdempotencyKey  string
 	TraceID         string
+	Principal       auth.Principal
 }
 
 type CreateWithdrawalResult struct {
@@ -49,13 +83,54 @@ type CreateWithdrawalResult struct {
 func (s *Service) CreateWithdrawal(
 	ctx context.Context,
 	p CreateWithdrawalParams,
-) (CreateWithdrawalResult, error) {
+) (_ CreateWithdrawalResult, err error) {
+	ctx, span := s.tracer.Start(ctx, "app.CreateWithdrawal")
+	path := createPathCreated
+	defer func() {
+		createWithdrawalTotal.WithLabelValues(createWithdrawalOutcome(path, err)).Inc()
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	now := time.Now().UTC()
 
 	v, err := NewValidatedCreateWithdrawal(p)
 	if err != nil {
 		return CreateWithdrawalResult{}, err
 	}
+	if v.UserID != p.Principal.Subject {
+		s.log.WarnContext(ctx, "CreateWithdrawal rejected: principal mismatch",
+			"principal", p.Principal.Subject,
+			"user_id", v.UserID,
+		)
+		return CreateWithdrawalResult{}, ErrPrincipalMismatch
+	}
+	span.SetAttributes(attribute.String("user_id", v.UserID), attribute.String("asset", v.Asset))
+
+	asset, err := s.lookupAsset(ctx, v.Asset)
+	if err != nil {
+		return CreateWithdrawalResult{}, err
+	}
+	dest, err := s.cfg.Addresses.Canonicalize(asset.Network, v.DestinationAddr)
+	if err != nil {
+		return CreateWithdrawalResult{}, fmt.Errorf("%w: %v", ErrInvalidDestination, err)
+	}
+	v = v.withDestination(dest)
+
+	// A retry of a request already seen gets its stored outcome, even if the asset was paused
+	// or the destination's status changed since.
+	replayed, ok, err := s.replay(ctx, v, now)
+	if err != nil || ok {
+		path = createPathReconciled
+		return replayed, err
+	}
+
+	if err := checkAsset(asset, v); err != nil {
+		return CreateWithdrawalResult{}, err
+	}
+	if err := s.checkAllowlist(ctx, v.UserID, asset.Network, v.DestinationAddr, now); err != nil {
+		return CreateWithdrawalResult{}, err
+	}
 
 	// Reserve the idempotency key
 	reserveTx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
@@ -80,14 +155,23 @@ func (s *Service) CreateWithdrawal(
 		return CreateWithdrawalResult{}, err
 	}
 	if err := reserveTx.Commit(ctx); err != nil {
+		path = createPathReconciled
 		return s.reconcile(ctx, v.UserID, v.IdempotencyKey)
 	}
 
 	// Reserve idempotency transaction is committed and idempotency key is reserved in db
 	// but current run does not own it.
 	if !idemRow.Owned {
+		path = createPathReconciled
 		return s.reconcile(ctx, v.UserID, v.IdempotencyKey)
 	}
+	if idemRow.StoleOwnership {
+		idemLeaseStealsTotal.Inc()
+	}
+	span.SetAttributes(
+		attribute.String("withdrawal_id", idemRow.WithdrawalID),
+		attribute.Bool("idempotency.stole_lease", idemRow.StoleOwnership),
+	)
 
 	// begin tx that will create the withdrawal.
 	finalParams := finalizeIdemParams{
@@ -102,6 +186,7 @@ func (s *Service) CreateWithdrawal(
 
 	workTx, err := s.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
+		path = createPathReconciled
 		return s.failAndReconcile(ctx, 13, finalParams)
 	}
 	defer func() { _ = workTx.Rollback(ctx) }()
@@ -110,8 +195,11 @@ func (s *Service) CreateWithdrawal(
 	identityPayload, err := codec.EncodeValid(&identity.IdentityRequestCmdPayload{
 		WithdrawalID: idemRow.WithdrawalID,
 		UserID:       v.UserID,
+		Asset:        v.Asset,
+		AmountMinor:  v.AmountMinor,
 	})
 	if err != nil {
+		path = createPathReconciled
 		return s.failAndReconcile(ctx, 13, finalParams)
 	}
 	withdrawPayload, err := codec.EncodeValid(&orchestrator.WithdrawalRequestPayload{
@@ -119,17 +207,21 @@ func (s *Service) CreateWithdrawal(
 		UserID:       v.UserID,
 	})
 	if err != nil {
+		path = createPathReconciled
 		return s.failAndReconcile(ctx, 13, finalParams)
 	}
 
 	res, err := s.repo.CreateWithdrawalTx(ctx, workTx, repo.CreateWithdrawalParams{
-		WithdrawalID:    idemRow.WithdrawalID,
-		SagaID:          uuid.NewString(),
-		UserID:          v.UserID,
-		Asset:           v.Asset,
-		AmountMinor:     v.AmountMinor,
-		DestinationAddr: v.DestinationAddr,
-		TraceID:         v.TraceID,
+		WithdrawalID:        idemRow.WithdrawalID,
+		SagaID:              uuid.NewString(),
+		UserID:              v.UserID,
+		Asset:               v.Asset,
+		AmountMinor:         v.AmountMinor,
+		DestinationAddr:     v.DestinationAddr,
+		RequestedBy:         p.Principal.Subject,
+		TraceID:             v.TraceID,
+		Traceparent:         tracing.Traceparent(ctx),
+		VelocityDefaultTier: s.cfg.VelocityDefaultTier,
 		OutboxEvents: []repo.OutboxEvent{
 			{
 				EventType: orchestrator.EventTypeWithdrawalRequested,
@@ -146,8 +238,20 @@ func (s *Service) CreateWithdrawal(
 	if err != nil {
 		// If withdrawal already exists some how, reconcile, do not mark as failure.
 		if errors.Is(err, repo.ErrWithdrawalAlreadyExists) {
+			path = createPathReconciled
 			return s.reconcile(ctx, v.UserID, v.IdempotencyKey)
 		}
+		// Over a velocity cap: release the (user, asset) lock before recording the rejection
+		// on the idempotency key.
+		var velErr repo.VelocityLimitError
+		if errors.As(err, &velErr) {
+			_ = workTx.Rollback(ctx)
+			path = createPathReconciled
+			return s.rejectAndReconcile(ctx, 9, finalParams,
+				fmt.Errorf("%w: %v", ErrVelocityLimitExceeded, velErr),
+			)
+		}
+		path = createPathReconciled
 		return s.failAndReconcile(ctx, 13, finalParams)
 	}
 
@@ -155,20 +259,24 @@ func (s *Service) CreateWithdrawal(
 	outcome, err := s.completeIdempotency(ctx, workTx, 0, finalParams)
 	if err != nil {
 		if errors.Is(err, repo.ErrLostLeaseOwnership) {
+			path = createPathReconciled
 			return s.reconcile(ctx, v.UserID, v.IdempotencyKey)
 		}
+		path = createPathReconciled
 		return s.failAndReconcile(ctx, 13, finalParams)
 	}
 
 	// This current run took too long from the moment of ownership till now, that
 	// another run gained ownership of the lease and already finalized the withdrawal request.
 	if outcome == repo.FinalizeAlreadyFinalized {
+		path = createPathReconciled
 		return s.reconcile(ctx, v.UserID, v.IdempotencyKey)
 	}
 
 	// Commit the atomic transaction: finalizes idempotency key status and inserts withdrawal.
 	if err := workTx.Commit(ctx); err != nil {
 		// Outcome unknown
+		path = createPathCommitUnknown
 		rctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 		defer cancel()
 
@@ -224,11 +332,22 @@ func (s *Service) failAndReconcile(
 	ctx context.Context,
 	grpcCode int,
 	p finalizeIdemParams,
+) (CreateWithdrawalResult, error) {
+	return s.rejectAndReconcile(ctx, grpcCode, p, ErrCreateWithdrawalFailed)
+}
+
+// rejectAndReconcile marks the idempotency key FAILED with grpcCode and returns domainErr, or
+// reconciles if another attempt already finalized it.
+func (s *Service) rejectAndReconcile(
+	ctx context.Context,
+	grpcCode int,
+	p finalizeIdemParams,
+	domainErr error,
 ) (CreateWithdrawalResult, error) {
 	outcome, err := s.failIdempotencyWithRetry(ctx, grpcCode, p)
 	if err == nil && outcome == repo.FinalizeApplied {
 		// Finalized applied successfully (marked FAILED), so return the domain error.
-		return CreateWithdrawalResult{}, ErrCreateWithdrawalFailed
+		return CreateWithdrawalResult{}, domainErr
 	}
 
 	res, rerr := s.reconcile(ctx, p.userID, p.idemKey)
@@ -342,6 +461,36 @@ func (s *Service) failIdempotencyWithRetry(
 	return outcome, nil
 }
 
+// replay returns the stored outcome of a request whose idempotency key was already used. ok is
+// false for a new key, and for an attempt that was abandoned midway, whose lease the caller takes
+// over by reserving the key as usual.
+func (s *Service) replay(
+	ctx context.Context,
+	v validatedCreateWithdrawal,
+	now time.Time,
+) (_ CreateWithdrawalResult, ok bool, err error) {
+	idemRow, err := s.repo.GetIdem(ctx, s.db, repo.GetIdemParams{
+		UserID:         v.UserID,
+		IdempotencyKey: v.IdempotencyKey,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return CreateWithdrawalResult{}, false, nil
+		}
+		return CreateWithdrawalResult{}, false, err
+	}
+
+	if idemRow.RequestHash != v.RequestHash {
+		return CreateWithdrawalResult{}, true, ErrInvalidIdempotencyKeyReuse
+	}
+	if idemRow.Status == orchestrator.IdemInProgress && !idemRow.LeaseExpiresAt.After(now) {
+		return CreateWithdrawalResult{}, false, nil
+	}
+
+	res, err := s.reconcile(ctx, v.UserID, v.IdempotencyKey)
+	return res, true, err
+}
+
 func (s *Service) reconcile(
 	ctx context.Context,
 	userID, idemKey string,
@@ -378,10 +527,7 @@ func (s *Service) reconcile(
 		}, nil
 
 	case orchestrator.IdemFailed:
-		return CreateWithdrawalResult{}, fmt.Errorf(
-			"previous attempt failed (grpc_code=%d)",
-			idemRow.GRPCCode,
-		)
+		return CreateWithdrawalResult{}, PreviousAttemptFailedError{GRPCCode: idemRow.GRPCCode}
 	case orchestrator.IdemInProgress:
 		existingWithdrawal, err := s.repo.GetWithdrawal(ctx, s.db, repo.GetWithdrawalParams{
 			WithdrawalID: idemRow.WithdrawalID,
@@ -406,6 +552,7 @@ func (s *Service) reconcile(
 
 type GetWithdrawalParams struct {
 	WithdrawalID string
+	Principal    auth.Principal
 }
 
 type GetWithdrawalResult struct {
@@ -416,8 +563,16 @@ type GetWithdrawalResult struct {
 	DestinationAddr string
 	Status          string
 	FailureReason   *string
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	// Set once the withdrawal's transaction is broadcast, and for the block once it completes.
+	TxHash        *string
+	FromAddr      *string
+	BroadcastAt   *time.Time
+	BlockHeight   *int64
+	BlockHash     *string
+	Confirmations *int64
+	CompletedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 func (s *Service) GetWithdrawal(
@@ -433,6 +588,18 @@ func (s *Service) GetWithdrawal(
 		return GetWithdrawalResult{}, err
 	}
 
+	if row.UserID != p.Principal.Subject {
+		if !p.Principal.IsPrivileged() {
+			return GetWithdrawalResult{}, ErrWithdrawalAccessDenied
+		}
+		s.log.InfoContext(ctx, "audit: withdrawal read by privileged principal",
+			"principal", p.Principal.Subject,
+			"roles", p.Principal.Roles,
+			"withdrawal_id", row.WithdrawalID,
+			"owner_user_id", row.UserID,
+		)
+	}
+
 	return GetWithdrawalResult{
 		WithdrawalID:    row.WithdrawalID,
 		UserID:          row.UserID,
@@ -441,6 +608,13 @@ func (s *Service) GetWithdrawal(
 		DestinationAddr: row.DestinationAddr,
 		Status:          row.Status,
 		FailureReason:   row.FailureReason,
+		TxHash:          row.TxHash,
+		FromAddr:        row.FromAddr,
+		BroadcastAt:     row.BroadcastAt,
+		BlockHeight:     row.BlockHeight,
+		BlockHash:       row.BlockHash,
+		Confirmations:   row.Confirmations,
+		CompletedAt:     row.CompletedAt,
 		CreatedAt:       row.CreatedAt,
 		UpdatedAt:       row.UpdatedAt,
 	}, nil
@@ -0,0 +1,107 @@
+package challenge
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+)
+
+const codeDigits = 6
+
+// Policy is how long a one-time code stays valid and how many wrong codes fail the withdrawal.
+// CodeKey seals the code for the notification sender.
+type Policy struct {
+	TTL         time.Duration
+	MaxAttempts int
+	CodeKey     []byte
+}
+
+// Params prepares a challenge for the withdrawal. The code only leaves the orchestrator sealed
+// in the notification event; the challenge row keeps a salted hash.
+func (p Policy) Params(withdrawalID, userID string, now time.Time) (repo.ChallengeParams, error) {
+	code, err := newCode()
+	if err != nil {
+		return repo.ChallengeParams{}, err
+	}
+	hash, err := hashCode(code)
+	if err != nil {
+		return repo.ChallengeParams{}, err
+	}
+	sealed, err := orchestrator.SealChallengeCode(p.CodeKey, withdrawalID, code)
+	if err != nil {
+		return repo.ChallengeParams{}, err
+	}
+	expiresAt := now.Add(p.TTL)
+
+	payload, err := codec.EncodeValid(&orchestrator.WithdrawalChallengePayload{
+		WithdrawalID: withdrawalID,
+		UserID:       userID,
+		SealedCode:   sealed,
+		ExpiresAt:    expiresAt.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return repo.ChallengeParams{}, err
+	}
+
+	return repo.ChallengeParams{
+		CodeHash:    hash,
+		ExpiresAt:   expiresAt,
+		MaxAttempts: p.MaxAttempts,
+		Outbox: repo.OutboxEvent{
+			EventType: orchestrator.EventTypeChallengeIssued,
+			Payload:   string(payload),
+			RouteKey:  orchestrator.RouteKeyNotificationEvt,
+		},
+	}, nil
+}
+
+// Matches reports whether code is the one hash was made from.
+func Matches(hash, code string) bool {
+	salt, sum, ok := strings.Cut(hash, ":")
+	if !ok {
+		return false
+	}
+	saltBytes, err := hex.DecodeString(salt)
+	if err != nil {
+		return false
+	}
+	want, err := hex.DecodeString(sum)
+	if err != nil {
+		return false
+	}
+	got := digest(saltBytes, strings.TrimSpace(code))
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
+
+func newCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("challenge: generate code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", codeDigits, n.Int64()), nil
+}
+
+// hashCode returns "<salt hex>:<sha256(salt || code) hex>".
+func hashCode(code string) (string, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("challenge: generate salt: %w", err)
+	}
+	return hex.EncodeToString(salt) + ":" + hex.EncodeToString(digest(salt, code)), nil
+}
+
+func digest(salt []byte, code string) []byte {
+	h := sha256.New()
+	h.Write(salt)
+	h.Write([]byte(code))
+	return h.Sum(nil)
+}
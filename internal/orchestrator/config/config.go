@@ -1,20 +1,77 @@
 package config
 
 import (
+	"encoding/hex"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/cicconee/cbsaga/internal/platform/config"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+)
+
+const (
+	RateLimitNone     = "none"
+	RateLimitMemory   = "memory"
+	RateLimitPostgres = "postgres"
 )
 
 type OrchestratorConfig struct {
-	Env                 string
-	GRPCAddr            string
-	ShutdownTimeout     time.Duration
-	PostgresDSN         string
-	KafkaBrokers        []string
-	IdentityEvtTopic    string
-	OrchestratorGroupID string
+	Env                    string
+	GRPCAddr               string
+	ShutdownTimeout        time.Duration
+	PostgresDSN            string
+	KafkaBrokers           []string
+	IdentityEvtTopic       string
+	RiskEvtTopic           string
+	OrchestratorGroupID    string
+	RiskGroupID            string
+	ExecutionEvtTopic      string
+	ExecutionGroupID       string
+	TraceExporter          string
+	TraceFile              string
+	OTLPEndpoint           string
+	OTLPInsecure           bool
+	OpsAddr                string
+	HealthInterval         time.Duration
+	ConsumerStall          time.Duration
+	LogFormat              string
+	LogLevel               string
+	AuthHMACKeys           []string
+	AuthIssuer             string
+	AuthAudience           string
+	TLSCertFile            string
+	TLSKeyFile             string
+	TLSClientCAFile        string
+	TLSReloadInterval      time.Duration
+	GRPCReflection         bool
+	RateLimitBackend       string
+	CreateUserBurst        int
+	CreateUserEvery        time.Duration
+	CreateMethodBurst      int
+	CreateMethodEvery      time.Duration
+	CreateMethodShards     int
+	WriteUserBurst         int
+	WriteUserEvery         time.Duration
+	HTTPAddr               string
+	GatewayTarget          string
+	GatewayCAFile          string
+	GatewayCertFile        string
+	GatewayKeyFile         string
+	GatewayServerName      string
+	BitcoinChain           string
+	AddressPattern         string
+	AllowlistMode          string
+	AddressCoolingOff      time.Duration
+	VelocityDefaultTier    string
+	ReviewSLA              time.Duration
+	ReviewClaimTTL         time.Duration
+	ReviewSweepInterval    time.Duration
+	ChallengeTTL           time.Duration
+	ChallengeMaxAttempts   int
+	ChallengeSweepInterval time.Duration
+	ChallengeCodeKey       []byte
+	MaxRebroadcasts        int
 }
 
 func Load() (OrchestratorConfig, error) {
@@ -30,12 +87,188 @@ func Load() (OrchestratorConfig, error) {
 			config.GetEnv("CBSAGA_KAFKA_BROKERS", "localhost:9092"),
 		),
 		IdentityEvtTopic:    config.GetEnv("CBSAGA_ORCH_IDENTITY_TOPIC", "cbsaga.evt.identity"),
+		RiskEvtTopic:        config.GetEnv("CBSAGA_ORCH_RISK_TOPIC", "cbsaga.evt.risk"),
 		OrchestratorGroupID: config.GetEnv("CBSAGA_ORCH_GROUP_ID", "cbsaga-orchestrator"),
+		RiskGroupID:         config.GetEnv("CBSAGA_ORCH_RISK_GROUP_ID", "cbsaga-orchestrator-risk"),
+		ExecutionEvtTopic: config.GetEnv(
+			"CBSAGA_ORCH_EXECUTION_TOPIC",
+			"cbsaga.evt.execution",
+		),
+		ExecutionGroupID: config.GetEnv(
+			"CBSAGA_ORCH_EXECUTION_GROUP_ID",
+			"cbsaga-orchestrator-execution",
+		),
+		TraceExporter: config.GetEnv("CBSAGA_TRACE_EXPORTER", "none"),
+		TraceFile: config.GetEnv(
+			"CBSAGA_ORCH_TRACE_FILE",
+			"./.run/traces/orchestrator.json",
+		),
+		OTLPEndpoint:    config.GetEnv("CBSAGA_OTLP_ENDPOINT", "localhost:4317"),
+		OTLPInsecure:    config.GetEnvBool("CBSAGA_OTLP_INSECURE", true),
+		OpsAddr:         config.GetEnv("CBSAGA_ORCH_OPS_ADDR", ":9100"),
+		HealthInterval:  config.GetEnvDuration("CBSAGA_HEALTH_INTERVAL", 5*time.Second),
+		ConsumerStall:   config.GetEnvDuration("CBSAGA_CONSUMER_STALL_TIMEOUT", 30*time.Second),
+		LogFormat:       config.GetEnv("CBSAGA_LOG_FORMAT", "json"),
+		LogLevel:        config.GetEnv("CBSAGA_LOG_LEVEL", "info"),
+		AuthIssuer:      config.GetEnv("CBSAGA_AUTH_ISSUER", "cbsaga-dev"),
+		AuthAudience:    config.GetEnv("CBSAGA_AUTH_AUDIENCE", "cbsaga-orchestrator"),
+		TLSCertFile:     config.GetEnv("CBSAGA_ORCH_TLS_CERT_FILE", ""),
+		TLSKeyFile:      config.GetEnv("CBSAGA_ORCH_TLS_KEY_FILE", ""),
+		TLSClientCAFile: config.GetEnv("CBSAGA_ORCH_TLS_CLIENT_CA_FILE", ""),
+		TLSReloadInterval: config.GetEnvDuration(
+			"CBSAGA_ORCH_TLS_RELOAD_INTERVAL",
+			30*time.Second,
+		),
+		RateLimitBackend:  config.GetEnv("CBSAGA_RATELIMIT_BACKEND", RateLimitPostgres),
+		CreateUserBurst:   config.GetEnvInt("CBSAGA_RATELIMIT_CREATE_USER_BURST", 5),
+		CreateUserEvery:   config.GetEnvDuration("CBSAGA_RATELIMIT_CREATE_USER_EVERY", time.Second),
+		CreateMethodBurst: config.GetEnvInt("CBSAGA_RATELIMIT_CREATE_METHOD_BURST", 200),
+		CreateMethodEvery: config.GetEnvDuration(
+			"CBSAGA_RATELIMIT_CREATE_METHOD_EVERY",
+			10*time.Millisecond,
+		),
+		CreateMethodShards: config.GetEnvInt(
+			"CBSAGA_RATELIMIT_CREATE_METHOD_SHARDS",
+			8,
+		),
+		WriteUserBurst: config.GetEnvInt("CBSAGA_RATELIMIT_WRITE_USER_BURST", 10),
+		WriteUserEvery: config.GetEnvDuration(
+			"CBSAGA_RATELIMIT_WRITE_USER_EVERY",
+			time.Second,
+		),
+		HTTPAddr:          config.GetEnv("CBSAGA_ORCH_HTTP_ADDR", ":9080"),
+		GatewayCAFile:     config.GetEnv("CBSAGA_ORCH_GATEWAY_CA_FILE", ""),
+		GatewayCertFile:   config.GetEnv("CBSAGA_ORCH_GATEWAY_CERT_FILE", ""),
+		GatewayKeyFile:    config.GetEnv("CBSAGA_ORCH_GATEWAY_KEY_FILE", ""),
+		GatewayServerName: config.GetEnv("CBSAGA_ORCH_GATEWAY_SERVER_NAME", "localhost"),
+		BitcoinChain:      config.GetEnv("CBSAGA_BITCOIN_CHAIN", "mainnet"),
+		AddressPattern: config.GetEnv(
+			"CBSAGA_ADDRESS_FALLBACK_PATTERN",
+			`^[A-Za-z0-9:._-]{16,128}$`,
+		),
+		AllowlistMode:     config.GetEnv("CBSAGA_ADDRESS_ALLOWLIST_MODE", "off"),
+		AddressCoolingOff: config.GetEnvDuration("CBSAGA_ADDRESS_COOLING_OFF", 24*time.Hour),
+		VelocityDefaultTier: config.GetEnv(
+			"CBSAGA_VELOCITY_DEFAULT_TIER",
+			"standard",
+		),
+		ReviewSLA:      config.GetEnvDuration("CBSAGA_REVIEW_SLA", 4*time.Hour),
+		ReviewClaimTTL: config.GetEnvDuration("CBSAGA_REVIEW_CLAIM_TTL", 30*time.Minute),
+		ReviewSweepInterval: config.GetEnvDuration(
+			"CBSAGA_REVIEW_SWEEP_INTERVAL",
+			time.Minute,
+		),
+		ChallengeTTL:         config.GetEnvDuration("CBSAGA_AUTH_CHALLENGE_TTL", 5*time.Minute),
+		ChallengeMaxAttempts: config.GetEnvInt("CBSAGA_AUTH_CHALLENGE_MAX_ATTEMPTS", 5),
+		ChallengeSweepInterval: config.GetEnvDuration(
+			"CBSAGA_AUTH_CHALLENGE_SWEEP_INTERVAL",
+			15*time.Second,
+		),
+		MaxRebroadcasts: config.GetEnvInt("CBSAGA_MAX_REBROADCASTS", 3),
 	}
+	// The gateway dials the gRPC server like any other client, by default over loopback.
+	cfg.GatewayTarget = config.GetEnv("CBSAGA_ORCH_GATEWAY_TARGET", loopback(cfg.GRPCAddr))
+
+	hmacKeys, err := config.GetEnvDevDefault(
+		"CBSAGA_AUTH_HMAC_KEYS",
+		cfg.Env,
+		"dev:dev-secret-change-me",
+	)
+	if err != nil {
+		return OrchestratorConfig{}, err
+	}
+	cfg.AuthHMACKeys = config.SplitCSV(hmacKeys)
+
+	// The dev key sealing one-time codes is hex of "dev-challenge-code-key-change-me".
+	codeKey, err := config.GetEnvDevDefault(
+		"CBSAGA_AUTH_CHALLENGE_CODE_KEY",
+		cfg.Env,
+		"6465762d6368616c6c656e67652d636f64652d6b65792d6368616e67652d6d65",
+	)
+	if err != nil {
+		return OrchestratorConfig{}, err
+	}
+
+	cfg.GRPCReflection = config.GetEnvBoolDevDefault("CBSAGA_GRPC_REFLECTION", cfg.Env)
 
 	if cfg.GRPCAddr == "" {
 		return OrchestratorConfig{}, fmt.Errorf("CBSAGA_ORCH_GRPC_ADDR cannot be empty")
 	}
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return OrchestratorConfig{}, fmt.Errorf(
+			"CBSAGA_ORCH_TLS_CERT_FILE and CBSAGA_ORCH_TLS_KEY_FILE must be set together",
+		)
+	}
+	if cfg.TLSClientCAFile != "" && cfg.TLSCertFile == "" {
+		return OrchestratorConfig{}, fmt.Errorf(
+			"CBSAGA_ORCH_TLS_CLIENT_CA_FILE requires CBSAGA_ORCH_TLS_CERT_FILE",
+		)
+	}
+	if cfg.CreateMethodShards <= 0 {
+		return OrchestratorConfig{}, fmt.Errorf(
+			"CBSAGA_RATELIMIT_CREATE_METHOD_SHARDS must be positive",
+		)
+	}
+	if len(cfg.AuthHMACKeys) == 0 {
+		return OrchestratorConfig{}, fmt.Errorf("CBSAGA_AUTH_HMAC_KEYS cannot be empty")
+	}
+	switch cfg.AllowlistMode {
+	case "off", "audit", "enforce":
+	default:
+		return OrchestratorConfig{}, fmt.Errorf(
+			"CBSAGA_ADDRESS_ALLOWLIST_MODE must be one of off, audit, enforce: got %q",
+			cfg.AllowlistMode,
+		)
+	}
+	if cfg.ReviewSLA <= 0 || cfg.ReviewSweepInterval <= 0 {
+		return OrchestratorConfig{}, fmt.Errorf(
+			"CBSAGA_REVIEW_SLA and CBSAGA_REVIEW_SWEEP_INTERVAL must be positive",
+		)
+	}
+	key, err := hex.DecodeString(codeKey)
+	if err != nil || len(key) != orchestrator.ChallengeCodeKeySize {
+		return OrchestratorConfig{}, fmt.Errorf(
+			"CBSAGA_AUTH_CHALLENGE_CODE_KEY must be %d hex-encoded bytes",
+			orchestrator.ChallengeCodeKeySize,
+		)
+	}
+	cfg.ChallengeCodeKey = key
+	if cfg.ChallengeTTL <= 0 || cfg.ChallengeMaxAttempts <= 0 || cfg.ChallengeSweepInterval <= 0 {
+		return OrchestratorConfig{}, fmt.Errorf(
+			"CBSAGA_AUTH_CHALLENGE_TTL, CBSAGA_AUTH_CHALLENGE_MAX_ATTEMPTS and " +
+				"CBSAGA_AUTH_CHALLENGE_SWEEP_INTERVAL must be positive",
+		)
+	}
+	if cfg.MaxRebroadcasts < 0 {
+		return OrchestratorConfig{}, fmt.Errorf("CBSAGA_MAX_REBROADCASTS cannot be negative")
+	}
+	switch cfg.RateLimitBackend {
+	case RateLimitNone, RateLimitMemory, RateLimitPostgres:
+	default:
+		return OrchestratorConfig{}, fmt.Errorf(
+			"CBSAGA_RATELIMIT_BACKEND must be one of none, memory, postgres: got %q",
+			cfg.RateLimitBackend,
+		)
+	}
 
 	return cfg, nil
 }
+
+func (c OrchestratorConfig) TLSEnabled() bool {
+	return c.TLSCertFile != ""
+}
+
+func (c OrchestratorConfig) GatewayEnabled() bool {
+	return c.HTTPAddr != ""
+}
+
+func loopback(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,379 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/health"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/messaging"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/shared/execution"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Execution struct {
+	db     *pgxpool.Pool
+	repo   *repo.Repo
+	log    *logging.Logger
+	r      *kafka.Reader
+	tracer trace.Tracer
+	live   *health.ConsumerLiveness
+	// maxRebroadcasts bounds how often a transaction that left the chain is sent again.
+	maxRebroadcasts int
+}
+
+func NewExecution(
+	db *pgxpool.Pool,
+	log *logging.Logger,
+	brokers []string,
+	groupID string,
+	topic string,
+	maxRebroadcasts int,
+) *Execution {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		GroupID:     groupID,
+		Topic:       topic,
+		MinBytes:    1,
+		MaxBytes:    10e6,
+		MaxWait:     500 * time.Millisecond,
+		StartOffset: kafka.LastOffset,
+	})
+
+	return &Execution{
+		db:              db,
+		repo:            repo.New(),
+		log:             log,
+		r:               reader,
+		tracer:          tracing.Tracer(tracerName),
+		live:            health.NewConsumerLiveness(),
+		maxRebroadcasts: maxRebroadcasts,
+	}
+}
+
+func (ec *Execution) Liveness() *health.ConsumerLiveness {
+	return ec.live
+}
+
+func (ec *Execution) Close() error {
+	return ec.r.Close()
+}
+
+func (ec *Execution) Run(ctx context.Context) (err error) {
+	defer func() { ec.live.Stopped(err) }()
+
+	ec.log.Info("orchestrator execution consumer started")
+
+	for {
+		ec.live.Fetching()
+		m, err := ec.r.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				ec.log.Info("orchestrator execution consumer stopped")
+				return nil
+			}
+			return err
+		}
+		ec.live.Fetched()
+
+		started := time.Now()
+		err = ec.handleMessage(ctx, m)
+		messaging.ObserveMessage("orchestrator-execution", m, started, err)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (ec *Execution) handleMessage(ctx context.Context, m kafka.Message) (err error) {
+	ctx, span := messaging.StartConsumerSpan(
+		ctx, ec.tracer, "orchestrator.HandleExecutionEvent", m,
+	)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	headers := messaging.NewHeaders(m.Headers)
+	traceID, ok := headers.String("trace_id")
+	if !ok || traceID == "" {
+		traceID = "local-trace-id-orchestrator"
+	}
+	ctx = logging.WithTraceID(ctx, traceID)
+
+	eventType, ok := headers.String("event_type")
+	if !ok || eventType == "" {
+		ec.log.WarnContext(ctx, "execution event without event_type, skipping")
+		return ec.r.CommitMessages(ctx, m)
+	}
+
+	switch eventType {
+	case execution.EventTypeWithdrawalBroadcast:
+		return ec.handleResult(ctx, m, eventType, execution.ExecutionStatusBroadcast, traceID)
+	case execution.EventTypeExecutionFailed:
+		return ec.handleResult(ctx, m, eventType, execution.ExecutionStatusFailed, traceID)
+	case execution.EventTypeWithdrawalConfirmed:
+		return ec.handleConfirmation(ctx, m, traceID)
+	case execution.EventTypeWithdrawalReorged:
+		return ec.handleReorg(ctx, m, traceID)
+	default:
+		ec.log.WarnContext(ctx, "unexpected execution event type, skipping",
+			"event_type", eventType,
+		)
+		return ec.r.CommitMessages(ctx, m)
+	}
+}
+
+func (ec *Execution) handleResult(
+	ctx context.Context,
+	m kafka.Message,
+	eventType string,
+	status string,
+	traceID string,
+) error {
+	result := execution.ExecutionResultPayload{}
+	err := messaging.DecodeConnectEnvelopeValid(m.Value, &result)
+	if err != nil {
+		ec.log.ErrorContext(ctx, "invalid execution event, skipping", "err", err)
+		return ec.r.CommitMessages(ctx, m)
+	}
+	if result.Status != status {
+		ec.log.ErrorContext(ctx, "execution event status mismatch, skipping",
+			"event_type", eventType,
+			"status", result.Status,
+		)
+		return ec.r.CommitMessages(ctx, m)
+	}
+	ctx = logging.WithWithdrawalID(ctx, result.WithdrawalID)
+	ctx = logging.WithSagaStep(ctx, orchestrator.SagaStepAwaitingExecution)
+
+	params := repo.ApplyExecutionResultParams{
+		WithdrawalID: result.WithdrawalID,
+		Status:       status,
+		FromAddr:     result.FromAddr,
+		TxHash:       result.TxHash,
+		Reason:       result.Reason,
+		At:           time.Now().UTC(),
+		TraceID:      traceID,
+		Traceparent:  tracing.Traceparent(ctx),
+	}
+	if status == execution.ExecutionStatusFailed {
+		payload, err := codec.EncodeValid(&orchestrator.WithdrawalEventPayload{
+			WithdrawalID: result.WithdrawalID,
+			UserID:       result.UserID,
+			Step:         orchestrator.SagaStepAwaitingExecution,
+			Reason:       result.Reason,
+		})
+		if err != nil {
+			return ec.r.CommitMessages(ctx, m)
+		}
+		params.Outbox = &repo.OutboxEvent{
+			EventType: orchestrator.EventTypeWithdrawalFailed,
+			Payload:   string(payload),
+			RouteKey:  orchestrator.RouteKeyWithdrawalEvt,
+		}
+	}
+
+	tx, err := ec.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	outcome, err := ec.repo.ApplyExecutionResultTx(ctx, tx, params)
+	if err != nil {
+		ec.log.ErrorContext(ctx, "ApplyExecutionResultTx failed", "err", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if err := ec.r.CommitMessages(ctx, m); err != nil {
+		ec.log.ErrorContext(ctx, "CommitMessages failed", "err", err)
+		return err
+	}
+
+	if outcome.Applied {
+		sagaStepDuration.WithLabelValues(orchestrator.SagaStepAwaitingExecution, eventType).
+			Observe(time.Since(outcome.StepStartedAt).Seconds())
+	}
+
+	ec.log.InfoContext(ctx, "execution result applied",
+		"event_type", eventType,
+		"tx_hash", result.TxHash,
+		"applied", outcome.Applied,
+	)
+
+	return nil
+}
+
+func (ec *Execution) handleConfirmation(
+	ctx context.Context,
+	m kafka.Message,
+	traceID string,
+) error {
+	conf := execution.ConfirmationPayload{}
+	err := messaging.DecodeConnectEnvelopeValid(m.Value, &conf)
+	if err != nil {
+		ec.log.ErrorContext(ctx, "invalid confirmation event, skipping", "err", err)
+		return ec.r.CommitMessages(ctx, m)
+	}
+	ctx = logging.WithWithdrawalID(ctx, conf.WithdrawalID)
+	ctx = logging.WithSagaStep(ctx, orchestrator.SagaStepBroadcast)
+
+	now := time.Now().UTC()
+	payload, err := codec.EncodeValid(&orchestrator.WithdrawalCompletedPayload{
+		WithdrawalID:  conf.WithdrawalID,
+		UserID:        conf.UserID,
+		Asset:         conf.Asset,
+		Network:       conf.Network,
+		TxHash:        conf.TxHash,
+		BlockHeight:   conf.BlockHeight,
+		BlockHash:     conf.BlockHash,
+		Confirmations: conf.Confirmations,
+		CompletedAt:   now.Format(time.RFC3339Nano),
+	})
+	if err != nil {
+		return ec.r.CommitMessages(ctx, m)
+	}
+
+	tx, err := ec.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	outcome, err := ec.repo.CompleteWithdrawalTx(ctx, tx, repo.CompleteWithdrawalParams{
+		WithdrawalID:  conf.WithdrawalID,
+		TxHash:        conf.TxHash,
+		BlockHeight:   conf.BlockHeight,
+		BlockHash:     conf.BlockHash,
+		Confirmations: conf.Confirmations,
+		At:            now,
+		TraceID:       traceID,
+		Traceparent:   tracing.Traceparent(ctx),
+		Outbox: repo.OutboxEvent{
+			EventType: orchestrator.EventTypeWithdrawalCompleted,
+			Payload:   string(payload),
+			RouteKey:  orchestrator.RouteKeyWithdrawalEvt,
+		},
+	})
+	if err != nil {
+		ec.log.ErrorContext(ctx, "CompleteWithdrawalTx failed", "err", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if err := ec.r.CommitMessages(ctx, m); err != nil {
+		ec.log.ErrorContext(ctx, "CommitMessages failed", "err", err)
+		return err
+	}
+
+	if outcome.Applied {
+		sagaStepDuration.WithLabelValues(
+			orchestrator.SagaStepBroadcast,
+			execution.EventTypeWithdrawalConfirmed,
+		).Observe(time.Since(outcome.StepStartedAt).Seconds())
+	}
+
+	ec.log.InfoContext(ctx, "withdrawal completed",
+		"tx_hash", conf.TxHash,
+		"block_height", conf.BlockHeight,
+		"confirmations", conf.Confirmations,
+		"applied", outcome.Applied,
+	)
+
+	return nil
+}
+
+func (ec *Execution) handleReorg(ctx context.Context, m kafka.Message, traceID string) error {
+	reorg := execution.ReorgPayload{}
+	err := messaging.DecodeConnectEnvelopeValid(m.Value, &reorg)
+	if err != nil {
+		ec.log.ErrorContext(ctx, "invalid reorg event, skipping", "err", err)
+		return ec.r.CommitMessages(ctx, m)
+	}
+	ctx = logging.WithWithdrawalID(ctx, reorg.WithdrawalID)
+	ctx = logging.WithSagaStep(ctx, orchestrator.SagaStepBroadcast)
+
+	reason := fmt.Sprintf("transaction dropped from the chain after %d rebroadcasts: %s",
+		ec.maxRebroadcasts,
+		reorg.Reason,
+	)
+	payload, err := codec.EncodeValid(&orchestrator.WithdrawalEventPayload{
+		WithdrawalID: reorg.WithdrawalID,
+		UserID:       reorg.UserID,
+		Step:         orchestrator.SagaStepBroadcast,
+		Reason:       &reason,
+	})
+	if err != nil {
+		return ec.r.CommitMessages(ctx, m)
+	}
+
+	tx, err := ec.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	outcome, err := ec.repo.ApplyReorgTx(ctx, tx, repo.ApplyReorgParams{
+		WithdrawalID:    reorg.WithdrawalID,
+		TxHash:          reorg.TxHash,
+		MaxRebroadcasts: ec.maxRebroadcasts,
+		Dropped:         reorg.Dropped,
+		Reason:          reason,
+		At:              time.Now().UTC(),
+		TraceID:         traceID,
+		Traceparent:     tracing.Traceparent(ctx),
+		Failed: repo.OutboxEvent{
+			EventType: orchestrator.EventTypeWithdrawalFailed,
+			Payload:   string(payload),
+			RouteKey:  orchestrator.RouteKeyWithdrawalEvt,
+		},
+	})
+	if err != nil {
+		ec.log.ErrorContext(ctx, "ApplyReorgTx failed", "err", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if err := ec.r.CommitMessages(ctx, m); err != nil {
+		ec.log.ErrorContext(ctx, "CommitMessages failed", "err", err)
+		return err
+	}
+
+	if outcome.Applied {
+		sagaStepDuration.WithLabelValues(
+			orchestrator.SagaStepBroadcast,
+			execution.EventTypeWithdrawalReorged,
+		).Observe(time.Since(outcome.StepStartedAt).Seconds())
+	}
+
+	ec.log.WarnContext(ctx, "withdrawal transaction left the chain",
+		"tx_hash", reorg.TxHash,
+		"reason", reorg.Reason,
+		"dropped", reorg.Dropped,
+		"applied", outcome.Applied,
+		"rebroadcast", outcome.Rebroadcast,
+		"rebroadcasts", outcome.Rebroadcasts,
+	)
+
+	return nil
+}
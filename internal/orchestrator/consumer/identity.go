@@ -7,21 +7,28 @@ import (
 
 	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
 	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/health"
 	"github.com/cicconee/cbsaga/internal/platform/logging"
 	"github.com/cicconee/cbsaga/internal/platform/messaging"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
 	"github.com/cicconee/cbsaga/internal/shared/identity"
 	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
 	"github.com/cicconee/cbsaga/internal/shared/risk"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "github.com/cicconee/cbsaga/internal/orchestrator/consumer"
+
 type Identity struct {
-	db   *pgxpool.Pool
-	repo *repo.Repo
-	log  *logging.Logger
-	r    *kafka.Reader
+	db     *pgxpool.Pool
+	repo   *repo.Repo
+	log    *logging.Logger
+	r      *kafka.Reader
+	tracer trace.Tracer
+	live   *health.ConsumerLiveness
 }
 
 func NewIdentity(
@@ -42,13 +49,19 @@ func NewIdentity(
 	})
 
 	return &Identity{
-		db:   db,
-		repo: repo.New(),
-		log:  log,
-		r:    reader,
+		db:     db,
+		repo:   repo.New(),
+		log:    log,
+		r:      reader,
+		tracer: tracing.Tracer(tracerName),
+		live:   health.NewConsumerLiveness(),
 	}
 }
 
+func (i *Identity) Liveness() *health.ConsumerLiveness {
+	return i.live
+}
+
 func (i *Identity) Close() error {
 	return i.r.Close()
 }
@@ -59,10 +72,13 @@ type IdentityResult struct {
 	Reason       *string `json:"reason,omitempty"`
 }
 
-func (i *Identity) Run(ctx context.Context) error {
+func (i *Identity) Run(ctx context.Context) (err error) {
+	defer func() { i.live.Stopped(err) }()
+
 	i.log.Info("orchestrator identity consumer started")
 
 	for {
+		i.live.Fetching()
 		m, err := i.r.FetchMessage(ctx)
 		if err != nil {
 			if errors.Is(err, context.Canceled) {
@@ -71,19 +87,30 @@ func (i *Identity) Run(ctx context.Context) error {
 			}
 			return err
 		}
+		i.live.Fetched()
 
-		if err := i.handleMessage(ctx, m); err != nil {
+		started := time.Now()
+		err = i.handleMessage(ctx, m)
+		messaging.ObserveMessage("orchestrator-identity", m, started, err)
+		if err != nil {
 			return err
 		}
 	}
 }
 
-func (i *Identity) handleMessage(ctx context.Context, m kafka.Message) error {
+func (i *Identity) handleMessage(ctx context.Context, m kafka.Message) (err error) {
+	ctx, span := messaging.StartConsumerSpan(ctx, i.tracer, "orchestrator.HandleIdentityEvent", m)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
 	headers := messaging.NewHeaders(m.Headers)
 	traceID, ok := headers.String("trace_id")
 	if !ok || traceID == "" {
 		traceID = "local-trace-id-orchestrator"
 	}
+	ctx = logging.WithTraceID(ctx, traceID)
 
 	eventType, ok := headers.String("event_type")
 	if !ok || eventType == "" {
@@ -97,11 +124,13 @@ func (i *Identity) handleMessage(ctx context.Context, m kafka.Message) error {
 	}
 
 	identityEvtPayload := identity.IdentityRequestEvtPayload{}
-	err := messaging.DecodeConnectEnvelopeValid(m.Value, &identityEvtPayload)
+	err = messaging.DecodeConnectEnvelopeValid(m.Value, &identityEvtPayload)
 	if err != nil {
 		// TODO: log, remember that decode also validates the event.
 		return i.r.CommitMessages(ctx, m)
 	}
+	ctx = logging.WithWithdrawalID(ctx, identityEvtPayload.WithdrawalID)
+	ctx = logging.WithSagaStep(ctx, orchestrator.SagaStepIdentityCheck)
 
 	tx, err := i.db.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
@@ -145,21 +174,20 @@ func (i *Identity) handleMessage(ctx context.Context, m kafka.Message) error {
 		return i.r.CommitMessages(ctx, m)
 	}
 
-	if err := i.repo.ApplyIdentityResultTx(ctx, tx, repo.ApplyIdentityResultParams{
+	outcome, err := i.repo.ApplyIdentityResultTx(ctx, tx, repo.ApplyIdentityResultParams{
 		WithdrawalID:      identityEvtPayload.WithdrawalID,
 		UserID:            identityEvtPayload.UserID,
 		IdentityEventType: eventType,
 		Reason:            identityEvtPayload.Reason,
 		UpdatedAt:         time.Now().UTC(),
 		TraceID:           traceID,
+		Traceparent:       tracing.Traceparent(ctx),
 		OutboxEventType:   outboxEventType,
 		OutboxPayload:     string(riskPayload),
 		RouteKey:          routeKey,
-	}); err != nil {
-		i.log.Error("ApplyIdentityResultTx failed",
-			"err", err,
-			"withdrawal_id", identityEvtPayload.WithdrawalID,
-		)
+	})
+	if err != nil {
+		i.log.ErrorContext(ctx, "ApplyIdentityResultTx failed", "err", err)
 		return err
 	}
 
@@ -167,11 +195,12 @@ func (i *Identity) handleMessage(ctx context.Context, m kafka.Message) error {
 		return err
 	}
 
-	i.log.Info("identity result applied",
-		"withdrawal_id", identityEvtPayload.WithdrawalID,
-		"event_type", eventType,
-		"trace_id", traceID,
-	)
+	if outcome.Applied {
+		sagaStepDuration.WithLabelValues(orchestrator.SagaStepIdentityCheck, eventType).
+			Observe(time.Since(outcome.StepStartedAt).Seconds())
+	}
+
+	i.log.InfoContext(ctx, "identity result applied", "event_type", eventType)
 
 	return nil
 }
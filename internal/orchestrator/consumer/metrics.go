@@ -0,0 +1,14 @@
+package consumer
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var sagaStepDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "saga",
+	Name:      "step_duration_seconds",
+	Help:      "Time a saga spent in a step before its result event was applied.",
+	Buckets:   metrics.DurationBuckets,
+}, []string{"step", "result"})
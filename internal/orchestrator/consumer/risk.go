@@ -0,0 +1,214 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/orchestrator/challenge"
+	"github.com/cicconee/cbsaga/internal/orchestrator/repo"
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/health"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/messaging"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/cicconee/cbsaga/internal/shared/risk"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type Risk struct {
+	db         *pgxpool.Pool
+	repo       *repo.Repo
+	log        *logging.Logger
+	r          *kafka.Reader
+	tracer     trace.Tracer
+	live       *health.ConsumerLiveness
+	reviewSLA  time.Duration
+	challenges challenge.Policy
+}
+
+func NewRisk(
+	db *pgxpool.Pool,
+	log *logging.Logger,
+	brokers []string,
+	groupID string,
+	topic string,
+	reviewSLA time.Duration,
+	challenges challenge.Policy,
+) *Risk {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		GroupID:     groupID,
+		Topic:       topic,
+		MinBytes:    1,
+		MaxBytes:    10e6,
+		MaxWait:     500 * time.Millisecond,
+		StartOffset: kafka.LastOffset,
+	})
+
+	return &Risk{
+		db:         db,
+		repo:       repo.New(),
+		log:        log,
+		r:          reader,
+		tracer:     tracing.Tracer(tracerName),
+		live:       health.NewConsumerLiveness(),
+		reviewSLA:  reviewSLA,
+		challenges: challenges,
+	}
+}
+
+func (rc *Risk) Liveness() *health.ConsumerLiveness {
+	return rc.live
+}
+
+func (rc *Risk) Close() error {
+	return rc.r.Close()
+}
+
+func (rc *Risk) Run(ctx context.Context) (err error) {
+	defer func() { rc.live.Stopped(err) }()
+
+	rc.log.Info("orchestrator risk consumer started")
+
+	for {
+		rc.live.Fetching()
+		m, err := rc.r.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				rc.log.Info("orchestrator risk consumer stopped")
+				return nil
+			}
+			return err
+		}
+		rc.live.Fetched()
+
+		started := time.Now()
+		err = rc.handleMessage(ctx, m)
+		messaging.ObserveMessage("orchestrator-risk", m, started, err)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (rc *Risk) handleMessage(ctx context.Context, m kafka.Message) (err error) {
+	ctx, span := messaging.StartConsumerSpan(ctx, rc.tracer, "orchestrator.HandleRiskEvent", m)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	headers := messaging.NewHeaders(m.Headers)
+	traceID, ok := headers.String("trace_id")
+	if !ok || traceID == "" {
+		traceID = "local-trace-id-orchestrator"
+	}
+	ctx = logging.WithTraceID(ctx, traceID)
+
+	eventType, ok := headers.String("event_type")
+	if !ok || eventType == "" {
+		rc.log.WarnContext(ctx, "risk event without event_type, skipping")
+		return rc.r.CommitMessages(ctx, m)
+	}
+
+	// Each risk decision resumes the saga with a different event: approved withdrawals are
+	// announced as approved, reviews as parked in MANUAL_REVIEW, rejections as failed.
+	var outboxEventType string
+	switch eventType {
+	case risk.EventTypeRiskCheckApproved:
+		outboxEventType = orchestrator.EventTypeWithdrawalApproved
+	case risk.EventTypeRiskCheckReview:
+		outboxEventType = orchestrator.EventTypeReviewRequested
+	case risk.EventTypeRiskCheckRejected:
+		outboxEventType = orchestrator.EventTypeWithdrawalFailed
+	default:
+		rc.log.WarnContext(ctx, "unexpected risk event type, skipping", "event_type", eventType)
+		return rc.r.CommitMessages(ctx, m)
+	}
+
+	result := risk.RiskCheckResultPayload{}
+	err = messaging.DecodeConnectEnvelopeValid(m.Value, &result)
+	if err != nil {
+		rc.log.ErrorContext(ctx, "invalid risk event, skipping", "err", err)
+		return rc.r.CommitMessages(ctx, m)
+	}
+	ctx = logging.WithWithdrawalID(ctx, result.WithdrawalID)
+	ctx = logging.WithSagaStep(ctx, orchestrator.SagaStepRiskCheck)
+
+	payload, err := codec.EncodeValid(&orchestrator.WithdrawalEventPayload{
+		WithdrawalID: result.WithdrawalID,
+		UserID:       result.UserID,
+		Step:         orchestrator.SagaStepRiskCheck,
+		Reason:       result.Reason,
+	})
+	if err != nil {
+		return rc.r.CommitMessages(ctx, m)
+	}
+
+	tx, err := rc.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	now := time.Now().UTC()
+	params := repo.ApplyRiskResultParams{
+		WithdrawalID:   result.WithdrawalID,
+		RiskEventType:  eventType,
+		Score:          result.Score,
+		Reason:         result.Reason,
+		TriggeredRules: result.TriggeredRules,
+		RulesVersion:   result.RulesVersion,
+		ReviewDueAt:    now.Add(rc.reviewSLA),
+		UpdatedAt:      now,
+		TraceID:        traceID,
+		Traceparent:    tracing.Traceparent(ctx),
+		Outbox: repo.OutboxEvent{
+			EventType: outboxEventType,
+			Payload:   string(payload),
+			RouteKey:  orchestrator.RouteKeyWithdrawalEvt,
+		},
+	}
+	// Large approvals wait for the user's one-time code; the repo decides whether this one is.
+	if eventType == risk.EventTypeRiskCheckApproved {
+		ch, err := rc.challenges.Params(result.WithdrawalID, result.UserID, now)
+		if err != nil {
+			return err
+		}
+		params.Challenge = &ch
+	}
+
+	outcome, err := rc.repo.ApplyRiskResultTx(ctx, tx, params)
+	if err != nil {
+		rc.log.ErrorContext(ctx, "ApplyRiskResultTx failed", "err", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if err := rc.r.CommitMessages(ctx, m); err != nil {
+		rc.log.ErrorContext(ctx, "CommitMessages failed", "err", err)
+		return err
+	}
+
+	if outcome.Applied {
+		sagaStepDuration.WithLabelValues(orchestrator.SagaStepRiskCheck, eventType).
+			Observe(time.Since(outcome.StepStartedAt).Seconds())
+	}
+
+	rc.log.InfoContext(ctx, "risk result applied",
+		"event_type", eventType,
+		"score", result.Score,
+		"rules", result.TriggeredRules,
+		"next_step", outcome.Step,
+	)
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package gateway
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ClientTLS configures how the gateway connects to a TLS-enabled gRPC server. CertFile and
+// KeyFile are only needed when the server requires client certificates.
+type ClientTLS struct {
+	CAFile     string
+	CertFile   string
+	KeyFile    string
+	ServerName string
+}
+
+// DialOptions builds the gateway's dial options. A nil tlsCfg dials plaintext.
+func DialOptions(tlsCfg *ClientTLS) ([]grpc.DialOption, error) {
+	opts := []grpc.DialOption{grpc.WithStatsHandler(otelgrpc.NewClientHandler())}
+
+	if tlsCfg == nil {
+		return append(opts, grpc.WithTransportCredentials(insecure.NewCredentials())), nil
+	}
+
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: tlsCfg.ServerName,
+	}
+
+	if tlsCfg.CAFile != "" {
+		pem, err := os.ReadFile(tlsCfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", tlsCfg.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if tlsCfg.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCfg.CertFile, tlsCfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load gateway client key pair: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return append(opts, grpc.WithTransportCredentials(credentials.NewTLS(cfg))), nil
+}
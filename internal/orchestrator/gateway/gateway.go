@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/textproto"
+	"strconv"
+
+	orchestratorv1 "github.com/cicconee/cbsaga/gen/orchestrator/v1"
+	"github.com/cicconee/cbsaga/internal/orchestrator/api"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// New returns an HTTP/JSON handler that proxies to the orchestrator gRPC server at target. It
+// dials over the network rather than calling the service in process so requests pass through
+// the same interceptors (auth, rate limiting, tracing) as native gRPC calls.
+func New(
+	ctx context.Context,
+	target string,
+	dialOpts []grpc.DialOption,
+) (http.Handler, error) {
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+	)
+
+	err := orchestratorv1.RegisterOrchestratorServiceHandlerFromEndpoint(ctx, mux, target, dialOpts)
+	if err != nil {
+		return nil, err
+	}
+	err = orchestratorv1.RegisterAssetAdminServiceHandlerFromEndpoint(ctx, mux, target, dialOpts)
+	if err != nil {
+		return nil, err
+	}
+	err = orchestratorv1.RegisterAddressBookServiceHandlerFromEndpoint(ctx, mux, target, dialOpts)
+	if err != nil {
+		return nil, err
+	}
+	err = orchestratorv1.RegisterReviewServiceHandlerFromEndpoint(ctx, mux, target, dialOpts)
+	if err != nil {
+		return nil, err
+	}
+	err = orchestratorv1.RegisterApprovalServiceHandlerFromEndpoint(ctx, mux, target, dialOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return mux, nil
+}
+
+func headerMatcher(key string) (string, bool) {
+	if textproto.CanonicalMIMEHeaderKey(key) == "Idempotency-Key" {
+		return api.MetadataIdempotencyKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// errorHandler writes the default status body ({code, message, details}) and, for rate-limited
+// calls, a Retry-After header derived from the RetryInfo detail.
+func errorHandler(
+	ctx context.Context,
+	mux *runtime.ServeMux,
+	m runtime.Marshaler,
+	w http.ResponseWriter,
+	r *http.Request,
+	err error,
+) {
+	for _, d := range status.Convert(err).Details() {
+		info, ok := d.(*errdetails.RetryInfo)
+		if !ok {
+			continue
+		}
+		secs := int(info.GetRetryDelay().AsDuration().Seconds() + 0.999)
+		w.Header().Set("Retry-After", strconv.Itoa(max(secs, 1)))
+	}
+
+	runtime.DefaultHTTPErrorHandler(ctx, mux, m, w, r, err)
+}
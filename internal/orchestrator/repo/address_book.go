@@ -0,0 +1,162 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrWithdrawalAddressExists = errors.New("withdrawal address already registered")
+
+type WithdrawalAddress struct {
+	ID        string
+	UserID    string
+	Network   string
+	Address   string
+	Label     string
+	UsableAt  time.Time
+	CreatedAt time.Time
+}
+
+const withdrawalAddressColumns = `
+	id,
+	user_id,
+	network,
+	address,
+	label,
+	usable_at,
+	created_at
+`
+
+func scanWithdrawalAddress(row pgx.Row) (WithdrawalAddress, error) {
+	var a WithdrawalAddress
+	err := row.Scan(
+		&a.ID,
+		&a.UserID,
+		&a.Network,
+		&a.Address,
+		&a.Label,
+		&a.UsableAt,
+		&a.CreatedAt,
+	)
+	return a, err
+}
+
+type AddWithdrawalAddressParams struct {
+	ID       string
+	UserID   string
+	Network  string
+	Address  string
+	Label    string
+	UsableAt time.Time
+}
+
+func (r *Repo) AddWithdrawalAddress(
+	ctx context.Context,
+	db postgres.DBTX,
+	p AddWithdrawalAddressParams,
+) (WithdrawalAddress, error) {
+	a, err := scanWithdrawalAddress(db.QueryRow(ctx, `
+		INSERT INTO orchestrator.withdrawal_addresses (
+			id,
+			user_id,
+			network,
+			address,
+			label,
+			usable_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+withdrawalAddressColumns,
+		p.ID,
+		p.UserID,
+		p.Network,
+		p.Address,
+		p.Label,
+		p.UsableAt,
+	))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return WithdrawalAddress{}, ErrWithdrawalAddressExists
+		}
+		return WithdrawalAddress{}, err
+	}
+	return a, nil
+}
+
+func (r *Repo) ListWithdrawalAddresses(
+	ctx context.Context,
+	db postgres.DBTX,
+	userID string,
+) ([]WithdrawalAddress, error) {
+	rows, err := db.Query(ctx, `
+		SELECT `+withdrawalAddressColumns+`
+		FROM orchestrator.withdrawal_addresses
+		WHERE
+			user_id = $1
+			AND removed_at IS NULL
+		ORDER BY created_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []WithdrawalAddress
+	for rows.Next() {
+		a, err := scanWithdrawalAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// GetActiveWithdrawalAddress returns the user's non-removed entry for address on network, or
+// pgx.ErrNoRows.
+func (r *Repo) GetActiveWithdrawalAddress(
+	ctx context.Context,
+	db postgres.DBTX,
+	userID string,
+	network string,
+	address string,
+) (WithdrawalAddress, error) {
+	return scanWithdrawalAddress(db.QueryRow(ctx, `
+		SELECT `+withdrawalAddressColumns+`
+		FROM orchestrator.withdrawal_addresses
+		WHERE
+			user_id = $1
+			AND network = $2
+			AND address = $3
+			AND removed_at IS NULL
+	`, userID, network, address))
+}
+
+// RemoveWithdrawalAddress soft-deletes the entry. It returns pgx.ErrNoRows if the entry does not
+// exist, belongs to another user or is already removed.
+func (r *Repo) RemoveWithdrawalAddress(
+	ctx context.Context,
+	db postgres.DBTX,
+	userID string,
+	id string,
+	now time.Time,
+) error {
+	tag, err := db.Exec(ctx, `
+		UPDATE orchestrator.withdrawal_addresses
+		SET removed_at = $3
+		WHERE
+			id = $1
+			AND user_id = $2
+			AND removed_at IS NULL
+	`, id, userID, now)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
@@ -0,0 +1,483 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/jackc/pgx/v5"
+)
+
+type ApprovalPolicy struct {
+	PolicyID          string
+	Asset             string
+	MinAmountMinor    int64
+	RequiredApprovals int
+	ApproverRole      string
+	UpdatedBy         string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+const approvalPolicyColumns = `
+	p.policy_id,
+	p.asset,
+	p.min_amount_minor,
+	p.required_approvals,
+	p.approver_role,
+	p.updated_by,
+	p.created_at,
+	p.updated_at
+`
+
+func scanApprovalPolicy(row pgx.Row) (ApprovalPolicy, error) {
+	var p ApprovalPolicy
+	err := row.Scan(
+		&p.PolicyID,
+		&p.Asset,
+		&p.MinAmountMinor,
+		&p.RequiredApprovals,
+		&p.ApproverRole,
+		&p.UpdatedBy,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	return p, err
+}
+
+// matchApprovalPolicyTx returns the policy with the highest threshold the withdrawal reaches,
+// or pgx.ErrNoRows when none applies.
+func (r *Repo) matchApprovalPolicyTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+) (ApprovalPolicy, error) {
+	p, err := scanApprovalPolicy(tx.QueryRow(ctx, `
+		SELECT `+approvalPolicyColumns+`
+		FROM orchestrator.withdrawals w
+		JOIN orchestrator.approval_policies p
+			ON p.asset = w.asset AND w.amount_minor >= p.min_amount_minor
+		WHERE w.id = $1
+		ORDER BY p.min_amount_minor DESC
+		LIMIT 1
+	`, withdrawalID))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return ApprovalPolicy{}, fmt.Errorf("match approval policy: %w", err)
+	}
+	return p, err
+}
+
+func (r *Repo) ListApprovalPolicies(
+	ctx context.Context,
+	db postgres.DBTX,
+) ([]ApprovalPolicy, error) {
+	rows, err := db.Query(ctx, `
+		SELECT `+approvalPolicyColumns+`
+		FROM orchestrator.approval_policies p
+		ORDER BY p.asset, p.min_amount_minor
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ApprovalPolicy
+	for rows.Next() {
+		p, err := scanApprovalPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+type UpsertApprovalPolicyParams struct {
+	Asset             string
+	MinAmountMinor    int64
+	RequiredApprovals int
+	ApproverRole      string
+	UpdatedBy         string
+}
+
+// UpsertApprovalPolicy creates the policy for (asset, min_amount_minor) or replaces what it
+// requires.
+func (r *Repo) UpsertApprovalPolicy(
+	ctx context.Context,
+	db postgres.DBTX,
+	p UpsertApprovalPolicyParams,
+) (ApprovalPolicy, error) {
+	return scanApprovalPolicy(db.QueryRow(ctx, `
+		INSERT INTO orchestrator.approval_policies AS p (
+			asset,
+			min_amount_minor,
+			required_approvals,
+			approver_role,
+			updated_by
+		)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (asset, min_amount_minor) DO UPDATE SET
+			required_approvals = EXCLUDED.required_approvals,
+			approver_role = EXCLUDED.approver_role,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = now()
+		RETURNING `+approvalPolicyColumns,
+		p.Asset,
+		p.MinAmountMinor,
+		p.RequiredApprovals,
+		p.ApproverRole,
+		p.UpdatedBy,
+	))
+}
+
+// DeleteApprovalPolicy removes a policy. Requests already opened under it are unaffected.
+func (r *Repo) DeleteApprovalPolicy(
+	ctx context.Context,
+	db postgres.DBTX,
+	policyID string,
+) (ApprovalPolicy, error) {
+	return scanApprovalPolicy(db.QueryRow(ctx, `
+		DELETE FROM orchestrator.approval_policies p
+		WHERE p.policy_id = $1
+		RETURNING `+approvalPolicyColumns,
+		policyID,
+	))
+}
+
+type ApprovalRequest struct {
+	WithdrawalID      string
+	UserID            string
+	RequestedBy       *string
+	Asset             string
+	AmountMinor       int64
+	DestinationAddr   string
+	PolicyID          string
+	RequiredApprovals int
+	ApproverRole      string
+	Status            string
+	DecidedAt         *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+const approvalRequestColumns = `
+	a.withdrawal_id,
+	w.user_id,
+	w.requested_by,
+	w.asset,
+	w.amount_minor,
+	w.destination_addr,
+	a.policy_id,
+	a.required_approvals,
+	a.approver_role,
+	a.status,
+	a.decided_at,
+	a.created_at,
+	a.updated_at
+`
+
+func scanApprovalRequest(row pgx.Row) (ApprovalRequest, error) {
+	var a ApprovalRequest
+	err := row.Scan(
+		&a.WithdrawalID,
+		&a.UserID,
+		&a.RequestedBy,
+		&a.Asset,
+		&a.AmountMinor,
+		&a.DestinationAddr,
+		&a.PolicyID,
+		&a.RequiredApprovals,
+		&a.ApproverRole,
+		&a.Status,
+		&a.DecidedAt,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	return a, err
+}
+
+func (r *Repo) insertApprovalRequestTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	policy ApprovalPolicy,
+	at time.Time,
+) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO orchestrator.approval_requests (
+			withdrawal_id,
+			policy_id,
+			required_approvals,
+			approver_role,
+			status,
+			created_at,
+			updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (withdrawal_id) DO NOTHING
+	`,
+		withdrawalID,
+		policy.PolicyID,
+		policy.RequiredApprovals,
+		policy.ApproverRole,
+		orchestrator.ApprovalStatusPending,
+		at,
+	)
+	if err != nil {
+		return fmt.Errorf("insert approval request: %w", err)
+	}
+	return nil
+}
+
+func (r *Repo) GetApprovalRequest(
+	ctx context.Context,
+	db postgres.DBTX,
+	withdrawalID string,
+) (ApprovalRequest, error) {
+	return scanApprovalRequest(db.QueryRow(ctx, `
+		SELECT `+approvalRequestColumns+`
+		FROM orchestrator.approval_requests a
+		JOIN orchestrator.withdrawals w ON w.id = a.withdrawal_id
+		WHERE a.withdrawal_id = $1
+	`, withdrawalID))
+}
+
+// LockApprovalRequestTx reads the request and holds its row lock until tx ends, which
+// serializes the votes on it.
+func (r *Repo) LockApprovalRequestTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+) (ApprovalRequest, error) {
+	return scanApprovalRequest(tx.QueryRow(ctx, `
+		SELECT `+approvalRequestColumns+`
+		FROM orchestrator.approval_requests a
+		JOIN orchestrator.withdrawals w ON w.id = a.withdrawal_id
+		WHERE a.withdrawal_id = $1
+		FOR UPDATE OF a
+	`, withdrawalID))
+}
+
+// ListPendingApprovalRequests returns up to limit open requests, oldest first. A non-nil
+// roles keeps only requests one of those roles may vote on.
+func (r *Repo) ListPendingApprovalRequests(
+	ctx context.Context,
+	db postgres.DBTX,
+	roles []string,
+	limit int,
+) ([]ApprovalRequest, error) {
+	rows, err := db.Query(ctx, `
+		SELECT `+approvalRequestColumns+`
+		FROM orchestrator.approval_requests a
+		JOIN orchestrator.withdrawals w ON w.id = a.withdrawal_id
+		WHERE
+			a.status = 'PENDING'
+			AND ($1::text[] IS NULL OR a.approver_role = ANY($1))
+		ORDER BY a.created_at ASC, a.withdrawal_id ASC
+		LIMIT $2
+	`, roles, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ApprovalRequest
+	for rows.Next() {
+		a, err := scanApprovalRequest(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+type ApprovalVote struct {
+	WithdrawalID string
+	Approver     string
+	Decision     string // APPROVE | REJECT
+	Roles        []string
+	Notes        *string
+	CreatedAt    time.Time
+}
+
+func (r *Repo) ListApprovalVotes(
+	ctx context.Context,
+	db postgres.DBTX,
+	withdrawalID string,
+) ([]ApprovalVote, error) {
+	rows, err := db.Query(ctx, `
+		SELECT withdrawal_id, approver, decision, roles, notes, created_at
+		FROM orchestrator.approval_votes
+		WHERE withdrawal_id = $1
+		ORDER BY created_at ASC, approver ASC
+	`, withdrawalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ApprovalVote
+	for rows.Next() {
+		var v ApprovalVote
+		err := rows.Scan(
+			&v.WithdrawalID,
+			&v.Approver,
+			&v.Decision,
+			&v.Roles,
+			&v.Notes,
+			&v.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repo) InsertApprovalVoteTx(ctx context.Context, tx pgx.Tx, v ApprovalVote) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO orchestrator.approval_votes (
+			withdrawal_id,
+			approver,
+			decision,
+			roles,
+			notes,
+			created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, v.WithdrawalID, v.Approver, v.Decision, v.Roles, v.Notes, v.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert approval vote: %w", err)
+	}
+	return nil
+}
+
+type ResolveApprovalParams struct {
+	WithdrawalID string
+	Status       string // APPROVED | REJECTED
+	// Reason is recorded on the withdrawal when it is rejected.
+	Reason      string
+	At          time.Time
+	TraceID     string
+	Traceparent *string
+	Outbox      OutboxEvent
+}
+
+type ResolveApprovalOutcome struct {
+	Applied bool
+	// Stale is set when the saga had already left APPROVAL; the request is closed as STALE and
+	// nothing is emitted.
+	Stale         bool
+	StepStartedAt time.Time
+}
+
+// ResolveApprovalTx closes an open request and resumes the saga from APPROVAL: approved
+// withdrawals await execution, rejected ones fail.
+func (r *Repo) ResolveApprovalTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p ResolveApprovalParams,
+) (ResolveApprovalOutcome, error) {
+	t := SagaTransition{
+		WithdrawalID: p.WithdrawalID,
+		From:         orchestrator.SagaStepApproval,
+		To:           orchestrator.SagaStepAwaitingExecution,
+		State:        orchestrator.SagaStateInProgress,
+		At:           p.At,
+	}
+	want := orchestrator.EventTypeWithdrawalApproved
+	switch p.Status {
+	case orchestrator.ApprovalStatusApproved:
+	case orchestrator.ApprovalStatusRejected:
+		t.To = orchestrator.SagaStepFailed
+		t.State = orchestrator.SagaStateFailed
+		want = orchestrator.EventTypeWithdrawalFailed
+	default:
+		return ResolveApprovalOutcome{}, fmt.Errorf("approval: invalid status %q", p.Status)
+	}
+	if p.Outbox.EventType != want {
+		return ResolveApprovalOutcome{},
+			fmt.Errorf("approval: invalid outbox event type: %s", p.Outbox.EventType)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE orchestrator.approval_requests
+		SET
+			status = $2,
+			decided_at = $3,
+			updated_at = $3
+		WHERE
+			withdrawal_id = $1
+			AND status = 'PENDING'
+	`, p.WithdrawalID, p.Status, p.At)
+	if err != nil {
+		return ResolveApprovalOutcome{}, fmt.Errorf("update approval request: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ResolveApprovalOutcome{}, nil
+	}
+
+	stepStartedAt, ok, err := r.advanceSagaTx(ctx, tx, t)
+	if err != nil {
+		return ResolveApprovalOutcome{}, err
+	}
+	if !ok {
+		if err := r.closeStaleApprovalTx(ctx, tx, p.WithdrawalID, p.At); err != nil {
+			return ResolveApprovalOutcome{}, err
+		}
+		return ResolveApprovalOutcome{Stale: true}, nil
+	}
+
+	outbox := []OutboxEvent{p.Outbox}
+	if p.Status == orchestrator.ApprovalStatusRejected {
+		if err := r.failWithdrawalTx(ctx, tx, p.WithdrawalID, p.Reason, p.At); err != nil {
+			return ResolveApprovalOutcome{}, err
+		}
+	} else {
+		cmd, err := r.executionCommandTx(ctx, tx, p.WithdrawalID)
+		if err != nil {
+			return ResolveApprovalOutcome{}, err
+		}
+		outbox = append(outbox, cmd)
+	}
+
+	for _, e := range outbox {
+		err = r.insertOutboxTx(ctx, tx, OutboxParams{
+			WithdrawalID: p.WithdrawalID,
+			Event:        e,
+			TraceID:      p.TraceID,
+			Traceparent:  p.Traceparent,
+		})
+		if err != nil {
+			return ResolveApprovalOutcome{}, err
+		}
+	}
+
+	return ResolveApprovalOutcome{Applied: true, StepStartedAt: stepStartedAt}, nil
+}
+
+// closeStaleApprovalTx overrides the resolution just written with STALE: the saga moved on
+// without the approval, so the votes took no effect.
+func (r *Repo) closeStaleApprovalTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	at time.Time,
+) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE orchestrator.approval_requests
+		SET
+			status = 'STALE',
+			updated_at = $2
+		WHERE withdrawal_id = $1
+	`, withdrawalID, at)
+	if err != nil {
+		return fmt.Errorf("close stale approval request: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type Asset struct {
+	Asset          string
+	Network        string
+	Decimals       int32
+	MinAmountMinor int64
+	MaxAmountMinor int64
+	// ChallengeThresholdMinor is the smallest amount that needs a one-time code; 0 never does.
+	ChallengeThresholdMinor int64
+	// Confirmations is the depth at which a withdrawal's transaction is final.
+	Confirmations int64
+	Status        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+const assetColumns = `
+	asset,
+	network,
+	decimals,
+	min_amount_minor,
+	max_amount_minor,
+	challenge_threshold_minor,
+	confirmations,
+	status,
+	created_at,
+	updated_at
+`
+
+func scanAsset(row pgx.Row) (Asset, error) {
+	var a Asset
+	err := row.Scan(
+		&a.Asset,
+		&a.Network,
+		&a.Decimals,
+		&a.MinAmountMinor,
+		&a.MaxAmountMinor,
+		&a.ChallengeThresholdMinor,
+		&a.Confirmations,
+		&a.Status,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	return a, err
+}
+
+func (r *Repo) GetAsset(ctx context.Context, db postgres.DBTX, asset string) (Asset, error) {
+	return scanAsset(db.QueryRow(ctx, `
+		SELECT `+assetColumns+`
+		FROM orchestrator.assets
+		WHERE asset = $1
+	`, asset))
+}
+
+func (r *Repo) ListAssets(ctx context.Context, db postgres.DBTX) ([]Asset, error) {
+	rows, err := db.Query(ctx, `
+		SELECT `+assetColumns+`
+		FROM orchestrator.assets
+		ORDER BY asset
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assets []Asset
+	for rows.Next() {
+		a, err := scanAsset(rows)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, a)
+	}
+	return assets, rows.Err()
+}
+
+// ErrAssetNetworkChanged is returned when an upsert names another network for an existing asset.
+var ErrAssetNetworkChanged = errors.New("asset network cannot change")
+
+type UpsertAssetParams struct {
+	Asset                   string
+	Network                 string
+	Decimals                int32
+	MinAmountMinor          int64
+	MaxAmountMinor          int64
+	ChallengeThresholdMinor int64
+	Confirmations           int64
+	Status                  string
+}
+
+// UpsertAsset inserts the asset with p.Status or updates its definition. An existing asset keeps
+// its status; that only changes through SetAssetStatus. Its network never changes: withdrawals in
+// flight were validated for it and are signed for whatever network the asset names.
+func (r *Repo) UpsertAsset(
+	ctx context.Context,
+	db postgres.DBTX,
+	p UpsertAssetParams,
+) (Asset, error) {
+	a, err := scanAsset(db.QueryRow(ctx, `
+		INSERT INTO orchestrator.assets (
+			asset,
+			network,
+			decimals,
+			min_amount_minor,
+			max_amount_minor,
+			challenge_threshold_minor,
+			confirmations,
+			status
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (asset) DO UPDATE SET
+			decimals = EXCLUDED.decimals,
+			min_amount_minor = EXCLUDED.min_amount_minor,
+			max_amount_minor = EXCLUDED.max_amount_minor,
+			challenge_threshold_minor = EXCLUDED.challenge_threshold_minor,
+			confirmations = EXCLUDED.confirmations,
+			updated_at = now()
+		WHERE orchestrator.assets.network = EXCLUDED.network
+		RETURNING `+assetColumns,
+		p.Asset,
+		p.Network,
+		p.Decimals,
+		p.MinAmountMinor,
+		p.MaxAmountMinor,
+		p.ChallengeThresholdMinor,
+		p.Confirmations,
+		p.Status,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Asset{}, ErrAssetNetworkChanged
+	}
+	return a, err
+}
+
+func (r *Repo) SetAssetStatus(
+	ctx context.Context,
+	db postgres.DBTX,
+	asset string,
+	status string,
+) (Asset, error) {
+	return scanAsset(db.QueryRow(ctx, `
+		UPDATE orchestrator.assets
+		SET
+			status = $2,
+			updated_at = now()
+		WHERE asset = $1
+		RETURNING `+assetColumns,
+		asset,
+		status,
+	))
+}
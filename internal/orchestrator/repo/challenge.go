@@ -0,0 +1,310 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/jackc/pgx/v5"
+)
+
+// ChallengeParams is a one-time code challenge issued in place of approving a withdrawal whose
+// amount reaches its asset's challenge threshold. Outbox is the notification carrying the code.
+type ChallengeParams struct {
+	CodeHash    string
+	ExpiresAt   time.Time
+	MaxAttempts int
+	Outbox      OutboxEvent
+}
+
+type AuthChallenge struct {
+	WithdrawalID string
+	UserID       string
+	CodeHash     string
+	Status       string
+	Attempts     int
+	MaxAttempts  int
+	ExpiresAt    time.Time
+	DecidedAt    *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+const authChallengeColumns = `
+	c.withdrawal_id,
+	w.user_id,
+	c.code_hash,
+	c.status,
+	c.attempts,
+	c.max_attempts,
+	c.expires_at,
+	c.decided_at,
+	c.created_at,
+	c.updated_at
+`
+
+func scanAuthChallenge(row pgx.Row) (AuthChallenge, error) {
+	var c AuthChallenge
+	err := row.Scan(
+		&c.WithdrawalID,
+		&c.UserID,
+		&c.CodeHash,
+		&c.Status,
+		&c.Attempts,
+		&c.MaxAttempts,
+		&c.ExpiresAt,
+		&c.DecidedAt,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	return c, err
+}
+
+func (r *Repo) insertChallengeTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	ch *ChallengeParams,
+	at time.Time,
+) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO orchestrator.auth_challenges (
+			withdrawal_id,
+			code_hash,
+			status,
+			max_attempts,
+			expires_at,
+			created_at,
+			updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (withdrawal_id) DO NOTHING
+	`,
+		withdrawalID,
+		ch.CodeHash,
+		orchestrator.ChallengeStatusPending,
+		ch.MaxAttempts,
+		ch.ExpiresAt,
+		at,
+	)
+	if err != nil {
+		return fmt.Errorf("insert auth challenge: %w", err)
+	}
+	return nil
+}
+
+// LockChallengeTx reads the withdrawal's challenge and holds its row lock until tx ends.
+func (r *Repo) LockChallengeTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+) (AuthChallenge, error) {
+	return scanAuthChallenge(tx.QueryRow(ctx, `
+		SELECT `+authChallengeColumns+`
+		FROM orchestrator.auth_challenges c
+		JOIN orchestrator.withdrawals w ON w.id = c.withdrawal_id
+		WHERE c.withdrawal_id = $1
+		FOR UPDATE OF c
+	`, withdrawalID))
+}
+
+// RecordChallengeAttemptTx counts a wrong code against an open challenge and returns the new
+// attempt count.
+func (r *Repo) RecordChallengeAttemptTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	at time.Time,
+) (int, error) {
+	var attempts int
+	err := tx.QueryRow(ctx, `
+		UPDATE orchestrator.auth_challenges
+		SET
+			attempts = attempts + 1,
+			updated_at = $2
+		WHERE
+			withdrawal_id = $1
+			AND status = 'PENDING'
+		RETURNING attempts
+	`, withdrawalID, at).Scan(&attempts)
+	if err != nil {
+		return 0, fmt.Errorf("record challenge attempt: %w", err)
+	}
+	return attempts, nil
+}
+
+// ListDueChallengesTx locks up to limit open challenges that have expired. Rows locked by
+// another sweeper are skipped.
+func (r *Repo) ListDueChallengesTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	now time.Time,
+	limit int,
+) ([]AuthChallenge, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+authChallengeColumns+`
+		FROM orchestrator.auth_challenges c
+		JOIN orchestrator.withdrawals w ON w.id = c.withdrawal_id
+		WHERE
+			c.status = 'PENDING'
+			AND c.expires_at <= $1
+		ORDER BY c.expires_at ASC, c.withdrawal_id ASC
+		LIMIT $2
+		FOR UPDATE OF c SKIP LOCKED
+	`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []AuthChallenge
+	for rows.Next() {
+		c, err := scanAuthChallenge(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+type ResolveChallengeParams struct {
+	WithdrawalID string
+	Status       string // CONFIRMED | FAILED | EXPIRED
+	// Reason is recorded on the withdrawal when the challenge fails.
+	Reason      string
+	At          time.Time
+	TraceID     string
+	Traceparent *string
+	Outbox      OutboxEvent
+}
+
+type ResolveChallengeOutcome struct {
+	Applied bool
+	// Stale is set when the saga had already left AUTH_CHALLENGE; the challenge was closed as
+	// STALE and nothing else changed.
+	Stale         bool
+	StepStartedAt time.Time
+	// Step is the step the saga moved to.
+	Step string
+}
+
+// ResolveChallengeTx closes an open challenge and resumes the saga from AUTH_CHALLENGE:
+// confirmed withdrawals await execution, or operator approval where a policy calls for it,
+// failed and expired ones fail the withdrawal.
+func (r *Repo) ResolveChallengeTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p ResolveChallengeParams,
+) (ResolveChallengeOutcome, error) {
+	t := SagaTransition{
+		WithdrawalID: p.WithdrawalID,
+		From:         orchestrator.SagaStepAuthChallenge,
+		To:           orchestrator.SagaStepAwaitingExecution,
+		State:        orchestrator.SagaStateInProgress,
+		At:           p.At,
+	}
+	want := orchestrator.EventTypeWithdrawalApproved
+	switch p.Status {
+	case orchestrator.ChallengeStatusConfirmed:
+	case orchestrator.ChallengeStatusFailed, orchestrator.ChallengeStatusExpired:
+		t.To = orchestrator.SagaStepFailed
+		t.State = orchestrator.SagaStateFailed
+		want = orchestrator.EventTypeWithdrawalFailed
+	default:
+		return ResolveChallengeOutcome{}, fmt.Errorf("auth challenge: invalid status %q", p.Status)
+	}
+	if p.Outbox.EventType != want {
+		return ResolveChallengeOutcome{},
+			fmt.Errorf("auth challenge: invalid outbox event type: %s", p.Outbox.EventType)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE orchestrator.auth_challenges
+		SET
+			status = $2,
+			decided_at = $3,
+			updated_at = $3
+		WHERE
+			withdrawal_id = $1
+			AND status = 'PENDING'
+	`, p.WithdrawalID, p.Status, p.At)
+	if err != nil {
+		return ResolveChallengeOutcome{}, fmt.Errorf("update auth challenge: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ResolveChallengeOutcome{}, nil
+	}
+
+	var next approvedStep
+	if p.Status == orchestrator.ChallengeStatusConfirmed {
+		next, err = r.approvedStepTx(ctx, tx, p.WithdrawalID, nil)
+		if err != nil {
+			return ResolveChallengeOutcome{}, err
+		}
+		t.To = next.Step
+	}
+
+	stepStartedAt, ok, err := r.advanceSagaTx(ctx, tx, t)
+	if err != nil {
+		return ResolveChallengeOutcome{}, err
+	}
+	if !ok {
+		if err := r.closeStaleChallengeTx(ctx, tx, p.WithdrawalID, p.At); err != nil {
+			return ResolveChallengeOutcome{}, err
+		}
+		return ResolveChallengeOutcome{Stale: true}, nil
+	}
+
+	outbox := []OutboxEvent{p.Outbox}
+	if p.Status == orchestrator.ChallengeStatusConfirmed {
+		outbox, err = r.enterApprovedStepTx(ctx, tx, p.WithdrawalID, next, p.Outbox, nil, p.At)
+		if err != nil {
+			return ResolveChallengeOutcome{}, err
+		}
+	} else {
+		if err := r.failWithdrawalTx(ctx, tx, p.WithdrawalID, p.Reason, p.At); err != nil {
+			return ResolveChallengeOutcome{}, err
+		}
+	}
+
+	for _, e := range outbox {
+		err = r.insertOutboxTx(ctx, tx, OutboxParams{
+			WithdrawalID: p.WithdrawalID,
+			Event:        e,
+			TraceID:      p.TraceID,
+			Traceparent:  p.Traceparent,
+		})
+		if err != nil {
+			return ResolveChallengeOutcome{}, err
+		}
+	}
+
+	return ResolveChallengeOutcome{
+		Applied:       true,
+		StepStartedAt: stepStartedAt,
+		Step:          t.To,
+	}, nil
+}
+
+// closeStaleChallengeTx overrides the status just written with STALE: the saga moved on without
+// the challenge, so resolving it took no effect.
+func (r *Repo) closeStaleChallengeTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	at time.Time,
+) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE orchestrator.auth_challenges
+		SET
+			status = 'STALE',
+			updated_at = $2
+		WHERE withdrawal_id = $1
+	`, withdrawalID, at)
+	if err != nil {
+		return fmt.Errorf("close stale auth challenge: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,359 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/shared/execution"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/jackc/pgx/v5"
+)
+
+// executionCommandTx builds the command asking the execution service to send the withdrawal's
+// funds. It is emitted alongside WithdrawalApproved whenever a saga enters AWAITING_EXECUTION.
+func (r *Repo) executionCommandTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+) (OutboxEvent, error) {
+	cmd := execution.ExecuteWithdrawalPayload{WithdrawalID: withdrawalID}
+	err := tx.QueryRow(ctx, `
+		SELECT w.user_id, w.asset, a.network, w.amount_minor, w.destination_addr, a.confirmations
+		FROM orchestrator.withdrawals w
+		JOIN orchestrator.assets a ON a.asset = w.asset
+		WHERE w.id = $1
+	`, withdrawalID).Scan(
+		&cmd.UserID,
+		&cmd.Asset,
+		&cmd.Network,
+		&cmd.AmountMinor,
+		&cmd.DestinationAddr,
+		&cmd.Confirmations,
+	)
+	if err != nil {
+		return OutboxEvent{}, fmt.Errorf("load execution command: %w", err)
+	}
+
+	payload, err := codec.EncodeValid(&cmd)
+	if err != nil {
+		return OutboxEvent{}, err
+	}
+	return OutboxEvent{
+		EventType: execution.EventTypeExecuteWithdrawal,
+		Payload:   string(payload),
+		RouteKey:  execution.RouteKeyExecutionCmd,
+	}, nil
+}
+
+type ApplyExecutionResultParams struct {
+	WithdrawalID string
+	Status       string // BROADCAST | FAILED
+	FromAddr     string
+	TxHash       string
+	Reason       *string
+	At           time.Time
+	TraceID      string
+	Traceparent  *string
+	// Outbox is only emitted for failures; a broadcast is announced by the execution service.
+	Outbox *OutboxEvent
+}
+
+func (p *ApplyExecutionResultParams) validate() error {
+	switch p.Status {
+	case execution.ExecutionStatusBroadcast:
+		if p.TxHash == "" {
+			return errors.New("execution event: missing tx_hash")
+		}
+	case execution.ExecutionStatusFailed:
+		if p.Outbox == nil || p.Outbox.EventType != orchestrator.EventTypeWithdrawalFailed {
+			return errors.New("execution event: failure needs a WithdrawalFailed outbox event")
+		}
+	default:
+		return fmt.Errorf("execution event: invalid status %q", p.Status)
+	}
+	return nil
+}
+
+type ApplyExecutionResultOutcome struct {
+	Applied       bool
+	StepStartedAt time.Time
+}
+
+// ApplyExecutionResultTx moves the saga out of AWAITING_EXECUTION: broadcast withdrawals record
+// their transaction and wait in BROADCAST, failed ones fail.
+func (r *Repo) ApplyExecutionResultTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p ApplyExecutionResultParams,
+) (ApplyExecutionResultOutcome, error) {
+	if err := p.validate(); err != nil {
+		return ApplyExecutionResultOutcome{}, err
+	}
+
+	t := SagaTransition{
+		WithdrawalID: p.WithdrawalID,
+		From:         orchestrator.SagaStepAwaitingExecution,
+		To:           orchestrator.SagaStepBroadcast,
+		State:        orchestrator.SagaStateInProgress,
+		At:           p.At,
+	}
+	if p.Status == execution.ExecutionStatusFailed {
+		t.To = orchestrator.SagaStepFailed
+		t.State = orchestrator.SagaStateFailed
+	}
+
+	stepStartedAt, ok, err := r.advanceSagaTx(ctx, tx, t)
+	if err != nil {
+		return ApplyExecutionResultOutcome{}, err
+	}
+	if !ok {
+		// Already processed. Treat as a no-op.
+		return ApplyExecutionResultOutcome{}, nil
+	}
+
+	if p.Status == execution.ExecutionStatusFailed {
+		reason := "execution failed"
+		if p.Reason != nil {
+			reason = *p.Reason
+		}
+		if err := r.failWithdrawalTx(ctx, tx, p.WithdrawalID, reason, p.At); err != nil {
+			return ApplyExecutionResultOutcome{}, err
+		}
+		err = r.insertOutboxTx(ctx, tx, OutboxParams{
+			WithdrawalID: p.WithdrawalID,
+			Event:        *p.Outbox,
+			TraceID:      p.TraceID,
+			Traceparent:  p.Traceparent,
+		})
+		if err != nil {
+			return ApplyExecutionResultOutcome{}, err
+		}
+		return ApplyExecutionResultOutcome{Applied: true, StepStartedAt: stepStartedAt}, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE orchestrator.withdrawals
+		SET
+			tx_hash = $2,
+			from_addr = $3,
+			broadcast_at = $4,
+			updated_at = $4
+		WHERE id = $1
+	`, p.WithdrawalID, p.TxHash, p.FromAddr, p.At)
+	if err != nil {
+		return ApplyExecutionResultOutcome{}, fmt.Errorf("record broadcast: %w", err)
+	}
+
+	return ApplyExecutionResultOutcome{Applied: true, StepStartedAt: stepStartedAt}, nil
+}
+
+type CompleteWithdrawalParams struct {
+	WithdrawalID  string
+	TxHash        string
+	BlockHeight   int64
+	BlockHash     string
+	Confirmations int64
+	At            time.Time
+	TraceID       string
+	Traceparent   *string
+	Outbox        OutboxEvent
+}
+
+type CompleteWithdrawalOutcome struct {
+	Applied       bool
+	StepStartedAt time.Time
+}
+
+// CompleteWithdrawalTx is the saga's final transition: the withdrawal's transaction reached its
+// confirmation depth, so the saga leaves BROADCAST, the withdrawal is marked COMPLETED with the
+// block it landed in, and p.Outbox announces it. A confirmation for another transaction, or one
+// that arrives after the saga left BROADCAST, is a no-op.
+func (r *Repo) CompleteWithdrawalTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p CompleteWithdrawalParams,
+) (CompleteWithdrawalOutcome, error) {
+	if p.Outbox.EventType != orchestrator.EventTypeWithdrawalCompleted {
+		return CompleteWithdrawalOutcome{}, errors.New(
+			"complete withdrawal: needs a WithdrawalCompleted outbox event",
+		)
+	}
+
+	var txHash *string
+	err := tx.QueryRow(ctx, `
+		SELECT tx_hash
+		FROM orchestrator.withdrawals
+		WHERE id = $1
+	`, p.WithdrawalID).Scan(&txHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return CompleteWithdrawalOutcome{}, nil
+	}
+	if err != nil {
+		return CompleteWithdrawalOutcome{}, fmt.Errorf("load tx_hash: %w", err)
+	}
+	if txHash == nil || *txHash != p.TxHash {
+		return CompleteWithdrawalOutcome{}, nil
+	}
+
+	stepStartedAt, ok, err := r.advanceSagaTx(ctx, tx, SagaTransition{
+		WithdrawalID: p.WithdrawalID,
+		From:         orchestrator.SagaStepBroadcast,
+		To:           orchestrator.SagaStepCompleted,
+		State:        orchestrator.SagaStateCompleted,
+		At:           p.At,
+	})
+	if err != nil {
+		return CompleteWithdrawalOutcome{}, err
+	}
+	if !ok {
+		// Already processed. Treat as a no-op.
+		return CompleteWithdrawalOutcome{}, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE orchestrator.withdrawals
+		SET
+			status = $2,
+			block_height = $3,
+			block_hash = $4,
+			confirmations = $5,
+			completed_at = $6,
+			updated_at = $6
+		WHERE id = $1
+	`,
+		p.WithdrawalID,
+		orchestrator.WithdrawalStatusCompleted,
+		p.BlockHeight,
+		p.BlockHash,
+		p.Confirmations,
+		p.At,
+	)
+	if err != nil {
+		return CompleteWithdrawalOutcome{}, fmt.Errorf("complete withdrawal: %w", err)
+	}
+
+	err = r.insertOutboxTx(ctx, tx, OutboxParams{
+		WithdrawalID: p.WithdrawalID,
+		Event:        p.Outbox,
+		TraceID:      p.TraceID,
+		Traceparent:  p.Traceparent,
+	})
+	if err != nil {
+		return CompleteWithdrawalOutcome{}, err
+	}
+
+	return CompleteWithdrawalOutcome{Applied: true, StepStartedAt: stepStartedAt}, nil
+}
+
+type ApplyReorgParams struct {
+	WithdrawalID string
+	TxHash       string
+	// MaxRebroadcasts is how often a transaction that left the chain is sent again before the
+	// withdrawal fails with Reason and Failed is emitted. It only applies to dropped
+	// transactions; one back in the mempool can still be mined and is always sent again.
+	MaxRebroadcasts int
+	Dropped         bool
+	Reason          string
+	At              time.Time
+	TraceID         string
+	Traceparent     *string
+	Failed          OutboxEvent
+}
+
+type ApplyReorgOutcome struct {
+	Applied       bool
+	Rebroadcast   bool
+	Rebroadcasts  int
+	StepStartedAt time.Time
+}
+
+// ApplyReorgTx handles a broadcast transaction that left the chain. While rebroadcasts remain,
+// or while the transaction is still in the mempool, the saga goes back to AWAITING_EXECUTION and
+// the execution command is emitted again; the execution service resubmits the same signed
+// transaction and tracks it again. Only a dropped transaction fails the withdrawal once its
+// rebroadcasts are used up.
+func (r *Repo) ApplyReorgTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p ApplyReorgParams,
+) (ApplyReorgOutcome, error) {
+	var rebroadcasts int
+	err := tx.QueryRow(ctx, `
+		SELECT rebroadcasts
+		FROM orchestrator.withdrawals
+		WHERE id = $1 AND tx_hash = $2
+	`, p.WithdrawalID, p.TxHash).Scan(&rebroadcasts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Not the transaction this withdrawal broadcast. Treat as a no-op.
+		return ApplyReorgOutcome{}, nil
+	}
+	if err != nil {
+		return ApplyReorgOutcome{}, fmt.Errorf("load rebroadcasts: %w", err)
+	}
+
+	rebroadcast := !p.Dropped || rebroadcasts < p.MaxRebroadcasts
+	t := SagaTransition{
+		WithdrawalID: p.WithdrawalID,
+		From:         orchestrator.SagaStepBroadcast,
+		To:           orchestrator.SagaStepAwaitingExecution,
+		State:        orchestrator.SagaStateInProgress,
+		At:           p.At,
+	}
+	if !rebroadcast {
+		t.To = orchestrator.SagaStepFailed
+		t.State = orchestrator.SagaStateFailed
+	}
+
+	stepStartedAt, ok, err := r.advanceSagaTx(ctx, tx, t)
+	if err != nil {
+		return ApplyReorgOutcome{}, err
+	}
+	if !ok {
+		// Already processed. Treat as a no-op.
+		return ApplyReorgOutcome{}, nil
+	}
+
+	event := p.Failed
+	if rebroadcast {
+		rebroadcasts++
+		_, err = tx.Exec(ctx, `
+			UPDATE orchestrator.withdrawals
+			SET
+				rebroadcasts = $2,
+				block_height = NULL,
+				block_hash = NULL,
+				confirmations = NULL,
+				updated_at = $3
+			WHERE id = $1
+		`, p.WithdrawalID, rebroadcasts, p.At)
+		if err != nil {
+			return ApplyReorgOutcome{}, fmt.Errorf("record rebroadcast: %w", err)
+		}
+		event, err = r.executionCommandTx(ctx, tx, p.WithdrawalID)
+		if err != nil {
+			return ApplyReorgOutcome{}, err
+		}
+	} else if err := r.failWithdrawalTx(ctx, tx, p.WithdrawalID, p.Reason, p.At); err != nil {
+		return ApplyReorgOutcome{}, err
+	}
+
+	err = r.insertOutboxTx(ctx, tx, OutboxParams{
+		WithdrawalID: p.WithdrawalID,
+		Event:        event,
+		TraceID:      p.TraceID,
+		Traceparent:  p.Traceparent,
+	})
+	if err != nil {
+		return ApplyReorgOutcome{}, err
+	}
+
+	return ApplyReorgOutcome{
+		Applied:       true,
+		Rebroadcast:   rebroadcast,
+		Rebroadcasts:  rebroadcasts,
+		StepStartedAt: stepStartedAt,
+	}, nil
+}
@@ -18,6 +18,7 @@ type ApplyIdentityResultParams struct {
 	IdentityEventType string // VERIFIED | REJECTED
 	Reason            *string
 	TraceID           string
+	Traceparent       *string
 	UpdatedAt         time.Time
 	OutboxEventType   string
 	OutboxPayload     string
@@ -46,13 +47,18 @@ func (i *ApplyIdentityResultParams) validate() error {
 	return nil
 }
 
+type ApplyIdentityResultOutcome struct {
+	Applied       bool
+	StepStartedAt time.Time
+}
+
 func (r *Repo) ApplyIdentityResultTx(
 	ctx context.Context,
 	tx pgx.Tx,
 	p ApplyIdentityResultParams,
-) error {
+) (ApplyIdentityResultOutcome, error) {
 	if err := p.validate(); err != nil {
-		return err
+		return ApplyIdentityResultOutcome{}, err
 	}
 
 	_, err := tx.Exec(ctx, `
@@ -80,11 +86,15 @@ func (r *Repo) ApplyIdentityResultTx(
 		p.UpdatedAt,
 	)
 	if err != nil {
-		return fmt.Errorf("update withdrawals based on identity result: %w", err)
+		return ApplyIdentityResultOutcome{},
+			fmt.Errorf("update withdrawals based on identity result: %w", err)
 	}
 
-	tag, err := tx.Exec(ctx, `
-		UPDATE orchestrator.saga_instances
+	// The FROM snapshot still holds the pre-update row, so RETURNING prev.updated_at yields the
+	// time the saga entered IDENTITY_CHECK.
+	var stepStartedAt time.Time
+	err = tx.QueryRow(ctx, `
+		UPDATE orchestrator.saga_instances s
 		SET
 			current_step = CASE
 				WHEN $2 = 'IdentityVerified' THEN 'RISK_CHECK'
@@ -95,23 +105,26 @@ func (r *Repo) ApplyIdentityResultTx(
 				ELSE 'FAILED'
 			END,
 			updated_at = $3
+		FROM orchestrator.saga_instances prev
 		WHERE
-			withdrawal_id = $1
-			AND current_step = 'IDENTITY_CHECK'
-			AND state IN ('STARTED','IN_PROGRESS')
+			prev.saga_id = s.saga_id
+			AND s.withdrawal_id = $1
+			AND s.current_step = 'IDENTITY_CHECK'
+			AND s.state IN ('STARTED','IN_PROGRESS')
+		RETURNING prev.updated_at
 	`,
 		p.WithdrawalID,
 		p.IdentityEventType,
 		p.UpdatedAt,
-	)
-	if err != nil {
-		return err
-	}
-	if tag.RowsAffected() != 1 {
+	).Scan(&stepStartedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
 		// Already processed. Treat as a no-op.
 		// TODO: Possibly early if for some reason saga still hasn't updated from initiating the
 		// withdrawal from the gRPC request. Maybe use inbox pattern and drain if delivered early?
-		return nil
+		return ApplyIdentityResultOutcome{}, nil
+	}
+	if err != nil {
+		return ApplyIdentityResultOutcome{}, err
 	}
 
 	_, err = tx.Exec(ctx, `
@@ -122,7 +135,8 @@ func (r *Repo) ApplyIdentityResultTx(
 			event_type,
 			payload_json,
 			trace_id,
-			route_key
+			route_key,
+			traceparent
 		)
 		VALUES (
 			gen_random_uuid(),
@@ -131,7 +145,8 @@ func (r *Repo) ApplyIdentityResultTx(
 			$3,
 			$4,
 			$5,
-			$6
+			$6,
+			$7
 		)
 	`,
 		orchestrator.AggregateTypeWithdrawal,
@@ -140,10 +155,11 @@ func (r *Repo) ApplyIdentityResultTx(
 		p.OutboxPayload,
 		p.TraceID,
 		p.RouteKey,
+		p.Traceparent,
 	)
 	if err != nil {
-		return fmt.Errorf("insert outbox WithdrawalFailed: %w", err)
+		return ApplyIdentityResultOutcome{}, fmt.Errorf("insert outbox WithdrawalFailed: %w", err)
 	}
 
-	return nil
+	return ApplyIdentityResultOutcome{Applied: true, StepStartedAt: stepStartedAt}, nil
 }
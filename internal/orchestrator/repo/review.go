@@ -0,0 +1,396 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/jackc/pgx/v5"
+)
+
+type ManualReview struct {
+	WithdrawalID    string
+	UserID          string
+	Asset           string
+	AmountMinor     int64
+	DestinationAddr string
+	Status          string
+	RiskScore       int
+	RiskReason      *string
+	TriggeredRules  []string
+	RulesVersion    string
+	ClaimedBy       *string
+	ClaimedAt       *time.Time
+	DecidedBy       *string
+	DecidedAt       *time.Time
+	Notes           *string
+	DueAt           time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+const manualReviewColumns = `
+	r.withdrawal_id,
+	w.user_id,
+	w.asset,
+	w.amount_minor,
+	w.destination_addr,
+	r.status,
+	r.risk_score,
+	r.risk_reason,
+	r.triggered_rules,
+	r.rules_version,
+	r.claimed_by,
+	r.claimed_at,
+	r.decided_by,
+	r.decided_at,
+	r.notes,
+	r.due_at,
+	r.created_at,
+	r.updated_at
+`
+
+func scanManualReview(row pgx.Row) (ManualReview, error) {
+	var m ManualReview
+	var rules []byte
+	err := row.Scan(
+		&m.WithdrawalID,
+		&m.UserID,
+		&m.Asset,
+		&m.AmountMinor,
+		&m.DestinationAddr,
+		&m.Status,
+		&m.RiskScore,
+		&m.RiskReason,
+		&rules,
+		&m.RulesVersion,
+		&m.ClaimedBy,
+		&m.ClaimedAt,
+		&m.DecidedBy,
+		&m.DecidedAt,
+		&m.Notes,
+		&m.DueAt,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		return ManualReview{}, err
+	}
+	if err := json.Unmarshal(rules, &m.TriggeredRules); err != nil {
+		return ManualReview{}, fmt.Errorf("decode triggered_rules: %w", err)
+	}
+	return m, nil
+}
+
+func (r *Repo) GetManualReview(
+	ctx context.Context,
+	db postgres.DBTX,
+	withdrawalID string,
+) (ManualReview, error) {
+	return scanManualReview(db.QueryRow(ctx, `
+		SELECT `+manualReviewColumns+`
+		FROM orchestrator.manual_reviews r
+		JOIN orchestrator.withdrawals w ON w.id = r.withdrawal_id
+		WHERE r.withdrawal_id = $1
+	`, withdrawalID))
+}
+
+// LockManualReviewTx reads the review and holds its row lock until tx ends.
+func (r *Repo) LockManualReviewTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+) (ManualReview, error) {
+	return scanManualReview(tx.QueryRow(ctx, `
+		SELECT `+manualReviewColumns+`
+		FROM orchestrator.manual_reviews r
+		JOIN orchestrator.withdrawals w ON w.id = r.withdrawal_id
+		WHERE r.withdrawal_id = $1
+		FOR UPDATE OF r
+	`, withdrawalID))
+}
+
+// ReviewCursor is the position after the last review of a page, in (due_at, withdrawal_id)
+// order.
+type ReviewCursor struct {
+	DueAt        time.Time
+	WithdrawalID string
+}
+
+type ListManualReviewsParams struct {
+	Statuses []string
+	After    *ReviewCursor
+	Limit    int
+}
+
+func (r *Repo) ListManualReviews(
+	ctx context.Context,
+	db postgres.DBTX,
+	p ListManualReviewsParams,
+) ([]ManualReview, error) {
+	var afterDue *time.Time
+	var afterID *string
+	if p.After != nil {
+		afterDue, afterID = &p.After.DueAt, &p.After.WithdrawalID
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT `+manualReviewColumns+`
+		FROM orchestrator.manual_reviews r
+		JOIN orchestrator.withdrawals w ON w.id = r.withdrawal_id
+		WHERE
+			r.status = ANY($1)
+			AND ($2::timestamptz IS NULL OR (r.due_at, r.withdrawal_id) > ($2, $3::uuid))
+		ORDER BY r.due_at ASC, r.withdrawal_id ASC
+		LIMIT $4
+	`, p.Statuses, afterDue, afterID, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ManualReview
+	for rows.Next() {
+		m, err := scanManualReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// ListDueManualReviewsTx locks up to limit open reviews whose SLA has passed. Rows locked by
+// another sweeper are skipped.
+func (r *Repo) ListDueManualReviewsTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	now time.Time,
+	limit int,
+) ([]ManualReview, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT `+manualReviewColumns+`
+		FROM orchestrator.manual_reviews r
+		JOIN orchestrator.withdrawals w ON w.id = r.withdrawal_id
+		WHERE
+			r.status IN ('PENDING', 'CLAIMED')
+			AND r.due_at <= $1
+		ORDER BY r.due_at ASC, r.withdrawal_id ASC
+		LIMIT $2
+		FOR UPDATE OF r SKIP LOCKED
+	`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []ManualReview
+	for rows.Next() {
+		m, err := scanManualReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+type ClaimManualReviewParams struct {
+	WithdrawalID string
+	Operator     string
+	Now          time.Time
+	// StaleBefore lets a claim older than this be taken over by another operator.
+	StaleBefore time.Time
+}
+
+// ClaimManualReviewTx assigns the review to p.Operator. It returns pgx.ErrNoRows when the
+// review is not claimable; the caller inspects the locked row to say why.
+func (r *Repo) ClaimManualReviewTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p ClaimManualReviewParams,
+) error {
+	tag, err := tx.Exec(ctx, `
+		UPDATE orchestrator.manual_reviews
+		SET
+			status = 'CLAIMED',
+			claimed_by = $2,
+			claimed_at = CASE WHEN claimed_by = $2 THEN claimed_at ELSE $3 END,
+			updated_at = $3
+		WHERE
+			withdrawal_id = $1
+			AND (
+				status = 'PENDING'
+				OR (status = 'CLAIMED' AND (claimed_by = $2 OR claimed_at < $4))
+			)
+	`, p.WithdrawalID, p.Operator, p.Now, p.StaleBefore)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+type ResolveManualReviewParams struct {
+	WithdrawalID string
+	Status       string // APPROVED | REJECTED | EXPIRED
+	DecidedBy    *string
+	Notes        *string
+	At           time.Time
+	TraceID      string
+	Traceparent  *string
+	Outbox       OutboxEvent
+	// Challenge, when set, replaces Outbox for an approval that needs the user's confirmation.
+	Challenge *ChallengeParams
+}
+
+type ResolveManualReviewOutcome struct {
+	Applied bool
+	// Stale is set when the saga had already left MANUAL_REVIEW; the review was closed as STALE
+	// and nothing else changed.
+	Stale         bool
+	StepStartedAt time.Time
+	// Step is the step the saga moved to.
+	Step string
+}
+
+// ResolveManualReviewTx closes an open review and resumes the saga from MANUAL_REVIEW: approved
+// withdrawals await execution, or first the user's confirmation and operator approvals where the
+// amount calls for them, anything else fails the withdrawal. The outbox event carries the saga
+// forward exactly like a service result would.
+func (r *Repo) ResolveManualReviewTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p ResolveManualReviewParams,
+) (ResolveManualReviewOutcome, error) {
+	t := SagaTransition{
+		WithdrawalID: p.WithdrawalID,
+		From:         orchestrator.SagaStepManualReview,
+		To:           orchestrator.SagaStepAwaitingExecution,
+		State:        orchestrator.SagaStateInProgress,
+		At:           p.At,
+	}
+	want := orchestrator.EventTypeWithdrawalApproved
+	switch p.Status {
+	case orchestrator.ReviewStatusApproved:
+	case orchestrator.ReviewStatusRejected, orchestrator.ReviewStatusExpired:
+		t.To = orchestrator.SagaStepFailed
+		t.State = orchestrator.SagaStateFailed
+		want = orchestrator.EventTypeWithdrawalFailed
+	default:
+		return ResolveManualReviewOutcome{},
+			fmt.Errorf("manual review: invalid status %q", p.Status)
+	}
+	if p.Outbox.EventType != want {
+		return ResolveManualReviewOutcome{},
+			fmt.Errorf("manual review: invalid outbox event type: %s", p.Outbox.EventType)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE orchestrator.manual_reviews
+		SET
+			status = $2,
+			decided_by = $3,
+			decided_at = $4,
+			notes = $5,
+			updated_at = $4
+		WHERE
+			withdrawal_id = $1
+			AND status IN ('PENDING', 'CLAIMED')
+	`, p.WithdrawalID, p.Status, p.DecidedBy, p.At, p.Notes)
+	if err != nil {
+		return ResolveManualReviewOutcome{}, fmt.Errorf("update manual review: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ResolveManualReviewOutcome{}, nil
+	}
+
+	var next approvedStep
+	if p.Status == orchestrator.ReviewStatusApproved {
+		next, err = r.approvedStepTx(ctx, tx, p.WithdrawalID, p.Challenge)
+		if err != nil {
+			return ResolveManualReviewOutcome{}, err
+		}
+		t.To = next.Step
+	}
+
+	stepStartedAt, ok, err := r.advanceSagaTx(ctx, tx, t)
+	if err != nil {
+		return ResolveManualReviewOutcome{}, err
+	}
+	if !ok {
+		if err := r.closeStaleReviewTx(ctx, tx, p.WithdrawalID, p.At); err != nil {
+			return ResolveManualReviewOutcome{}, err
+		}
+		return ResolveManualReviewOutcome{Stale: true}, nil
+	}
+
+	outbox := []OutboxEvent{p.Outbox}
+	if p.Status == orchestrator.ReviewStatusApproved {
+		outbox, err = r.enterApprovedStepTx(ctx, tx,
+			p.WithdrawalID,
+			next,
+			p.Outbox,
+			p.Challenge,
+			p.At,
+		)
+		if err != nil {
+			return ResolveManualReviewOutcome{}, err
+		}
+	}
+
+	if p.Status != orchestrator.ReviewStatusApproved {
+		reason := "manual review rejected"
+		if p.Status == orchestrator.ReviewStatusExpired {
+			reason = "manual review SLA expired"
+		}
+		if err := r.failWithdrawalTx(ctx, tx, p.WithdrawalID, reason, p.At); err != nil {
+			return ResolveManualReviewOutcome{}, err
+		}
+	}
+
+	for _, e := range outbox {
+		err = r.insertOutboxTx(ctx, tx, OutboxParams{
+			WithdrawalID: p.WithdrawalID,
+			Event:        e,
+			TraceID:      p.TraceID,
+			Traceparent:  p.Traceparent,
+		})
+		if err != nil {
+			return ResolveManualReviewOutcome{}, err
+		}
+	}
+
+	return ResolveManualReviewOutcome{
+		Applied:       true,
+		StepStartedAt: stepStartedAt,
+		Step:          t.To,
+	}, nil
+}
+
+// closeStaleReviewTx overrides the decision just written with STALE: the saga moved on without
+// the review, so the decision took no effect.
+func (r *Repo) closeStaleReviewTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	at time.Time,
+) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE orchestrator.manual_reviews
+		SET
+			status = 'STALE',
+			decided_by = NULL,
+			updated_at = $2
+		WHERE withdrawal_id = $1
+	`, withdrawalID, at)
+	if err != nil {
+		return fmt.Errorf("close stale manual review: %w", err)
+	}
+	return nil
+}
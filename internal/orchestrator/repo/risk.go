@@ -0,0 +1,186 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/cicconee/cbsaga/internal/shared/risk"
+	"github.com/jackc/pgx/v5"
+)
+
+type ApplyRiskResultParams struct {
+	WithdrawalID   string
+	RiskEventType  string // RiskCheckApproved | RiskCheckReview | RiskCheckRejected
+	Score          int
+	Reason         *string
+	TriggeredRules []string
+	RulesVersion   string
+	ReviewDueAt    time.Time
+	UpdatedAt      time.Time
+	TraceID        string
+	Traceparent    *string
+	Outbox         OutboxEvent
+	// Challenge, when set, replaces Outbox for an approval that needs the user's confirmation.
+	Challenge *ChallengeParams
+}
+
+func (p *ApplyRiskResultParams) validate() error {
+	if p.WithdrawalID == "" {
+		return errors.New("risk event: missing withdrawal_id")
+	}
+	if p.TraceID == "" {
+		return errors.New("risk event: missing trace_id")
+	}
+
+	var want string
+	switch p.RiskEventType {
+	case risk.EventTypeRiskCheckApproved:
+		want = orchestrator.EventTypeWithdrawalApproved
+	case risk.EventTypeRiskCheckReview:
+		want = orchestrator.EventTypeReviewRequested
+		if p.ReviewDueAt.IsZero() {
+			return errors.New("risk event: review requires a due time")
+		}
+	case risk.EventTypeRiskCheckRejected:
+		want = orchestrator.EventTypeWithdrawalFailed
+	default:
+		return fmt.Errorf("risk event: invalid event type %q", p.RiskEventType)
+	}
+	if p.Outbox.EventType != want {
+		return fmt.Errorf("risk event: invalid outbox event type: %s", p.Outbox.EventType)
+	}
+
+	return nil
+}
+
+type ApplyRiskResultOutcome struct {
+	Applied       bool
+	StepStartedAt time.Time
+	// Step is the step the saga moved to.
+	Step string
+}
+
+// ApplyRiskResultTx moves the saga out of RISK_CHECK: approved withdrawals await execution, or
+// first the user's confirmation and operator approvals where the amount calls for them,
+// rejected ones fail, and review decisions park the saga in MANUAL_REVIEW with a queue entry
+// due at p.ReviewDueAt.
+func (r *Repo) ApplyRiskResultTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p ApplyRiskResultParams,
+) (ApplyRiskResultOutcome, error) {
+	if err := p.validate(); err != nil {
+		return ApplyRiskResultOutcome{}, err
+	}
+
+	t := SagaTransition{
+		WithdrawalID: p.WithdrawalID,
+		From:         orchestrator.SagaStepRiskCheck,
+		To:           orchestrator.SagaStepAwaitingExecution,
+		State:        orchestrator.SagaStateInProgress,
+		At:           p.UpdatedAt,
+	}
+	var next approvedStep
+	var err error
+	switch p.RiskEventType {
+	case risk.EventTypeRiskCheckApproved:
+		next, err = r.approvedStepTx(ctx, tx, p.WithdrawalID, p.Challenge)
+		if err != nil {
+			return ApplyRiskResultOutcome{}, err
+		}
+		t.To = next.Step
+	case risk.EventTypeRiskCheckReview:
+		t.To = orchestrator.SagaStepManualReview
+	case risk.EventTypeRiskCheckRejected:
+		t.To = orchestrator.SagaStepFailed
+		t.State = orchestrator.SagaStateFailed
+	}
+
+	stepStartedAt, ok, err := r.advanceSagaTx(ctx, tx, t)
+	if err != nil {
+		return ApplyRiskResultOutcome{}, err
+	}
+	if !ok {
+		// Already processed. Treat as a no-op.
+		return ApplyRiskResultOutcome{}, nil
+	}
+
+	outbox := []OutboxEvent{p.Outbox}
+	switch p.RiskEventType {
+	case risk.EventTypeRiskCheckApproved:
+		outbox, err = r.enterApprovedStepTx(ctx, tx,
+			p.WithdrawalID,
+			next,
+			p.Outbox,
+			p.Challenge,
+			p.UpdatedAt,
+		)
+		if err != nil {
+			return ApplyRiskResultOutcome{}, err
+		}
+
+	case risk.EventTypeRiskCheckRejected:
+		reason := "risk rejected"
+		if p.Reason != nil {
+			reason = "risk rejected: " + *p.Reason
+		}
+		if err := r.failWithdrawalTx(ctx, tx, p.WithdrawalID, reason, p.UpdatedAt); err != nil {
+			return ApplyRiskResultOutcome{}, err
+		}
+
+	case risk.EventTypeRiskCheckReview:
+		rules, err := json.Marshal(p.TriggeredRules)
+		if err != nil {
+			return ApplyRiskResultOutcome{}, err
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO orchestrator.manual_reviews (
+				withdrawal_id,
+				status,
+				risk_score,
+				risk_reason,
+				triggered_rules,
+				rules_version,
+				due_at,
+				created_at,
+				updated_at
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+			ON CONFLICT (withdrawal_id) DO NOTHING
+		`,
+			p.WithdrawalID,
+			orchestrator.ReviewStatusPending,
+			p.Score,
+			p.Reason,
+			string(rules),
+			p.RulesVersion,
+			p.ReviewDueAt,
+			p.UpdatedAt,
+		)
+		if err != nil {
+			return ApplyRiskResultOutcome{}, fmt.Errorf("insert manual review: %w", err)
+		}
+	}
+
+	for _, e := range outbox {
+		err = r.insertOutboxTx(ctx, tx, OutboxParams{
+			WithdrawalID: p.WithdrawalID,
+			Event:        e,
+			TraceID:      p.TraceID,
+			Traceparent:  p.Traceparent,
+		})
+		if err != nil {
+			return ApplyRiskResultOutcome{}, err
+		}
+	}
+
+	return ApplyRiskResultOutcome{
+		Applied:       true,
+		StepStartedAt: stepStartedAt,
+		Step:          t.To,
+	}, nil
+}
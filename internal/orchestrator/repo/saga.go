@@ -0,0 +1,202 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/jackc/pgx/v5"
+)
+
+type SagaTransition struct {
+	WithdrawalID string
+	From         string
+	To           string
+	State        string
+	At           time.Time
+}
+
+// advanceSagaTx moves the saga out of t.From and returns when it entered that step. ok is false
+// when the saga is no longer in t.From, i.e. the transition was already applied.
+func (r *Repo) advanceSagaTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	t SagaTransition,
+) (stepStartedAt time.Time, ok bool, err error) {
+	// The FROM snapshot still holds the pre-update row, so RETURNING prev.updated_at yields the
+	// time the saga entered t.From.
+	err = tx.QueryRow(ctx, `
+		UPDATE orchestrator.saga_instances s
+		SET
+			current_step = $3,
+			state = $4,
+			updated_at = $5
+		FROM orchestrator.saga_instances prev
+		WHERE
+			prev.saga_id = s.saga_id
+			AND s.withdrawal_id = $1
+			AND s.current_step = $2
+			AND s.state IN ('STARTED','IN_PROGRESS')
+		RETURNING prev.updated_at
+	`,
+		t.WithdrawalID,
+		t.From,
+		t.To,
+		t.State,
+		t.At,
+	).Scan(&stepStartedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("advance saga from %s: %w", t.From, err)
+	}
+
+	return stepStartedAt, true, nil
+}
+
+// approvedStep is where a saga goes once its checks have passed: AUTH_CHALLENGE when the user
+// must confirm with a one-time code, APPROVAL when operators must sign off under Policy, and
+// AWAITING_EXECUTION otherwise.
+type approvedStep struct {
+	Step   string
+	Policy *ApprovalPolicy
+}
+
+// approvedStepTx picks the next step for an approved withdrawal. ch is nil once the challenge
+// is behind the saga (or when the caller cannot issue one), which skips the threshold check.
+func (r *Repo) approvedStepTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	ch *ChallengeParams,
+) (approvedStep, error) {
+	if ch != nil {
+		var required bool
+		err := tx.QueryRow(ctx, `
+			SELECT a.challenge_threshold_minor > 0
+				AND w.amount_minor >= a.challenge_threshold_minor
+			FROM orchestrator.withdrawals w
+			JOIN orchestrator.assets a ON a.asset = w.asset
+			WHERE w.id = $1
+		`, withdrawalID).Scan(&required)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return approvedStep{}, fmt.Errorf("challenge threshold: %w", err)
+		}
+		if required {
+			return approvedStep{Step: orchestrator.SagaStepAuthChallenge}, nil
+		}
+	}
+
+	policy, err := r.matchApprovalPolicyTx(ctx, tx, withdrawalID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return approvedStep{Step: orchestrator.SagaStepAwaitingExecution}, nil
+	}
+	if err != nil {
+		return approvedStep{}, err
+	}
+	return approvedStep{Step: orchestrator.SagaStepApproval, Policy: &policy}, nil
+}
+
+// enterApprovedStepTx records what the step needs and returns the events announcing it. approved
+// is the WithdrawalApproved event the caller would emit had nothing else been required; it goes
+// out together with the execution command once the saga reaches AWAITING_EXECUTION.
+func (r *Repo) enterApprovedStepTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	s approvedStep,
+	approved OutboxEvent,
+	ch *ChallengeParams,
+	at time.Time,
+) ([]OutboxEvent, error) {
+	switch s.Step {
+	case orchestrator.SagaStepAuthChallenge:
+		if err := r.insertChallengeTx(ctx, tx, withdrawalID, ch, at); err != nil {
+			return nil, err
+		}
+		return []OutboxEvent{ch.Outbox}, nil
+
+	case orchestrator.SagaStepApproval:
+		if err := r.insertApprovalRequestTx(ctx, tx, withdrawalID, *s.Policy, at); err != nil {
+			return nil, err
+		}
+		return []OutboxEvent{{
+			EventType: orchestrator.EventTypeApprovalRequested,
+			Payload:   approved.Payload,
+			RouteKey:  approved.RouteKey,
+		}}, nil
+
+	default:
+		cmd, err := r.executionCommandTx(ctx, tx, withdrawalID)
+		if err != nil {
+			return nil, err
+		}
+		return []OutboxEvent{approved, cmd}, nil
+	}
+}
+
+func (r *Repo) failWithdrawalTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	withdrawalID string,
+	reason string,
+	at time.Time,
+) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE orchestrator.withdrawals
+		SET
+			status = $2,
+			failure_reason = $3,
+			updated_at = $4
+		WHERE
+			id = $1
+			AND status IN ('REQUESTED', 'IN_PROGRESS')
+	`,
+		withdrawalID,
+		orchestrator.WithdrawalStatusFailed,
+		reason,
+		at,
+	)
+	if err != nil {
+		return fmt.Errorf("fail withdrawal: %w", err)
+	}
+	return nil
+}
+
+type OutboxParams struct {
+	WithdrawalID string
+	Event        OutboxEvent
+	TraceID      string
+	Traceparent  *string
+}
+
+func (r *Repo) insertOutboxTx(ctx context.Context, tx pgx.Tx, p OutboxParams) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO orchestrator.outbox_events (
+			event_id,
+			aggregate_type,
+			aggregate_id,
+			event_type,
+			payload_json,
+			trace_id,
+			route_key,
+			traceparent
+		)
+		VALUES (gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7)
+	`,
+		orchestrator.AggregateTypeWithdrawal,
+		p.WithdrawalID,
+		p.Event.EventType,
+		p.Event.Payload,
+		p.TraceID,
+		p.Event.RouteKey,
+		p.Traceparent,
+	)
+	if err != nil {
+		return fmt.Errorf("insert outbox %s: %w", p.Event.EventType, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/shared/orchestrator"
+	"github.com/jackc/pgx/v5"
+)
+
+// VelocityLimitError reports the first rolling window whose cap the request would exceed. Window
+// is zero when the tier has no caps for the asset at all: an uncapped asset is refused rather than
+// left unlimited.
+type VelocityLimitError struct {
+	Tier           string
+	Asset          string
+	Window         time.Duration
+	MaxAmountMinor int64
+	UsedMinor      int64
+}
+
+func (e VelocityLimitError) Error() string {
+	if e.Window == 0 {
+		return fmt.Sprintf("tier %s has no velocity limits for %s", e.Tier, e.Asset)
+	}
+	return fmt.Sprintf("tier %s allows %d per %s, %d already used",
+		e.Tier,
+		e.MaxAmountMinor,
+		e.Window,
+		e.UsedMinor,
+	)
+}
+
+type CheckVelocityParams struct {
+	UserID      string
+	Asset       string
+	AmountMinor int64
+	DefaultTier string
+}
+
+// CheckVelocityTx sums the user's non-failed withdrawals of the asset over each configured
+// window and fails if adding p.AmountMinor exceeds a cap, or if the user's tier has no caps for
+// the asset. It first takes a transaction-scoped
+// advisory lock on (user, asset), so concurrent creates for the same pair serialize until the
+// holder commits and cannot both pass against the same total.
+func (r *Repo) CheckVelocityTx(ctx context.Context, tx pgx.Tx, p CheckVelocityParams) error {
+	_, err := tx.Exec(ctx, `
+		SELECT pg_advisory_xact_lock(hashtextextended('velocity:' || $1 || ':' || $2, 0))
+	`, p.UserID, p.Asset)
+	if err != nil {
+		return err
+	}
+
+	var tier string
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE(
+			(SELECT tier FROM orchestrator.user_velocity_tiers WHERE user_id = $1),
+			$2
+		)
+	`, p.UserID, p.DefaultTier).Scan(&tier)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT
+			l.window_seconds,
+			l.max_amount_minor,
+			LEAST(used.total, 9223372036854775807)::bigint,
+			used.total + $4 > l.max_amount_minor
+		FROM orchestrator.velocity_limits l
+		CROSS JOIN LATERAL (
+			SELECT COALESCE(SUM(w.amount_minor), 0)::numeric AS total
+			FROM orchestrator.withdrawals w
+			WHERE
+				w.user_id = $1
+				AND w.created_at > now() - make_interval(secs => l.window_seconds)
+				AND w.asset = $2
+				AND w.status <> $5
+		) used
+		WHERE l.tier = $3 AND l.asset = $2
+		ORDER BY l.window_seconds
+	`,
+		p.UserID,
+		p.Asset,
+		tier,
+		p.AmountMinor,
+		orchestrator.WithdrawalStatusFailed,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	limited := false
+	for rows.Next() {
+		limited = true
+		var (
+			e             = VelocityLimitError{Tier: tier, Asset: p.Asset}
+			windowSeconds int32
+			exceeded      bool
+		)
+		err := rows.Scan(&windowSeconds, &e.MaxAmountMinor, &e.UsedMinor, &exceeded)
+		if err != nil {
+			return err
+		}
+		if exceeded {
+			e.Window = time.Duration(windowSeconds) * time.Second
+			return e
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if !limited {
+		return VelocityLimitError{Tier: tier, Asset: p.Asset}
+	}
+	return nil
+}
@@ -26,8 +26,13 @@ type CreateWithdrawalParams struct {
 	Asset           string
 	AmountMinor     int64
 	DestinationAddr string
+	RequestedBy     string
 	TraceID         string
+	Traceparent     *string
 	OutboxEvents    []OutboxEvent
+
+	// VelocityDefaultTier is the limits tier for users without an explicit one.
+	VelocityDefaultTier string
 }
 
 type CreateWithdrawalResult struct {
@@ -40,14 +45,25 @@ func (r *Repo) CreateWithdrawalTx(
 	tx pgx.Tx,
 	p CreateWithdrawalParams,
 ) (CreateWithdrawalResult, error) {
-	_, err := tx.Exec(ctx, `
+	err := r.CheckVelocityTx(ctx, tx, CheckVelocityParams{
+		UserID:      p.UserID,
+		Asset:       p.Asset,
+		AmountMinor: p.AmountMinor,
+		DefaultTier: p.VelocityDefaultTier,
+	})
+	if err != nil {
+		return CreateWithdrawalResult{}, err
+	}
+
+	_, err = tx.Exec(ctx, `
 		INSERT INTO orchestrator.withdrawals (
 			id,
 			user_id,
 			asset,
 			amount_minor,
 			destination_addr,
-			status
+			status,
+			requested_by
 		)
 		VALUES (
 			$1,
@@ -55,7 +71,8 @@ func (r *Repo) CreateWithdrawalTx(
 			$3,
 			$4,
 			$5,
-			$6
+			$6,
+			NULLIF($7, '')
 		)
 	`,
 		p.WithdrawalID,
@@ -64,6 +81,7 @@ func (r *Repo) CreateWithdrawalTx(
 		p.AmountMinor,
 		p.DestinationAddr,
 		orchestrator.WithdrawalStatusRequested,
+		p.RequestedBy,
 	)
 	if err != nil {
 		if isUniqueViolation(err) {
@@ -106,7 +124,8 @@ func (r *Repo) CreateWithdrawalTx(
 			event_type,
 			payload_json,
 			trace_id,
-			route_key
+			route_key,
+			traceparent
 		)
 		VALUES (
 			gen_random_uuid(),
@@ -115,7 +134,8 @@ func (r *Repo) CreateWithdrawalTx(
 			$3,
 			$4,
 			$5,
-			$6
+			$6,
+			$7
 		)
 	`,
 			orchestrator.AggregateTypeWithdrawal,
@@ -124,6 +144,7 @@ func (r *Repo) CreateWithdrawalTx(
 			evt.Payload,
 			p.TraceID,
 			evt.RouteKey,
+			p.Traceparent,
 		)
 		if err != nil {
 			return CreateWithdrawalResult{}, err
@@ -156,8 +177,16 @@ type GetWithdrawalResult struct {
 	DestinationAddr string
 	Status          string
 	FailureReason   *string
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	// The broadcast transaction and, once final, the block it landed in.
+	TxHash        *string
+	FromAddr      *string
+	BroadcastAt   *time.Time
+	BlockHeight   *int64
+	BlockHash     *string
+	Confirmations *int64
+	CompletedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
 }
 
 func (r *Repo) GetWithdrawal(
@@ -175,6 +204,13 @@ func (r *Repo) GetWithdrawal(
 			destination_addr,
 			status,
 			failure_reason,
+			tx_hash,
+			from_addr,
+			broadcast_at,
+			block_height,
+			block_hash,
+			confirmations,
+			completed_at,
 			created_at,
 			updated_at
 		FROM orchestrator.withdrawals
@@ -190,6 +226,13 @@ func (r *Repo) GetWithdrawal(
 		&res.DestinationAddr,
 		&res.Status,
 		&res.FailureReason,
+		&res.TxHash,
+		&res.FromAddr,
+		&res.BroadcastAt,
+		&res.BlockHeight,
+		&res.BlockHash,
+		&res.Confirmations,
+		&res.CompletedAt,
 		&res.CreatedAt,
 		&res.UpdatedAt,
 	)
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (Principal, error)
+}
+
+type Claims struct {
+	Roles []string `json:"roles,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type HMACConfig struct {
+	// Keys maps a key ID (the JWT "kid" header) to its shared secret.
+	Keys     map[string][]byte
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// HMACAuthenticator verifies HS256 tokens against locally configured keys. It is meant for dev
+// and tests; production deployments plug in an Authenticator backed by the identity provider.
+type HMACAuthenticator struct {
+	cfg    HMACConfig
+	parser *jwt.Parser
+}
+
+func NewHMACAuthenticator(cfg HMACConfig) (*HMACAuthenticator, error) {
+	if len(cfg.Keys) == 0 {
+		return nil, errors.New("auth: at least one HMAC key is required")
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(cfg.Leeway),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &HMACAuthenticator{cfg: cfg, parser: jwt.NewParser(opts...)}, nil
+}
+
+func (a *HMACAuthenticator) Authenticate(_ context.Context, token string) (Principal, error) {
+	claims := &Claims{}
+	_, err := a.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := a.cfg.Keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if claims.Subject == "" {
+		return Principal{}, fmt.Errorf("%w: missing subject", ErrInvalidToken)
+	}
+
+	return Principal{Subject: claims.Subject, Roles: claims.Roles}, nil
+}
+
+// Sign issues an HS256 token with key kid. Used by the dev token tool.
+func (a *HMACAuthenticator) Sign(
+	kid string,
+	subject string,
+	roles []string,
+	ttl time.Duration,
+) (string, error) {
+	key, ok := a.cfg.Keys[kid]
+	if !ok {
+		return "", fmt.Errorf("unknown key id %q", kid)
+	}
+
+	now := time.Now()
+	claims := Claims{
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			Issuer:    a.cfg.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	if a.cfg.Audience != "" {
+		claims.Audience = jwt.ClaimStrings{a.cfg.Audience}
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	t.Header["kid"] = kid
+	return t.SignedString(key)
+}
+
+// ParseHMACKeys parses "kid:secret" pairs as produced by config.SplitCSV.
+func ParseHMACKeys(pairs []string) (map[string][]byte, error) {
+	keys := make(map[string][]byte, len(pairs))
+	for _, p := range pairs {
+		kid, secret, ok := strings.Cut(p, ":")
+		if !ok || kid == "" || secret == "" {
+			return nil, fmt.Errorf("auth: malformed key %q, want kid:secret", kid)
+		}
+		keys[kid] = []byte(secret)
+	}
+	return keys, nil
+}
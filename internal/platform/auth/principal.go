@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+const (
+	RoleAdmin   = "admin"
+	RoleSupport = "support"
+
+	// RoleRiskOperator works the manual review queue.
+	RoleRiskOperator = "risk_operator"
+
+	// RoleTreasuryApprover signs off large withdrawals under the default approval policies.
+	RoleTreasuryApprover = "treasury_approver"
+)
+
+// Principal is the authenticated caller. Subject is the user ID for end users and an operator
+// identifier for admin/support callers.
+type Principal struct {
+	Subject string
+	Roles   []string
+}
+
+func (p Principal) HasRole(roles ...string) bool {
+	for _, r := range roles {
+		if slices.Contains(p.Roles, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPrivileged reports whether the principal may act on resources owned by other users.
+func (p Principal) IsPrivileged() bool {
+	return p.HasRole(RoleAdmin, RoleSupport)
+}
+
+type ctxKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, ctxKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(ctxKey{}).(Principal)
+	return p, ok
+}
@@ -1,12 +1,16 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 )
 
+// EnvDev is the CBSAGA_ENV of a local checkout.
+const EnvDev = "dev"
+
 func GetEnv(key string, def string) string {
 	if v := os.Getenv(key); v != "" {
 		return v
@@ -33,6 +37,61 @@ func GetEnvDuration(key string, def time.Duration) time.Duration {
 	return def
 }
 
+func GetEnvInt(key string, def int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+
+	return n
+}
+
+func GetEnvBool(key string, def bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return def
+	}
+
+	return b
+}
+
+// DevDefault returns def when env is dev and "" anywhere else. Dev defaults are public, e.g.
+// secrets committed for local runs, so no other environment may fall back to them.
+func DevDefault(env, def string) string {
+	if env == EnvDev {
+		return def
+	}
+
+	return ""
+}
+
+// GetEnvDevDefault reads key, falling back to def only in dev. Outside dev the variable is
+// required.
+func GetEnvDevDefault(key, env, def string) (string, error) {
+	v := GetEnv(key, DevDefault(env, def))
+	if v == "" {
+		return "", fmt.Errorf("%s must be set when CBSAGA_ENV is %q", key, env)
+	}
+
+	return v, nil
+}
+
+// GetEnvBoolDevDefault reads key, defaulting to true in dev and false elsewhere, for features
+// that are convenient locally but must be opted into anywhere else.
+func GetEnvBoolDevDefault(key, env string) bool {
+	return GetEnvBool(key, env == EnvDev)
+}
+
 func SplitCSV(s string) []string {
 	parts := strings.Split(s, ",")
 	out := make([]string, 0, len(parts))
@@ -4,8 +4,10 @@ import (
 	"context"
 	"time"
 
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type DB interface {
@@ -13,6 +15,7 @@ type DB interface {
 }
 
 type DBTX interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
 }
@@ -23,9 +26,19 @@ func WithTx(
 	txOptions pgx.TxOptions,
 	op string,
 	fn func(context.Context, pgx.Tx) error,
-) error {
+) (err error) {
+	ctx, span := tracing.Tracer(tracerName).Start(ctx, "tx "+op)
+	span.SetAttributes(attribute.String("db.tx.op", op))
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	began := time.Now()
+
 	tx, err := db.BeginTx(ctx, txOptions)
 	if err != nil {
+		txDuration.WithLabelValues(op, txResultBeginFailed).Observe(time.Since(began).Seconds())
 		return BeginTxError{
 			Op:     op,
 			Err:    err,
@@ -37,10 +50,12 @@ func WithTx(
 	start := time.Now()
 
 	if err := fn(ctx, tx); err != nil {
+		txDuration.WithLabelValues(op, txResultRolledBack).Observe(time.Since(began).Seconds())
 		return err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
+		txDuration.WithLabelValues(op, txResultCommitUnknown).Observe(time.Since(began).Seconds())
 		return CommitUnknownError{
 			Op:       op,
 			Err:      err,
@@ -49,5 +64,6 @@ func WithTx(
 		}
 	}
 
+	txDuration.WithLabelValues(op, txResultCommitted).Observe(time.Since(began).Seconds())
 	return nil
 }
@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	txResultCommitted     = "committed"
+	txResultRolledBack    = "rolled_back"
+	txResultBeginFailed   = "begin_failed"
+	txResultCommitUnknown = "commit_unknown"
+)
+
+var txDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "db",
+	Name:      "tx_duration_seconds",
+	Help:      "Duration of transactions run through postgres.WithTx.",
+	Buckets:   metrics.DurationBuckets,
+}, []string{"op", "result"})
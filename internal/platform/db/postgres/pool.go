@@ -18,6 +18,7 @@ func NewPool(ctx context.Context, dsn string, log *logging.Logger) (*pgxpool.Poo
 	cfg.MinConns = 1
 	cfg.MaxConnLifetime = 30 * time.Minute
 	cfg.MaxConnIdleTime = 5 * time.Minute
+	cfg.ConnConfig.Tracer = newQueryTracer()
 
 	pool, err := pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
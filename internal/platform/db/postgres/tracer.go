@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/cicconee/cbsaga/internal/platform/db/postgres"
+
+// queryTracer creates a client span for every Query, QueryRow and Exec issued through the pool,
+// which covers each repo call without instrumenting the repos individually.
+type queryTracer struct {
+	tracer trace.Tracer
+}
+
+func newQueryTracer() *queryTracer {
+	return &queryTracer{tracer: tracing.Tracer(tracerName)}
+}
+
+func (t *queryTracer) TraceQueryStart(
+	ctx context.Context,
+	_ *pgx.Conn,
+	data pgx.TraceQueryStartData,
+) context.Context {
+	ctx, _ = t.tracer.Start(ctx, "postgres "+sqlOperation(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", compactSQL(data.SQL)),
+		),
+	)
+	return ctx
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span := trace.SpanFromContext(ctx)
+	tracing.RecordError(span, data.Err)
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	span.End()
+}
+
+func sqlOperation(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "query"
+	}
+	return strings.ToUpper(fields[0])
+}
+
+func compactSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
@@ -2,51 +2,123 @@ package grpcserver
 
 import (
 	"net"
+	"strings"
 	"time"
 
 	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
 type Server struct {
-	grpcServer *grpc.Server
-	lis        net.Listener
+	grpcServer   *grpc.Server
+	healthServer *health.Server
+	lis          net.Listener
+	certs        *certReloader
 }
 
 type Options struct {
 	Addr                string
 	GracefulStopTimeout time.Duration
+	UnaryInterceptors   []grpc.UnaryServerInterceptor
+	StreamInterceptors  []grpc.StreamServerInterceptor
+
+	// TLS enables transport security. Nil serves plaintext.
+	TLS *TLSOptions
+
+	// Reflection registers the gRPC reflection service.
+	Reflection bool
 }
 
 func New(opts Options, log *logging.Logger, register func(s *grpc.Server)) (*Server, error) {
+	serverOpts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(opts.UnaryInterceptors...),
+		grpc.ChainStreamInterceptor(opts.StreamInterceptors...),
+	}
+
+	var certs *certReloader
+	if opts.TLS != nil {
+		var err error
+		certs, err = newCertReloader(*opts.TLS, log)
+		if err != nil {
+			return nil, err
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(certs.tlsConfig())))
+	}
+
 	lis, err := net.Listen("tcp", opts.Addr)
 	if err != nil {
 		return nil, err
 	}
 
-	gs := grpc.NewServer()
+	gs := grpc.NewServer(serverOpts...)
 
+	// Every service starts NOT_SERVING until the health checker reports its dependencies up.
 	hs := health.NewServer()
-	hs.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	hs.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 	grpc_health_v1.RegisterHealthServer(gs, hs)
 
-	reflection.Register(gs)
+	if opts.Reflection {
+		reflection.Register(gs)
+	}
 
 	if register != nil {
 		register(gs)
 	}
 
-	log.Info("gRPC server created", "addr", opts.Addr)
+	for _, name := range appServices(gs) {
+		hs.SetServingStatus(name, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+
+	if certs != nil {
+		go certs.run()
+	}
+
+	log.Info("gRPC server created",
+		"addr", opts.Addr,
+		"tls", opts.TLS != nil,
+		"mtls", opts.TLS != nil && opts.TLS.ClientCAFile != "",
+		"reflection", opts.Reflection,
+	)
 
 	return &Server{
-		grpcServer: gs,
-		lis:        lis,
+		grpcServer:   gs,
+		healthServer: hs,
+		lis:          lis,
+		certs:        certs,
 	}, nil
 }
 
+// SetServing flips the health status of the overall server and every registered service.
+func (s *Server) SetServing(serving bool) {
+	st := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if serving {
+		st = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+
+	s.healthServer.SetServingStatus("", st)
+	for _, name := range appServices(s.grpcServer) {
+		s.healthServer.SetServingStatus(name, st)
+	}
+}
+
+// appServices lists registered services, skipping the grpc.* health and reflection services.
+func appServices(gs *grpc.Server) []string {
+	var names []string
+	for name := range gs.GetServiceInfo() {
+		if strings.HasPrefix(name, "grpc.") {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
 func (s *Server) Serve(log *logging.Logger) error {
 	log.Info("gRPC server starting")
 	return s.grpcServer.Serve(s.lis)
@@ -54,6 +126,10 @@ func (s *Server) Serve(log *logging.Logger) error {
 
 func (s *Server) GracefulStop(log *logging.Logger) {
 	log.Info("gRPC server graceful stopping")
+	s.healthServer.Shutdown()
 	s.grpcServer.GracefulStop()
+	if s.certs != nil {
+		s.certs.stop()
+	}
 	log.Info("gRPC server stopped")
 }
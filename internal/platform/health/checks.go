@@ -0,0 +1,40 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+func PostgresCheck(db Pinger) Check {
+	return func(ctx context.Context) error {
+		return db.Ping(ctx)
+	}
+}
+
+// KafkaCheck passes when at least one broker accepts a connection.
+func KafkaCheck(brokers []string) Check {
+	return func(ctx context.Context) error {
+		if len(brokers) == 0 {
+			return errors.New("no kafka brokers configured")
+		}
+
+		var errs []error
+		for _, b := range brokers {
+			conn, err := kafka.DialContext(ctx, "tcp", b)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", b, err))
+				continue
+			}
+			_ = conn.Close()
+			return nil
+		}
+		return errors.Join(errs...)
+	}
+}
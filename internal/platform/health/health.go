@@ -0,0 +1,176 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+)
+
+type Check func(ctx context.Context) error
+
+type Kind int
+
+const (
+	// Readiness checks gate traffic: a failing dependency flips the service to NOT_SERVING.
+	Readiness Kind = iota
+	// Liveness checks report whether the process itself is still making progress.
+	Liveness
+)
+
+type Status struct {
+	Name      string    `json:"name"`
+	Healthy   bool      `json:"healthy"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type Report struct {
+	Healthy bool     `json:"healthy"`
+	Checks  []Status `json:"checks"`
+}
+
+type Options struct {
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+type check struct {
+	name string
+	kind Kind
+	fn   Check
+}
+
+// Checker runs the registered checks on an interval and caches the results so probes never
+// hit Postgres or Kafka directly.
+type Checker struct {
+	opts Options
+	log  *logging.Logger
+
+	mu        sync.RWMutex
+	checks    []check
+	results   map[string]Status
+	ready     bool
+	listeners []func(ready bool)
+}
+
+func NewChecker(opts Options, log *logging.Logger) *Checker {
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Second
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 2 * time.Second
+	}
+
+	return &Checker{
+		opts:    opts,
+		log:     log,
+		results: make(map[string]Status),
+	}
+}
+
+func (c *Checker) Add(name string, kind Kind, fn Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{name: name, kind: kind, fn: fn})
+}
+
+// OnChange registers fn to be called whenever overall readiness flips, and once after the
+// first round of checks.
+func (c *Checker) OnChange(fn func(ready bool)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, fn)
+}
+
+func (c *Checker) Run(ctx context.Context) {
+	c.runOnce(ctx, true)
+
+	ticker := time.NewTicker(c.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.runOnce(ctx, false)
+		}
+	}
+}
+
+func (c *Checker) runOnce(ctx context.Context, first bool) {
+	c.mu.RLock()
+	checks := append([]check(nil), c.checks...)
+	c.mu.RUnlock()
+
+	results := make(map[string]Status, len(checks))
+	ready := true
+	for _, chk := range checks {
+		cctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+		err := chk.fn(cctx)
+		cancel()
+
+		st := Status{Name: chk.name, Healthy: err == nil, CheckedAt: time.Now().UTC()}
+		if err != nil {
+			st.Error = err.Error()
+			ready = false
+		}
+		results[chk.name] = st
+	}
+
+	c.mu.Lock()
+	for name, st := range results {
+		prev, seen := c.results[name]
+		if seen && prev.Healthy != st.Healthy {
+			if st.Healthy {
+				c.log.Info("health check recovered", "check", name)
+			} else {
+				c.log.Warn("health check failing", "check", name, "err", st.Error)
+			}
+		}
+	}
+	c.results = results
+	changed := first || c.ready != ready
+	c.ready = ready
+	listeners := append([]func(bool){}, c.listeners...)
+	c.mu.Unlock()
+
+	if changed {
+		for _, fn := range listeners {
+			fn(ready)
+		}
+	}
+}
+
+// Live reports the cached liveness checks.
+func (c *Checker) Live() Report {
+	return c.report(func(k Kind) bool { return k == Liveness })
+}
+
+// Ready reports every cached check; the service is only ready when its liveness checks pass too.
+func (c *Checker) Ready() Report {
+	return c.report(func(Kind) bool { return true })
+}
+
+func (c *Checker) report(include func(Kind) bool) Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	r := Report{Healthy: true}
+	for _, chk := range c.checks {
+		if !include(chk.kind) {
+			continue
+		}
+		st, ok := c.results[chk.name]
+		if !ok {
+			st = Status{Name: chk.name, Error: "not checked yet"}
+		}
+		if !st.Healthy {
+			r.Healthy = false
+		}
+		r.Checks = append(r.Checks, st)
+	}
+	return r
+}
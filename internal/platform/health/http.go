@@ -0,0 +1,24 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Register mounts /healthz (liveness) and /readyz (readiness) on mux.
+func Register(mux *http.ServeMux, c *Checker) {
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		writeReport(w, c.Live())
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, _ *http.Request) {
+		writeReport(w, c.Ready())
+	})
+}
+
+func writeReport(w http.ResponseWriter, r Report) {
+	w.Header().Set("Content-Type", "application/json")
+	if !r.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(r)
+}
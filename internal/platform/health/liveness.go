@@ -0,0 +1,92 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ConsumerLiveness tracks a Kafka consumer loop. A consumer blocked in FetchMessage is idle, not
+// stuck, so it only counts as stalled when a fetched message has been in flight longer than the
+// allowed duration or the loop has exited.
+type ConsumerLiveness struct {
+	mu        sync.Mutex
+	started   bool
+	fetching  bool
+	lastFetch time.Time
+	stopped   bool
+	stopErr   error
+}
+
+func NewConsumerLiveness() *ConsumerLiveness {
+	return &ConsumerLiveness{}
+}
+
+func (l *ConsumerLiveness) Fetching() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.started = true
+	l.fetching = true
+}
+
+func (l *ConsumerLiveness) Fetched() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fetching = false
+	l.lastFetch = time.Now()
+}
+
+// Waiting marks the loop as blocked on work outside it, such as funds other messages hold.
+// Like a fetch, a wait does not count as stalled.
+func (l *ConsumerLiveness) Waiting() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fetching = true
+}
+
+// Resumed ends a wait and gives the message in flight a fresh allowance.
+func (l *ConsumerLiveness) Resumed() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.fetching = false
+	l.lastFetch = time.Now()
+}
+
+func (l *ConsumerLiveness) Stopped(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stopped = true
+	l.stopErr = err
+}
+
+func (l *ConsumerLiveness) LastFetch() time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastFetch
+}
+
+func (l *ConsumerLiveness) Check(maxInFlight time.Duration) Check {
+	return func(context.Context) error {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		switch {
+		case l.stopped && l.stopErr != nil:
+			return fmt.Errorf("consumer stopped: %w", l.stopErr)
+		case l.stopped:
+			return fmt.Errorf("consumer stopped")
+		case !l.started:
+			return fmt.Errorf("consumer not started")
+		case l.fetching:
+			return nil
+		case time.Since(l.lastFetch) > maxInFlight:
+			return fmt.Errorf(
+				"consumer stalled: last successful fetch at %s",
+				l.lastFetch.UTC().Format(time.RFC3339),
+			)
+		default:
+			return nil
+		}
+	}
+}
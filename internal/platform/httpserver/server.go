@@ -0,0 +1,50 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+)
+
+type Server struct {
+	name       string
+	httpServer *http.Server
+	lis        net.Listener
+}
+
+func New(name string, addr string, handler http.Handler, log *logging.Logger) (*Server, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("http server created", "server", name, "addr", addr)
+
+	return &Server{
+		name: name,
+		httpServer: &http.Server{
+			Handler:           handler,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		lis: lis,
+	}, nil
+}
+
+func (s *Server) Serve(log *logging.Logger) error {
+	log.Info("http server starting", "server", s.name)
+	if err := s.httpServer.Serve(s.lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+func (s *Server) Shutdown(ctx context.Context, log *logging.Logger) {
+	log.Info("http server stopping", "server", s.name)
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		log.Warn("http server shutdown failed", "server", s.name, "err", err)
+	}
+}
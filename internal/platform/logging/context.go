@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ctxKey int
+
+const (
+	traceIDKey ctxKey = iota
+	withdrawalIDKey
+	sagaStepKey
+)
+
+// WithTraceID stores the saga trace ID (the trace_id header carried between services). It takes
+// precedence over the trace ID of the active span.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey, traceID)
+}
+
+func WithWithdrawalID(ctx context.Context, withdrawalID string) context.Context {
+	return context.WithValue(ctx, withdrawalIDKey, withdrawalID)
+}
+
+func WithSagaStep(ctx context.Context, step string) context.Context {
+	return context.WithValue(ctx, sagaStepKey, step)
+}
+
+func contextAttrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+
+	sc := trace.SpanContextFromContext(ctx)
+	if v, ok := ctx.Value(traceIDKey).(string); ok && v != "" {
+		attrs = append(attrs, slog.String("trace_id", v))
+	} else if sc.HasTraceID() {
+		attrs = append(attrs, slog.String("trace_id", sc.TraceID().String()))
+	}
+	if sc.HasSpanID() {
+		attrs = append(attrs, slog.String("span_id", sc.SpanID().String()))
+	}
+	if v, ok := ctx.Value(withdrawalIDKey).(string); ok && v != "" {
+		attrs = append(attrs, slog.String("withdrawal_id", v))
+	}
+	if v, ok := ctx.Value(sagaStepKey).(string); ok && v != "" {
+		attrs = append(attrs, slog.String("saga_step", v))
+	}
+
+	return attrs
+}
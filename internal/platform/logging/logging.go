@@ -1,31 +1,93 @@
 package logging
 
 import (
-	"log"
+	"context"
+	"io"
+	"log/slog"
 	"os"
+	"strings"
 )
 
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Config struct {
+	Format string // json | text
+	Level  string // debug | info | warn | error
+	Output io.Writer
+}
+
+// Logger keeps the Info/Debug/Warn/Error(msg, kv...) call sites of the original stdlib based
+// logger while emitting structured records through slog.
 type Logger struct {
-	*log.Logger
+	*slog.Logger
+}
+
+func New(service string, cfg Config) *Logger {
+	out := cfg.Output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	level, levelErr := ParseLevel(cfg.Level)
+
+	opts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	}
+
+	var h slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case FormatText:
+		h = slog.NewTextHandler(out, opts)
+	default:
+		h = slog.NewJSONHandler(out, opts)
+	}
+
+	l := &Logger{Logger: slog.New(&contextHandler{Handler: h}).With("service", service)}
+	if levelErr != nil {
+		l.Warn("invalid log level, defaulting to info", "level", cfg.Level)
+	}
+
+	return l
 }
 
-func New(service string) *Logger {
-	prefix := service + " "
-	return &Logger{Logger: log.New(os.Stdout, prefix, log.LstdFlags|log.Lmicroseconds|log.LUTC)}
+func (l *Logger) With(kv ...any) *Logger {
+	return &Logger{Logger: l.Logger.With(kv...)}
+}
+
+func ParseLevel(s string) (slog.Level, error) {
+	if s == "" {
+		return slog.LevelInfo, nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return slog.LevelInfo, err
+	}
+	return level, nil
 }
 
-func (l *Logger) Info(msg string, kv ...any) {
-	l.Printf("INFO  %s %v", msg, kv)
+// contextHandler enriches records logged with the *Context variants using the fields carried on
+// the context: trace/span IDs from the active span and the saga fields set with the With*
+// helpers in context.go.
+type contextHandler struct {
+	slog.Handler
 }
 
-func (l *Logger) Debug(msg string, kv ...any) {
-	l.Printf("DEBUG  %s %v", msg, kv)
+func (h *contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if ctx != nil {
+		r.AddAttrs(contextAttrs(ctx)...)
+	}
+	return h.Handler.Handle(ctx, r)
 }
 
-func (l *Logger) Warn(msg string, kv ...any) {
-	l.Printf("WARN  %s %v", msg, kv)
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
 }
 
-func (l *Logger) Error(msg string, kv ...any) {
-	l.Printf("ERROR  %s %v", msg, kv)
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
 }
@@ -0,0 +1,23 @@
+package logging
+
+import (
+	"log/slog"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var sensitiveKeys = map[string]struct{}{
+	"destination_addr": {},
+	"authorization":    {},
+	"password":         {},
+	"secret":           {},
+	"token":            {},
+}
+
+func redact(_ []string, a slog.Attr) slog.Attr {
+	if _, ok := sensitiveKeys[strings.ToLower(a.Key)]; ok {
+		return slog.String(a.Key, redacted)
+	}
+	return a
+}
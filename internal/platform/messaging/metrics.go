@@ -0,0 +1,58 @@
+package messaging
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/kafka-go"
+)
+
+var (
+	processingDuration = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "consumer",
+		Name:      "processing_duration_seconds",
+		Help:      "Time spent handling a single Kafka message.",
+		Buckets:   metrics.DurationBuckets,
+	}, []string{"consumer", "topic", "result"})
+
+	messageAge = metrics.Factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "consumer",
+		Name:      "message_age_seconds",
+		Help:      "Time between a message being produced and its handling completing.",
+		Buckets:   metrics.DurationBuckets,
+	}, []string{"consumer", "topic"})
+
+	consumerLag = metrics.Factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "consumer",
+		Name:      "lag_messages",
+		Help:      "Messages between the last handled offset and the partition high watermark.",
+	}, []string{"consumer", "topic", "partition"})
+)
+
+// ObserveMessage records processing latency, end-to-end age and lag for a handled message.
+func ObserveMessage(consumer string, m kafka.Message, started time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+
+	processingDuration.WithLabelValues(consumer, m.Topic, result).
+		Observe(time.Since(started).Seconds())
+
+	if !m.Time.IsZero() {
+		messageAge.WithLabelValues(consumer, m.Topic).Observe(time.Since(m.Time).Seconds())
+	}
+
+	if m.HighWaterMark > 0 {
+		lag := m.HighWaterMark - m.Offset - 1
+		if lag < 0 {
+			lag = 0
+		}
+		consumerLag.WithLabelValues(consumer, m.Topic, strconv.Itoa(m.Partition)).Set(float64(lag))
+	}
+}
@@ -0,0 +1,42 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const HeaderTraceparent = "traceparent"
+
+// StartConsumerSpan starts a span for handling m. When the producing outbox row carried a
+// traceparent the span continues that trace and links back to the producing span.
+func StartConsumerSpan(
+	ctx context.Context,
+	tracer trace.Tracer,
+	name string,
+	m kafka.Message,
+) (context.Context, trace.Span) {
+	headers := NewHeaders(m.Headers)
+
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", m.Topic),
+			attribute.Int("messaging.kafka.partition", m.Partition),
+			attribute.Int64("messaging.kafka.offset", m.Offset),
+		),
+	}
+
+	if tp, ok := headers.String(HeaderTraceparent); ok {
+		if producer := tracing.SpanContextFromTraceparent(tp); producer.IsValid() {
+			ctx = trace.ContextWithRemoteSpanContext(ctx, producer)
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: producer}))
+		}
+	}
+
+	return tracer.Start(ctx, name, opts...)
+}
@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const Namespace = "cbsaga"
+
+// Registry holds every collector exposed by a service. Packages register their collectors with
+// Factory at init time so the server only needs to serve this one registry.
+var Registry = prometheus.NewRegistry()
+
+var Factory = promauto.With(Registry)
+
+// DurationBuckets covers sub-millisecond Postgres calls up to multi-second saga steps.
+var DurationBuckets = []float64{
+	.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60,
+}
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
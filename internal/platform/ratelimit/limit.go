@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket: Burst tokens at most, refilled by one token every Every.
+type Limit struct {
+	Burst int
+	Every time.Duration
+}
+
+func (l Limit) Enabled() bool {
+	return l.Burst > 0 && l.Every > 0
+}
+
+// Bucket names one token bucket and the limit it refills by.
+type Bucket struct {
+	Key   string
+	Limit Limit
+}
+
+// Decision is the outcome of a Take. When it is not allowed, Denied is the index of the first
+// bucket that had no token and RetryAfter is how long until it has one.
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration
+	Denied     int
+}
+
+// Store takes one token from each bucket, all or none: if any bucket is empty no bucket is
+// debited.
+type Store interface {
+	Take(ctx context.Context, buckets []Bucket) (Decision, error)
+}
+
+// take applies the refill for elapsed time to tokens and tries to consume one. It returns the
+// remaining tokens and the decision.
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Decision) {
+	if elapsed > 0 {
+		tokens += float64(elapsed) / float64(limit.Every)
+	}
+	tokens = math.Min(tokens, float64(limit.Burst))
+
+	if tokens >= 1 {
+		return tokens - 1, Decision{Allowed: true}
+	}
+
+	wait := time.Duration((1 - tokens) * float64(limit.Every))
+	return tokens, Decision{RetryAfter: wait}
+}
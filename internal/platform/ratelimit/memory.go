@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryStore keeps buckets in process. Limits are per replica, so it only suits single-node
+// deployments and local development.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (s *MemoryStore) Take(_ context.Context, buckets []Bucket) (Decision, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tokens := make([]float64, len(buckets))
+	for i, bk := range buckets {
+		b, ok := s.buckets[bk.Key]
+		if !ok {
+			b = &bucket{tokens: float64(bk.Limit.Burst), updated: now}
+			s.buckets[bk.Key] = b
+		}
+
+		var d Decision
+		tokens[i], d = take(b.tokens, now.Sub(b.updated), bk.Limit)
+		if !d.Allowed {
+			d.Denied = i
+			return d, nil
+		}
+	}
+
+	for i, bk := range buckets {
+		b := s.buckets[bk.Key]
+		b.tokens = tokens[i]
+		b.updated = now
+	}
+	return Decision{Allowed: true}, nil
+}
+
+// Sweep drops buckets idle long enough to have refilled completely; they are indistinguishable
+// from new ones.
+func (s *MemoryStore) Sweep(maxIdle time.Duration) {
+	cutoff := s.now().Add(-maxIdle)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k, b := range s.buckets {
+		if b.updated.Before(cutoff) {
+			delete(s.buckets, k)
+		}
+	}
+}
+
+func (s *MemoryStore) RunSweeper(ctx context.Context, interval, maxIdle time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			s.Sweep(maxIdle)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package ratelimit
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	scopeUser   = "user"
+	scopeMethod = "method"
+)
+
+var (
+	rejectedTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "ratelimit",
+		Name:      "rejected_total",
+		Help:      "Calls rejected by the rate limiter by bucket scope.",
+	}, []string{"method", "scope"})
+
+	storeErrorsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "ratelimit",
+		Name:      "store_errors_total",
+		Help:      "Bucket store failures; the call is let through.",
+	}, []string{"method"})
+)
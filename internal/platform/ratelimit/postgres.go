@@ -0,0 +1,167 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/db/postgres"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/jackc/pgx/v5"
+)
+
+// PostgresStore keeps buckets in a table shared by every replica. The rows of one Take are locked
+// in key order for the read-modify-write, so concurrent takes on a key serialize without
+// deadlocking. Elapsed time is measured with clock_timestamp() once the rows are locked, not
+// with the transaction's start time. The table must have the shape:
+//
+//	bucket_key TEXT PRIMARY KEY, tokens DOUBLE PRECISION NOT NULL, updated_at TIMESTAMPTZ NOT NULL
+type PostgresStore struct {
+	db    pgDB
+	table string
+}
+
+type pgDB interface {
+	postgres.DB
+	postgres.DBTX
+}
+
+func NewPostgresStore(db pgDB, table string) *PostgresStore {
+	return &PostgresStore{db: db, table: table}
+}
+
+func (s *PostgresStore) Take(ctx context.Context, buckets []Bucket) (Decision, error) {
+	sorted := make([]Bucket, len(buckets))
+	copy(sorted, buckets)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+	keys := make([]string, len(sorted))
+	bursts := make([]float64, len(sorted))
+	for i, b := range sorted {
+		keys[i] = b.Key
+		bursts[i] = float64(b.Limit.Burst)
+	}
+
+	var d Decision
+	err := postgres.WithTx(ctx, s.db, pgx.TxOptions{}, "ratelimit_take",
+		func(ctx context.Context, tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, fmt.Sprintf(`
+				INSERT INTO %s (bucket_key, tokens, updated_at)
+				SELECT k, b, clock_timestamp()
+				FROM unnest($1::text[], $2::float8[]) AS t(k, b)
+				ORDER BY k
+				ON CONFLICT (bucket_key) DO NOTHING
+			`, s.table), keys, bursts); err != nil {
+				return err
+			}
+
+			rows, err := tx.Query(ctx, fmt.Sprintf(`
+				SELECT bucket_key, tokens, updated_at
+				FROM %s
+				WHERE bucket_key = ANY($1)
+				ORDER BY bucket_key
+				FOR UPDATE
+			`, s.table), keys)
+			if err != nil {
+				return err
+			}
+			type row struct {
+				tokens  float64
+				updated time.Time
+			}
+			found := make(map[string]row, len(keys))
+			for rows.Next() {
+				var key string
+				var r row
+				if err := rows.Scan(&key, &r.tokens, &r.updated); err != nil {
+					rows.Close()
+					return err
+				}
+				found[key] = r
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			// Read the clock once the rows are locked: waiting for a lock must count as elapsed.
+			var now time.Time
+			if err := tx.QueryRow(ctx, `SELECT clock_timestamp()`).Scan(&now); err != nil {
+				return err
+			}
+
+			remaining := make([]float64, len(buckets))
+			for i, b := range buckets {
+				r, ok := found[b.Key]
+				if !ok {
+					return fmt.Errorf("ratelimit: bucket %q missing", b.Key)
+				}
+				var bd Decision
+				remaining[i], bd = take(r.tokens, now.Sub(r.updated), b.Limit)
+				if !bd.Allowed {
+					// Nothing is debited, so the rows are left as they are.
+					bd.Denied = i
+					d = bd
+					return nil
+				}
+			}
+
+			for i, b := range buckets {
+				if _, err := tx.Exec(ctx, fmt.Sprintf(`
+					UPDATE %s
+					SET tokens = $2, updated_at = $3
+					WHERE bucket_key = $1
+				`, s.table), b.Key, remaining[i], now); err != nil {
+					return err
+				}
+			}
+			d = Decision{Allowed: true}
+			return nil
+		},
+	)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	return d, nil
+}
+
+// Sweep deletes buckets idle for maxIdle, long enough to have refilled completely; they are
+// indistinguishable from new ones. It returns how many were deleted.
+func (s *PostgresStore) Sweep(ctx context.Context, maxIdle time.Duration) (int64, error) {
+	tag, err := s.db.Exec(ctx, fmt.Sprintf(`
+		DELETE FROM %s
+		WHERE updated_at < clock_timestamp() - $1::interval
+	`, s.table), maxIdle)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (s *PostgresStore) RunSweeper(
+	ctx context.Context,
+	interval, maxIdle time.Duration,
+	log *logging.Logger,
+) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			n, err := s.Sweep(ctx, maxIdle)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.WarnContext(ctx, "rate limit sweep failed", "err", err)
+				}
+				continue
+			}
+			if n > 0 {
+				log.DebugContext(ctx, "rate limit buckets swept", "deleted", n)
+			}
+		}
+	}
+}
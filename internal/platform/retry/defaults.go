@@ -3,6 +3,7 @@ package retry
 import "time"
 
 const (
+	DefaultOp          = "unnamed"
 	DefaultMaxAttempts = 3
 	DefaultBaseDelay   = 50 * time.Millisecond
 	DefaultMaxDelay    = 500 * time.Millisecond
@@ -11,6 +12,7 @@ const (
 
 func DefaultConfig() Config {
 	return Config{
+		Op:          DefaultOp,
 		MaxAttempts: DefaultMaxAttempts,
 		BaseDelay:   DefaultBaseDelay,
 		MaxDelay:    DefaultMaxDelay,
@@ -0,0 +1,29 @@
+package retry
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultSuccess      = "success"
+	resultExhausted    = "exhausted"
+	resultNonRetryable = "non_retryable"
+	resultCanceled     = "canceled"
+)
+
+var (
+	attemptsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "retry",
+		Name:      "attempts_total",
+		Help:      "Attempts made by retry.Do, including the first.",
+	}, []string{"op"})
+
+	callsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "retry",
+		Name:      "calls_total",
+		Help:      "Completed retry.Do calls by final result.",
+	}, []string{"op", "result"})
+)
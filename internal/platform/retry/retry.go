@@ -7,6 +7,8 @@ import (
 )
 
 type Config struct {
+	// Op labels the retry metrics for this call site.
+	Op          string
 	MaxAttempts int
 	BaseDelay   time.Duration
 	MaxDelay    time.Duration
@@ -17,6 +19,9 @@ type Config struct {
 func normalizeConfig(cfg Config) Config {
 	def := DefaultConfig()
 
+	if cfg.Op != "" {
+		def.Op = cfg.Op
+	}
 	if cfg.MaxAttempts > 0 {
 		def.MaxAttempts = cfg.MaxAttempts
 	}
@@ -42,15 +47,23 @@ func Do(ctx context.Context, cfg Config, fn func() error) error {
 	var attempt int
 	for {
 		if err := ctx.Err(); err != nil {
+			callsTotal.WithLabelValues(cfg.Op, resultCanceled).Inc()
 			return err
 		}
 
 		attempt++
+		attemptsTotal.WithLabelValues(cfg.Op).Inc()
 		err := fn()
 		if err == nil {
+			callsTotal.WithLabelValues(cfg.Op, resultSuccess).Inc()
 			return nil
 		}
-		if attempt >= cfg.MaxAttempts || !cfg.IsRetryable(err) {
+		if !cfg.IsRetryable(err) {
+			callsTotal.WithLabelValues(cfg.Op, resultNonRetryable).Inc()
+			return err
+		}
+		if attempt >= cfg.MaxAttempts {
+			callsTotal.WithLabelValues(cfg.Op, resultExhausted).Inc()
 			return err
 		}
 
@@ -63,6 +76,7 @@ func Do(ctx context.Context, cfg Config, fn func() error) error {
 		select {
 		case <-ctx.Done():
 			timer.Stop()
+			callsTotal.WithLabelValues(cfg.Op, resultCanceled).Inc()
 			return ctx.Err()
 		case <-timer.C:
 		}
@@ -0,0 +1,145 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+	ExporterOTLP   = "otlp"
+)
+
+type Config struct {
+	Exporter     string
+	OTLPEndpoint string
+	OTLPInsecure bool
+	FilePath     string
+}
+
+// Setup installs the global tracer provider and W3C trace context propagator. With the "none"
+// exporter the provider still creates spans (so trace IDs exist for logs and outbox rows) but
+// never exports them.
+func Setup(ctx context.Context, service string, cfg Config) (func(context.Context) error, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", service)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdktrace.TracerProviderOption{sdktrace.WithResource(res)}
+	var closer io.Closer
+
+	switch cfg.Exporter {
+	case "", ExporterNone:
+	case ExporterStdout:
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	case ExporterFile:
+		if err := os.MkdirAll(filepath.Dir(cfg.FilePath), 0o755); err != nil {
+			return nil, err
+		}
+		f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, err
+		}
+		closer = f
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	case ExporterOTLP:
+		otlpOpts := []otlptracegrpc.Option{}
+		if cfg.OTLPEndpoint != "" {
+			otlpOpts = append(otlpOpts, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		if cfg.OTLPInsecure {
+			otlpOpts = append(otlpOpts, otlptracegrpc.WithInsecure())
+		}
+		exp, err := otlptracegrpc.New(ctx, otlpOpts...)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdktrace.WithBatcher(exp))
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", cfg.Exporter)
+	}
+
+	tp := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return err
+	}, nil
+}
+
+func Tracer(name string) trace.Tracer {
+	return otel.Tracer(name)
+}
+
+// TraceID returns the hex trace ID of the span in ctx, or an empty string if there is none.
+func TraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.HasTraceID() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// Traceparent returns the W3C traceparent of the span in ctx. It is stored on outbox rows so
+// Debezium can forward it as a Kafka header.
+func Traceparent(ctx context.Context) *string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	tp, ok := carrier["traceparent"]
+	if !ok || tp == "" {
+		return nil
+	}
+	return &tp
+}
+
+// SpanContextFromTraceparent parses a W3C traceparent into a remote span context.
+func SpanContextFromTraceparent(tp string) trace.SpanContext {
+	if tp == "" {
+		return trace.SpanContext{}
+	}
+	ctx := propagation.TraceContext{}.Extract(
+		context.Background(),
+		propagation.MapCarrier{"traceparent": tp},
+	)
+	return trace.SpanContextFromContext(ctx)
+}
+
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
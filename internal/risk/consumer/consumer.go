@@ -0,0 +1,292 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/codec"
+	"github.com/cicconee/cbsaga/internal/platform/health"
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+	"github.com/cicconee/cbsaga/internal/platform/messaging"
+	"github.com/cicconee/cbsaga/internal/platform/tracing"
+	"github.com/cicconee/cbsaga/internal/risk/engine"
+	"github.com/cicconee/cbsaga/internal/risk/repo"
+	"github.com/cicconee/cbsaga/internal/risk/screening"
+	"github.com/cicconee/cbsaga/internal/shared/risk"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/cicconee/cbsaga/internal/risk/consumer"
+
+type Consumer struct {
+	db       *pgxpool.Pool
+	repo     *repo.Repo
+	engine   *engine.Engine
+	screener *screening.Screener
+	log      *logging.Logger
+	r        *kafka.Reader
+	tracer   trace.Tracer
+	live     *health.ConsumerLiveness
+}
+
+func New(
+	db *pgxpool.Pool,
+	log *logging.Logger,
+	eng *engine.Engine,
+	screener *screening.Screener,
+	brokers []string,
+	groupID, topic string,
+) *Consumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		GroupID:     groupID,
+		Topic:       topic,
+		MinBytes:    1,
+		MaxBytes:    10e6, // 10mb
+		MaxWait:     500 * time.Millisecond,
+		StartOffset: kafka.LastOffset,
+	})
+
+	return &Consumer{
+		db:       db,
+		repo:     repo.New(),
+		engine:   eng,
+		screener: screener,
+		log:      log,
+		r:        reader,
+		tracer:   tracing.Tracer(tracerName),
+		live:     health.NewConsumerLiveness(),
+	}
+}
+
+func (c *Consumer) Liveness() *health.ConsumerLiveness {
+	return c.live
+}
+
+func (c *Consumer) Close() error {
+	return c.r.Close()
+}
+
+func (c *Consumer) Run(ctx context.Context) (err error) {
+	defer func() { c.live.Stopped(err) }()
+
+	c.log.Info("risk consumer started")
+
+	for {
+		c.live.Fetching()
+		m, err := c.r.FetchMessage(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				c.log.Info("risk consumer stopped")
+				return nil
+			}
+			return err
+		}
+		c.live.Fetched()
+
+		started := time.Now()
+		err = c.handleMessage(ctx, m)
+		messaging.ObserveMessage("risk", m, started, err)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Consumer) handleMessage(ctx context.Context, m kafka.Message) (err error) {
+	ctx, span := messaging.StartConsumerSpan(ctx, c.tracer, "risk.HandleRiskCheckRequest", m)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	headers := messaging.NewHeaders(m.Headers)
+	traceID, ok := headers.String("trace_id")
+	if !ok || traceID == "" {
+		traceID = "local-trace-id-risk"
+	}
+	ctx = logging.WithTraceID(ctx, traceID)
+
+	req := risk.RiskCheckRequestPayload{}
+	err = messaging.DecodeConnectEnvelopeValid(m.Value, &req)
+	if err != nil {
+		c.log.ErrorContext(ctx, "invalid risk check request, skipping", "err", err)
+		return c.r.CommitMessages(ctx, m)
+	}
+	ctx = logging.WithWithdrawalID(ctx, req.WithdrawalID)
+
+	tx, err := c.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Serialize scoring per user so velocity and new-destination checks see every earlier
+	// decision for that user.
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended('risk:' || $1, 0))`,
+		req.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Screening runs before scoring and a match rejects outright; the rules cannot outweigh it.
+	lists := c.screener.Lists()
+	var result engine.Result
+	var reason *string
+	if match, hit := lists.Screen(req.UserID, req.DestinationAddr); hit {
+		result, reason = sanctionsResult(match, c.engine.Version())
+		c.log.WarnContext(ctx, "sanctions screening matched",
+			"list", match.List,
+			"source", match.Entry.Source,
+			"sanctions_version", lists.Version,
+		)
+	} else {
+		result, err = c.score(ctx, tx, req)
+		if err != nil {
+			return err
+		}
+		reason = result.Reason()
+	}
+
+	outboxType := outboxEventType(result.Decision)
+
+	evtPayload, err := codec.EncodeValid(&risk.RiskCheckResultPayload{
+		WithdrawalID:     req.WithdrawalID,
+		UserID:           req.UserID,
+		Decision:         result.Decision,
+		Score:            result.Score,
+		TriggeredRules:   result.RuleIDs(),
+		RulesVersion:     result.Version,
+		SanctionsVersion: lists.Version,
+		Reason:           reason,
+	})
+	if err != nil {
+		c.log.ErrorContext(ctx, "encode risk result failed, skipping", "err", err)
+		return c.r.CommitMessages(ctx, m)
+	}
+
+	triggered := result.Triggered
+	if triggered == nil {
+		triggered = []engine.Triggered{}
+	}
+	triggeredJSON, err := json.Marshal(triggered)
+	if err != nil {
+		return err
+	}
+
+	applied, err := c.repo.DecideAndEmitTx(ctx, tx, repo.DecideAndEmitParams{
+		DecisionID:       uuid.New().String(),
+		WithdrawalID:     req.WithdrawalID,
+		UserID:           req.UserID,
+		Asset:            req.Asset,
+		AmountMinor:      req.AmountMinor,
+		DestinationAddr:  req.DestinationAddr,
+		Score:            result.Score,
+		Decision:         result.Decision,
+		TriggeredRules:   triggeredJSON,
+		RulesVersion:     result.Version,
+		SanctionsVersion: lists.Version,
+		Reason:           reason,
+		OutboxEventType:  outboxType,
+		OutboxPayload:    string(evtPayload),
+		TraceID:          traceID,
+		Traceparent:      tracing.Traceparent(ctx),
+		RouteKey:         risk.RouteKeyRiskEvt,
+	})
+	if err != nil {
+		c.log.ErrorContext(ctx, "DecideAndEmitTx failed", "err", err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	if err := c.r.CommitMessages(ctx, m); err != nil {
+		c.log.ErrorContext(ctx, "CommitMessages failed", "err", err)
+		return err
+	}
+
+	if !applied {
+		c.log.InfoContext(ctx, "risk decision already recorded")
+		return nil
+	}
+	observeDecision(result)
+
+	c.log.InfoContext(ctx, "risk emitted decision",
+		"decision", result.Decision,
+		"score", result.Score,
+		"rules", result.RuleIDs(),
+		"rules_version", result.Version,
+		"sanctions_version", lists.Version,
+		"event_type", outboxType,
+	)
+
+	return nil
+}
+
+func (c *Consumer) score(
+	ctx context.Context,
+	tx pgx.Tx,
+	req risk.RiskCheckRequestPayload,
+) (engine.Result, error) {
+	now := time.Now().UTC()
+	history, err := c.repo.HistoryTx(ctx, tx, repo.HistoryParams{
+		WithdrawalID:    req.WithdrawalID,
+		UserID:          req.UserID,
+		DestinationAddr: req.DestinationAddr,
+		Since:           now.Add(-c.engine.Lookback()),
+	})
+	if err != nil {
+		return engine.Result{}, err
+	}
+
+	in := engine.Input{
+		Asset:            req.Asset,
+		AmountMinor:      req.AmountMinor,
+		DestinationAddr:  req.DestinationAddr,
+		Now:              now,
+		KnownDestination: history.KnownDestination,
+	}
+	for _, a := range history.Recent {
+		in.Recent = append(in.Recent, engine.Attempt{
+			Asset:       a.Asset,
+			AmountMinor: a.AmountMinor,
+			At:          a.CreatedAt,
+		})
+	}
+	return c.engine.Evaluate(in), nil
+}
+
+// sanctionsResult reports a screening match in the same shape as a rules decision, so it is
+// stored, emitted and counted like any other rejection.
+func sanctionsResult(m screening.Match, rulesVersion string) (engine.Result, *string) {
+	reason := m.Reason()
+	return engine.Result{
+		Decision: risk.RiskStatusRejected,
+		Triggered: []engine.Triggered{{
+			ID:     "sanctions_" + m.List,
+			Type:   "sanctions",
+			Detail: reason,
+		}},
+		Version: rulesVersion,
+	}, &reason
+}
+
+func outboxEventType(decision string) string {
+	switch decision {
+	case risk.RiskStatusRejected:
+		return risk.EventTypeRiskCheckRejected
+	case risk.RiskStatusReview:
+		return risk.EventTypeRiskCheckReview
+	default:
+		return risk.EventTypeRiskCheckApproved
+	}
+}
@@ -0,0 +1,30 @@
+package consumer
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/cicconee/cbsaga/internal/risk/engine"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	decisionsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "risk",
+		Name:      "decisions_total",
+		Help:      "Risk decisions by outcome.",
+	}, []string{"decision"})
+
+	rulesTriggeredTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "risk",
+		Name:      "rules_triggered_total",
+		Help:      "Risk rules that contributed to a decision.",
+	}, []string{"rule"})
+)
+
+func observeDecision(r engine.Result) {
+	decisionsTotal.WithLabelValues(r.Decision).Inc()
+	for _, t := range r.Triggered {
+		rulesTriggeredTotal.WithLabelValues(t.ID).Inc()
+	}
+}
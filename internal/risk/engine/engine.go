@@ -0,0 +1,244 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/shared/risk"
+)
+
+// Attempt is a prior withdrawal by the same user that counts toward velocity rules.
+type Attempt struct {
+	Asset       string
+	AmountMinor int64
+	At          time.Time
+}
+
+// Input is everything a rule may look at. History is gathered by the caller so that
+// evaluation stays a pure function of its input.
+type Input struct {
+	Asset            string
+	AmountMinor      int64
+	DestinationAddr  string
+	Now              time.Time
+	KnownDestination bool
+	Recent           []Attempt
+}
+
+type Triggered struct {
+	ID     string `json:"id"`
+	Type   string `json:"type"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail"`
+}
+
+type Result struct {
+	Score     int
+	Decision  string
+	Triggered []Triggered
+	Version   string
+}
+
+// Reason summarizes the triggered rules for the emitted risk event. It is nil when nothing
+// triggered.
+func (r Result) Reason() *string {
+	if len(r.Triggered) == 0 {
+		return nil
+	}
+
+	parts := make([]string, len(r.Triggered))
+	for i, t := range r.Triggered {
+		parts[i] = fmt.Sprintf("%s(+%d): %s", t.ID, t.Score, t.Detail)
+	}
+	reason := fmt.Sprintf("score %d: %s", r.Score, strings.Join(parts, "; "))
+	return &reason
+}
+
+func (r Result) RuleIDs() []string {
+	ids := make([]string, len(r.Triggered))
+	for i, t := range r.Triggered {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+type compiledRule struct {
+	Rule
+	assets   map[string]bool
+	prefixes []string
+	start    int
+	end      int
+	loc      *time.Location
+}
+
+type Engine struct {
+	version    string
+	thresholds Thresholds
+	rules      []compiledRule
+	lookback   time.Duration
+}
+
+func New(rs RuleSet) (*Engine, error) {
+	if err := rs.validate(); err != nil {
+		return nil, fmt.Errorf("rules: %w", err)
+	}
+
+	e := &Engine{version: rs.Version, thresholds: rs.Thresholds}
+	seen := make(map[string]bool, len(rs.Rules))
+
+	for _, r := range rs.Rules {
+		if err := r.validate(); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", r.ID, err)
+		}
+		if seen[r.ID] {
+			return nil, fmt.Errorf("rule %q: duplicate id", r.ID)
+		}
+		seen[r.ID] = true
+
+		c := compiledRule{Rule: r}
+		if len(r.Assets) > 0 {
+			c.assets = make(map[string]bool, len(r.Assets))
+			for _, a := range r.Assets {
+				c.assets[strings.ToUpper(a)] = true
+			}
+		}
+		for _, p := range r.Prefixes {
+			c.prefixes = append(c.prefixes, strings.ToLower(strings.TrimSpace(p)))
+		}
+		if r.Type == RuleTimeOfDay {
+			c.start, _ = parseClock(r.Start)
+			c.end, _ = parseClock(r.End)
+			c.loc, _ = time.LoadLocation(r.Timezone)
+		}
+		if w := time.Duration(r.Window); r.Type == RuleVelocity && w > e.lookback {
+			e.lookback = w
+		}
+
+		e.rules = append(e.rules, c)
+	}
+
+	return e, nil
+}
+
+func (e *Engine) Version() string { return e.version }
+
+// Lookback is the longest velocity window, i.e. how much history the caller must supply in
+// Input.Recent.
+func (e *Engine) Lookback() time.Duration { return e.lookback }
+
+// Evaluate runs every rule against in. The decision is the stricter of the score-based outcome
+// and any decision forced by a triggered rule.
+func (e *Engine) Evaluate(in Input) Result {
+	res := Result{Decision: risk.RiskStatusApproved, Version: e.version}
+	forced := risk.RiskStatusApproved
+
+	for _, r := range e.rules {
+		if r.assets != nil && !r.assets[strings.ToUpper(in.Asset)] {
+			continue
+		}
+
+		detail, hit := r.match(in)
+		if !hit {
+			continue
+		}
+
+		res.Score += r.Score
+		res.Triggered = append(res.Triggered, Triggered{
+			ID:     r.ID,
+			Type:   r.Type,
+			Score:  r.Score,
+			Detail: detail,
+		})
+		if r.Decision != "" {
+			forced = stricter(forced, r.Decision)
+		}
+	}
+
+	switch {
+	case res.Score >= e.thresholds.Reject:
+		res.Decision = risk.RiskStatusRejected
+	case res.Score >= e.thresholds.Review:
+		res.Decision = risk.RiskStatusReview
+	}
+	res.Decision = stricter(res.Decision, forced)
+
+	return res
+}
+
+func (r compiledRule) match(in Input) (string, bool) {
+	switch r.Type {
+	case RuleAmountThreshold:
+		if in.AmountMinor >= r.MinAmountMinor {
+			return fmt.Sprintf("amount %d >= %d", in.AmountMinor, r.MinAmountMinor), true
+		}
+
+	case RuleNewDestination:
+		if !in.KnownDestination {
+			return "no prior approved withdrawal to destination", true
+		}
+
+	case RuleVelocity:
+		window := time.Duration(r.Window)
+		count, total := 1, in.AmountMinor
+		for _, a := range in.Recent {
+			if in.Now.Sub(a.At) > window {
+				continue
+			}
+			if r.assets != nil && !r.assets[strings.ToUpper(a.Asset)] {
+				continue
+			}
+			count++
+			// Minor units differ per asset, so amounts only add up within the request's asset.
+			if strings.EqualFold(a.Asset, in.Asset) {
+				total += a.AmountMinor
+			}
+		}
+		if r.MaxCount > 0 && count > r.MaxCount {
+			return fmt.Sprintf("%d withdrawals in %s > %d", count, window, r.MaxCount), true
+		}
+		if r.MaxAmountMinor > 0 && total > r.MaxAmountMinor {
+			return fmt.Sprintf("%d withdrawn in %s > %d", total, window, r.MaxAmountMinor), true
+		}
+
+	case RuleAddressPrefix:
+		addr := strings.ToLower(in.DestinationAddr)
+		for _, p := range r.prefixes {
+			if strings.HasPrefix(addr, p) {
+				return fmt.Sprintf("destination matches prefix %s", p), true
+			}
+		}
+
+	case RuleTimeOfDay:
+		local := in.Now.In(r.loc)
+		now := local.Hour()*60 + local.Minute()
+		inside := now >= r.start && now < r.end
+		if r.end <= r.start {
+			inside = now >= r.start || now < r.end
+		}
+		if inside {
+			return fmt.Sprintf("%s within %s-%s %s", local.Format("15:04"), r.Start, r.End, r.loc),
+				true
+		}
+	}
+
+	return "", false
+}
+
+func severity(decision string) int {
+	switch decision {
+	case risk.RiskStatusRejected:
+		return 2
+	case risk.RiskStatusReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func stricter(a, b string) string {
+	if severity(b) > severity(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/shared/risk"
+)
+
+const (
+	RuleAmountThreshold = "amount_threshold"
+	RuleNewDestination  = "new_destination"
+	RuleVelocity        = "velocity"
+	RuleAddressPrefix   = "address_prefix"
+	RuleTimeOfDay       = "time_of_day"
+)
+
+// RuleSet is the on-disk form of the rules file.
+type RuleSet struct {
+	Version    string     `json:"version"`
+	Thresholds Thresholds `json:"thresholds"`
+	Rules      []Rule     `json:"rules"`
+}
+
+// Thresholds map the summed score to a decision. A score at or above Reject is rejected, at or
+// above Review goes to review, anything lower is approved.
+type Thresholds struct {
+	Review int `json:"review"`
+	Reject int `json:"reject"`
+}
+
+// Rule is one declarative check. Which fields apply depends on Type; unused fields are ignored.
+type Rule struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Score int    `json:"score"`
+
+	// Decision optionally forces at least this outcome when the rule triggers, regardless of
+	// the total score. One of REVIEW or REJECTED.
+	Decision string `json:"decision,omitempty"`
+
+	// Assets limits the rule to these asset symbols. Empty applies to every asset.
+	Assets []string `json:"assets,omitempty"`
+
+	// amount_threshold
+	MinAmountMinor int64 `json:"min_amount_minor,omitempty"`
+
+	// velocity
+	Window         Duration `json:"window,omitempty"`
+	MaxCount       int      `json:"max_count,omitempty"`
+	MaxAmountMinor int64    `json:"max_amount_minor,omitempty"`
+
+	// address_prefix
+	Prefixes []string `json:"prefixes,omitempty"`
+
+	// time_of_day, as HH:MM in Timezone (default UTC). End before Start wraps past midnight.
+	Start    string `json:"start,omitempty"`
+	End      string `json:"end,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Duration reads Go duration strings ("1h", "30m") from JSON.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Load reads and compiles the rules file at path.
+func Load(path string) (*Engine, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rs RuleSet
+	if err := json.Unmarshal(b, &rs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	return New(rs)
+}
+
+func (rs RuleSet) validate() error {
+	if rs.Version == "" {
+		return errors.New("version is empty")
+	}
+	if rs.Thresholds.Review <= 0 || rs.Thresholds.Reject <= 0 {
+		return errors.New("thresholds must be positive")
+	}
+	if rs.Thresholds.Review > rs.Thresholds.Reject {
+		return errors.New("review threshold is above reject threshold")
+	}
+	return nil
+}
+
+func (r Rule) validate() error {
+	if r.ID == "" {
+		return errors.New("id is empty")
+	}
+	if r.Score < 0 {
+		return errors.New("score is negative")
+	}
+	if r.Decision != "" &&
+		r.Decision != risk.RiskStatusReview &&
+		r.Decision != risk.RiskStatusRejected {
+		return fmt.Errorf("decision must be %s or %s",
+			risk.RiskStatusReview,
+			risk.RiskStatusRejected,
+		)
+	}
+
+	switch r.Type {
+	case RuleAmountThreshold:
+		if r.MinAmountMinor <= 0 {
+			return errors.New("min_amount_minor must be positive")
+		}
+	case RuleNewDestination:
+	case RuleVelocity:
+		if r.Window <= 0 {
+			return errors.New("window must be positive")
+		}
+		if r.MaxCount <= 0 && r.MaxAmountMinor <= 0 {
+			return errors.New("max_count or max_amount_minor is required")
+		}
+	case RuleAddressPrefix:
+		if len(r.Prefixes) == 0 {
+			return errors.New("prefixes is empty")
+		}
+		for _, p := range r.Prefixes {
+			if strings.TrimSpace(p) == "" {
+				return errors.New("prefixes contains an empty entry")
+			}
+		}
+	case RuleTimeOfDay:
+		if _, err := parseClock(r.Start); err != nil {
+			return fmt.Errorf("start: %w", err)
+		}
+		if _, err := parseClock(r.End); err != nil {
+			return fmt.Errorf("end: %w", err)
+		}
+		if _, err := time.LoadLocation(r.Timezone); err != nil {
+			return fmt.Errorf("timezone: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown type %q", r.Type)
+	}
+
+	return nil
+}
+
+// parseClock returns minutes after midnight for an HH:MM string.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("want HH:MM, got %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
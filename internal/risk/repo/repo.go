@@ -0,0 +1,145 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type Repo struct{}
+
+func New() *Repo { return &Repo{} }
+
+type HistoryParams struct {
+	WithdrawalID    string
+	UserID          string
+	DestinationAddr string
+	Since           time.Time
+}
+
+type RecentAttempt struct {
+	Asset       string
+	AmountMinor int64
+	CreatedAt   time.Time
+}
+
+type History struct {
+	KnownDestination bool
+	Recent           []RecentAttempt
+}
+
+// HistoryTx loads what the rules need about the user's earlier withdrawals. The current
+// withdrawal is excluded so a redelivered command scores the same as the first delivery.
+func (r *Repo) HistoryTx(ctx context.Context, tx pgx.Tx, p HistoryParams) (History, error) {
+	var h History
+
+	err := tx.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM risk.decisions
+			WHERE
+				user_id = $1
+				AND destination_addr = $2
+				AND decision = 'APPROVED'
+				AND withdrawal_id <> $3
+		)
+	`, p.UserID, p.DestinationAddr, p.WithdrawalID).Scan(&h.KnownDestination)
+	if err != nil {
+		return History{}, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT asset, amount_minor, created_at
+		FROM risk.decisions
+		WHERE
+			user_id = $1
+			AND created_at > $2
+			AND decision <> 'REJECTED'
+			AND withdrawal_id <> $3
+		ORDER BY created_at DESC
+	`, p.UserID, p.Since, p.WithdrawalID)
+	if err != nil {
+		return History{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a RecentAttempt
+		if err := rows.Scan(&a.Asset, &a.AmountMinor, &a.CreatedAt); err != nil {
+			return History{}, err
+		}
+		h.Recent = append(h.Recent, a)
+	}
+
+	return h, rows.Err()
+}
+
+type DecideAndEmitParams struct {
+	DecisionID       string
+	WithdrawalID     string
+	UserID           string
+	Asset            string
+	AmountMinor      int64
+	DestinationAddr  string
+	Score            int
+	Decision         string
+	TriggeredRules   []byte
+	RulesVersion     string
+	SanctionsVersion string
+	Reason           *string
+	OutboxEventType  string
+	OutboxPayload    string
+	TraceID          string
+	Traceparent      *string
+	RouteKey         string
+}
+
+// DecideAndEmitTx records the decision and its outbox event. A decision that already exists for
+// the withdrawal is left untouched, nothing is emitted and false is returned.
+func (r *Repo) DecideAndEmitTx(
+	ctx context.Context,
+	tx pgx.Tx,
+	p DecideAndEmitParams,
+) (bool, error) {
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO risk.decisions
+			(decision_id, withdrawal_id, user_id, asset, amount_minor, destination_addr, score,
+			decision, triggered_rules, rules_version, sanctions_version, reason)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (withdrawal_id) DO NOTHING
+	`,
+		p.DecisionID,
+		p.WithdrawalID,
+		p.UserID,
+		p.Asset,
+		p.AmountMinor,
+		p.DestinationAddr,
+		p.Score,
+		p.Decision,
+		string(p.TriggeredRules),
+		p.RulesVersion,
+		p.SanctionsVersion,
+		p.Reason,
+	)
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO risk.outbox_events
+			(event_id, aggregate_type, aggregate_id, event_type, payload_json, trace_id, route_key,
+			traceparent)
+		VALUES
+			(gen_random_uuid(), 'risk', $1, $2, $3, $4, $5, $6)
+	`, p.WithdrawalID, p.OutboxEventType, p.OutboxPayload, p.TraceID, p.RouteKey, p.Traceparent)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
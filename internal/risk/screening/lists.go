@@ -0,0 +1,176 @@
+package screening
+
+import (
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	ListAddress = "address"
+	ListUser    = "user"
+)
+
+// Entry is one denylisted value and the list it was published on, e.g. "OFAC-SDN".
+type Entry struct {
+	Value  string
+	Source string
+}
+
+// Lists is an immutable snapshot of both denylists. Version identifies the exact file contents
+// it was built from.
+type Lists struct {
+	Version   string
+	addresses map[string]Entry
+	users     map[string]Entry
+}
+
+// loadLists reads both files. The version is a digest of their raw bytes, so any edit (including
+// reordering) produces a new version and an auditor can recompute it from archived files.
+func loadLists(addressesFile, usersFile string) (*Lists, error) {
+	h := sha256.New()
+
+	addresses, err := loadFile(addressesFile, "address", normalizeAddress, h)
+	if err != nil {
+		return nil, fmt.Errorf("address denylist: %w", err)
+	}
+	users, err := loadFile(usersFile, "user_id", normalizeUser, h)
+	if err != nil {
+		return nil, fmt.Errorf("user denylist: %w", err)
+	}
+
+	return &Lists{
+		Version:   hex.EncodeToString(h.Sum(nil))[:16],
+		addresses: addresses,
+		users:     users,
+	}, nil
+}
+
+// loadFile parses a CSV or JSON denylist, chosen by file extension.
+//
+// CSV needs a header row with a key column ("address" or "user_id") and an optional "source"
+// column. JSON is an array of objects with the same keys.
+func loadFile(
+	path string,
+	key string,
+	normalize func(string) string,
+	h io.Writer,
+) (map[string]Entry, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = h.Write(b)
+
+	var rows []map[string]string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		rows, err = parseCSV(b)
+	case ".json":
+		err = json.Unmarshal(b, &rows)
+	default:
+		return nil, fmt.Errorf("%s: unsupported format, want .csv or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	out := make(map[string]Entry, len(rows))
+	for i, row := range rows {
+		v := normalize(row[key])
+		if v == "" {
+			return nil, fmt.Errorf("%s: entry %d: %s is empty", path, i+1, key)
+		}
+		out[v] = Entry{Value: strings.TrimSpace(row[key]), Source: strings.TrimSpace(row["source"])}
+	}
+	return out, nil
+}
+
+func parseCSV(b []byte) ([]map[string]string, error) {
+	r := csv.NewReader(strings.NewReader(string(b)))
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("missing header row")
+	}
+
+	header := records[0]
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(header[i]))
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, col := range header {
+			if i < len(rec) {
+				row[col] = rec[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// normalizeAddress folds case for formats where case carries no meaning (hex and bech32), so an
+// EIP-55 checksummed destination matches a lowercase list entry. Base58 stays case-sensitive.
+func normalizeAddress(s string) string {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	for _, p := range []string{"0x", "bc1", "tb1", "bcrt1"} {
+		if strings.HasPrefix(lower, p) {
+			return lower
+		}
+	}
+	return s
+}
+
+func normalizeUser(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// Match describes why a request was stopped.
+type Match struct {
+	List  string // address | user
+	Entry Entry
+}
+
+// Screen checks the user and destination against the lists. The user list is checked first.
+func (l *Lists) Screen(userID, destinationAddr string) (Match, bool) {
+	if e, ok := l.users[normalizeUser(userID)]; ok {
+		return Match{List: ListUser, Entry: e}, true
+	}
+	if e, ok := l.addresses[normalizeAddress(destinationAddr)]; ok {
+		return Match{List: ListAddress, Entry: e}, true
+	}
+	return Match{}, false
+}
+
+// Reason is the failure reason recorded for a match.
+func (m Match) Reason() string {
+	subject := "destination address"
+	if m.List == ListUser {
+		subject = "user"
+	}
+	source := m.Entry.Source
+	if source == "" {
+		source = "unspecified"
+	}
+	return fmt.Sprintf("sanctions screening: %s %s is on the %s denylist",
+		subject,
+		m.Entry.Value,
+		source,
+	)
+}
@@ -0,0 +1,22 @@
+package screening
+
+import (
+	"github.com/cicconee/cbsaga/internal/platform/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	listEntries = metrics.Factory.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "sanctions",
+		Name:      "list_entries",
+		Help:      "Entries in the currently loaded sanctions denylists.",
+	}, []string{"list"})
+
+	listReloadsTotal = metrics.Factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "sanctions",
+		Name:      "list_reloads_total",
+		Help:      "Sanctions denylist reloads by result.",
+	}, []string{"result"})
+)
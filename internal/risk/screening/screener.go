@@ -0,0 +1,131 @@
+package screening
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cicconee/cbsaga/internal/platform/logging"
+)
+
+const defaultReloadInterval = 30 * time.Second
+
+type Options struct {
+	AddressesFile string
+	UsersFile     string
+
+	// ReloadInterval is how often the files are checked for changes. Updated lists apply to the
+	// next request without restarting the service.
+	ReloadInterval time.Duration
+}
+
+// Screener holds the current denylists and swaps them when the files on disk change. A failed
+// reload keeps screening against the previous lists.
+type Screener struct {
+	opts Options
+	log  *logging.Logger
+
+	mu       sync.RWMutex
+	lists    *Lists
+	modTimes map[string]time.Time
+}
+
+func New(opts Options, log *logging.Logger) (*Screener, error) {
+	if opts.ReloadInterval <= 0 {
+		opts.ReloadInterval = defaultReloadInterval
+	}
+
+	s := &Screener{opts: opts, log: log}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Lists returns the current snapshot. Callers screen and record the version from the same
+// snapshot so the audited version is the one actually used.
+func (s *Screener) Lists() *Lists {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lists
+}
+
+func (s *Screener) files() []string {
+	return []string{s.opts.AddressesFile, s.opts.UsersFile}
+}
+
+func (s *Screener) load() error {
+	modTimes := make(map[string]time.Time, 2)
+	for _, f := range s.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			return err
+		}
+		modTimes[f] = info.ModTime()
+	}
+
+	lists, err := loadLists(s.opts.AddressesFile, s.opts.UsersFile)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.lists = lists
+	s.modTimes = modTimes
+	s.mu.Unlock()
+
+	listEntries.WithLabelValues(ListAddress).Set(float64(len(lists.addresses)))
+	listEntries.WithLabelValues(ListUser).Set(float64(len(lists.users)))
+
+	return nil
+}
+
+func (s *Screener) changed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, f := range s.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			// The file may briefly be missing while it is replaced; try again next tick.
+			return false
+		}
+		if !info.ModTime().Equal(s.modTimes[f]) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run checks the files every ReloadInterval until ctx is done.
+func (s *Screener) Run(ctx context.Context) {
+	t := time.NewTicker(s.opts.ReloadInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if !s.changed() {
+				continue
+			}
+			prev := s.Lists().Version
+			if err := s.load(); err != nil {
+				listReloadsTotal.WithLabelValues("error").Inc()
+				s.log.Error("sanctions reload failed, keeping previous lists",
+					"err", err,
+					"version", prev,
+				)
+				continue
+			}
+			listReloadsTotal.WithLabelValues("ok").Inc()
+			s.log.Info("sanctions lists reloaded",
+				"previous_version", prev,
+				"version", s.Lists().Version,
+			)
+		}
+	}
+}
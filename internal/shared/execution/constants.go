@@ -0,0 +1,28 @@
+package execution
+
+const (
+	AggregateTypeExecution = "execution"
+)
+
+const (
+	ExecutionStatusSigned    = "SIGNED"
+	ExecutionStatusBroadcast = "BROADCAST"
+	ExecutionStatusConfirmed = "CONFIRMED"
+	// ExecutionStatusReorged marks a broadcast transaction that left the chain, reorged out or
+	// dropped. It waits for the saga to ask for a rebroadcast.
+	ExecutionStatusReorged = "REORGED"
+	ExecutionStatusFailed  = "FAILED"
+)
+
+const (
+	EventTypeExecuteWithdrawal   = "ExecuteWithdrawal"
+	EventTypeWithdrawalBroadcast = "WithdrawalBroadcast"
+	EventTypeExecutionFailed     = "WithdrawalExecutionFailed"
+	EventTypeWithdrawalConfirmed = "WithdrawalConfirmed"
+	EventTypeWithdrawalReorged   = "WithdrawalReorged"
+)
+
+const (
+	RouteKeyExecutionCmd = "cmd.execution"
+	RouteKeyExecutionEvt = "evt.execution"
+)
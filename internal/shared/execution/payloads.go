@@ -0,0 +1,136 @@
+package execution
+
+import "errors"
+
+type ExecuteWithdrawalPayload struct {
+	WithdrawalID    string `json:"withdrawal_id"`
+	UserID          string `json:"user_id"`
+	Asset           string `json:"asset"`
+	Network         string `json:"network"`
+	AmountMinor     int64  `json:"amount_minor"`
+	DestinationAddr string `json:"destination_addr"`
+	// Confirmations is the depth at which the transaction counts as final. Commands enqueued
+	// before it existed carry none and are rejected rather than guessed at.
+	Confirmations int64 `json:"confirmations"`
+}
+
+func (p *ExecuteWithdrawalPayload) Validate() error {
+	if p.WithdrawalID == "" {
+		return errors.New("withdrawal_id is empty")
+	}
+	if p.UserID == "" {
+		return errors.New("user_id is empty")
+	}
+	if p.Asset == "" {
+		return errors.New("asset is empty")
+	}
+	if p.Network == "" {
+		return errors.New("network is empty")
+	}
+	if p.AmountMinor <= 0 {
+		return errors.New("amount_minor not greater than zero")
+	}
+	if p.DestinationAddr == "" {
+		return errors.New("destination_addr is empty")
+	}
+	if p.Confirmations <= 0 {
+		return errors.New("confirmations not greater than zero")
+	}
+
+	return nil
+}
+
+type ExecutionResultPayload struct {
+	WithdrawalID string `json:"withdrawal_id"`
+	UserID       string `json:"user_id"`
+	Status       string `json:"status"` // BROADCAST | FAILED
+	Asset        string `json:"asset"`
+	Network      string `json:"network"`
+	// FromAddr and TxHash are set once the transaction is broadcast.
+	FromAddr string  `json:"from_addr,omitempty"`
+	TxHash   string  `json:"tx_hash,omitempty"`
+	Reason   *string `json:"reason,omitempty"`
+}
+
+func (p *ExecutionResultPayload) Validate() error {
+	if p.WithdrawalID == "" {
+		return errors.New("withdrawal_id is empty")
+	}
+	if p.UserID == "" {
+		return errors.New("user_id is empty")
+	}
+	switch p.Status {
+	case ExecutionStatusBroadcast:
+		if p.TxHash == "" || p.FromAddr == "" {
+			return errors.New("broadcast without tx_hash or from_addr")
+		}
+	case ExecutionStatusFailed:
+		if p.Reason == nil || *p.Reason == "" {
+			return errors.New("failure without reason")
+		}
+	default:
+		return errors.New("status is invalid")
+	}
+
+	return nil
+}
+
+type ConfirmationPayload struct {
+	WithdrawalID  string `json:"withdrawal_id"`
+	UserID        string `json:"user_id"`
+	Asset         string `json:"asset"`
+	Network       string `json:"network"`
+	TxHash        string `json:"tx_hash"`
+	BlockHeight   int64  `json:"block_height"`
+	BlockHash     string `json:"block_hash"`
+	Confirmations int64  `json:"confirmations"`
+}
+
+func (p *ConfirmationPayload) Validate() error {
+	if p.WithdrawalID == "" {
+		return errors.New("withdrawal_id is empty")
+	}
+	if p.UserID == "" {
+		return errors.New("user_id is empty")
+	}
+	if p.TxHash == "" {
+		return errors.New("tx_hash is empty")
+	}
+	if p.BlockHeight <= 0 || p.BlockHash == "" {
+		return errors.New("block is missing")
+	}
+	if p.Confirmations <= 0 {
+		return errors.New("confirmations not greater than zero")
+	}
+
+	return nil
+}
+
+type ReorgPayload struct {
+	WithdrawalID string `json:"withdrawal_id"`
+	UserID       string `json:"user_id"`
+	Asset        string `json:"asset"`
+	Network      string `json:"network"`
+	TxHash       string `json:"tx_hash"`
+	Reason       string `json:"reason"`
+	// Dropped is set when the chain no longer knows the transaction. Otherwise it is back in
+	// the mempool and can still be mined.
+	Dropped bool `json:"dropped"`
+}
+
+func (p *ReorgPayload) Validate() error {
+	if p.WithdrawalID == "" {
+		return errors.New("withdrawal_id is empty")
+	}
+	if p.UserID == "" {
+		return errors.New("user_id is empty")
+	}
+	if p.TxHash == "" {
+		return errors.New("tx_hash is empty")
+	}
+	if p.Reason == "" {
+		return errors.New("reason is empty")
+	}
+
+	return nil
+}
@@ -7,6 +7,15 @@ const (
 const (
 	IdentityStatusVerified = "VERIFIED"
 	IdentityStatusRejected = "REJECTED"
+	// IdentityStatusPending marks a verification waiting on the external provider's callback.
+	IdentityStatusPending = "PENDING"
+)
+
+const (
+	ProfileStatusUnverified = "UNVERIFIED"
+	ProfileStatusPending    = "PENDING"
+	ProfileStatusVerified   = "VERIFIED"
+	ProfileStatusRejected   = "REJECTED"
 )
 
 const (
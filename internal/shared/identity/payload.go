@@ -5,6 +5,8 @@ import "errors"
 type IdentityRequestCmdPayload struct {
 	WithdrawalID string `json:"withdrawal_id"`
 	UserID       string `json:"user_id"`
+	Asset        string `json:"asset"`
+	AmountMinor  int64  `json:"amount_minor"`
 }
 
 func (p *IdentityRequestCmdPayload) Validate() error {
@@ -14,10 +16,24 @@ func (p *IdentityRequestCmdPayload) Validate() error {
 	if p.UserID == "" {
 		return errors.New("user_id is empty")
 	}
+	if p.Legacy() {
+		return nil
+	}
+	if p.Asset == "" {
+		return errors.New("asset is empty")
+	}
+	if p.AmountMinor <= 0 {
+		return errors.New("amount_minor not greater than zero")
+	}
 
 	return nil
 }
 
+// Legacy reports a command enqueued before commands carried the asset and amount.
+func (p *IdentityRequestCmdPayload) Legacy() bool {
+	return p.Asset == "" && p.AmountMinor == 0
+}
+
 type IdentityRequestEvtPayload struct {
 	WithdrawalID string  `json:"withdrawal_id"`
 	UserID       string  `json:"user_id"`
@@ -0,0 +1,61 @@
+package orchestrator
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ChallengeCodeKeySize is the length of the AES-256 key shared with the notification sender.
+const ChallengeCodeKeySize = 32
+
+// SealChallengeCode encrypts a one-time code for the notification sender with AES-GCM, bound to
+// the withdrawal so a sealed code cannot be replayed against another one. The result is
+// base64(nonce || ciphertext).
+func SealChallengeCode(key []byte, withdrawalID, code string) (string, error) {
+	aead, err := challengeCodeAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("seal challenge code: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(code), []byte(withdrawalID))
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// OpenChallengeCode reverses SealChallengeCode.
+func OpenChallengeCode(key []byte, withdrawalID, sealed string) (string, error) {
+	aead, err := challengeCodeAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	raw, err := base64.StdEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", fmt.Errorf("open challenge code: %w", err)
+	}
+	if len(raw) < aead.NonceSize() {
+		return "", errors.New("open challenge code: too short")
+	}
+	nonce, ciphertext := raw[:aead.NonceSize()], raw[aead.NonceSize():]
+	code, err := aead.Open(nil, nonce, ciphertext, []byte(withdrawalID))
+	if err != nil {
+		return "", fmt.Errorf("open challenge code: %w", err)
+	}
+	return string(code), nil
+}
+
+func challengeCodeAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != ChallengeCodeKeySize {
+		return nil, fmt.Errorf("challenge code key must be %d bytes", ChallengeCodeKeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -7,20 +7,64 @@ const (
 const (
 	SagaStateStarted    = "STARTED"
 	SagaStateInProgress = "IN_PROGRESS"
+	SagaStateCompleted  = "COMPLETED"
 	SagaStateFailed     = "FAILED"
 )
 
 const (
 	SagaStepIdentityCheck = "IDENTITY_CHECK"
 	SagaStepRiskCheck     = "RISK_CHECK"
+	SagaStepManualReview  = "MANUAL_REVIEW"
+	SagaStepAuthChallenge = "AUTH_CHALLENGE"
+	SagaStepApproval      = "APPROVAL"
+	SagaStepCompleted     = "COMPLETED"
+	SagaStepFailed        = "FAILED"
+
+	// SagaStepAwaitingExecution is entered once every check has passed; the execution service
+	// is asked to send the funds at the same time.
+	SagaStepAwaitingExecution = "AWAITING_EXECUTION"
+	// SagaStepBroadcast is entered once the transaction is on the network. A transaction that
+	// leaves the chain sends the saga back to AWAITING_EXECUTION to be broadcast again, and one
+	// that reaches its confirmation depth completes it.
+	SagaStepBroadcast = "BROADCAST"
 )
 
 const (
 	WithdrawalStatusRequested  = "REQUESTED"
 	WithdrawalStatusInProgress = "IN_PROGRESS"
+	WithdrawalStatusCompleted  = "COMPLETED"
 	WithdrawalStatusFailed     = "FAILED"
 )
 
+const (
+	AssetStatusEnabled = "ENABLED"
+	AssetStatusPaused  = "PAUSED"
+)
+
+const (
+	ReviewStatusPending  = "PENDING"
+	ReviewStatusClaimed  = "CLAIMED"
+	ReviewStatusApproved = "APPROVED"
+	ReviewStatusRejected = "REJECTED"
+	ReviewStatusExpired  = "EXPIRED"
+	ReviewStatusStale    = "STALE"
+)
+
+const (
+	ChallengeStatusPending   = "PENDING"
+	ChallengeStatusConfirmed = "CONFIRMED"
+	ChallengeStatusFailed    = "FAILED"
+	ChallengeStatusExpired   = "EXPIRED"
+	ChallengeStatusStale     = "STALE"
+)
+
+const (
+	ApprovalStatusPending  = "PENDING"
+	ApprovalStatusApproved = "APPROVED"
+	ApprovalStatusRejected = "REJECTED"
+	ApprovalStatusStale    = "STALE"
+)
+
 const (
 	IdemInProgress = "IN_PROGRESS"
 	IdemCompleted  = "COMPLETED"
@@ -30,9 +74,17 @@ const (
 const (
 	EventTypeWithdrawalRequested = "WithdrawalRequested"
 	EventTypeWithdrawalFailed    = "WithdrawalFailed"
+	EventTypeWithdrawalApproved  = "WithdrawalApproved"
+	EventTypeReviewRequested     = "ManualReviewRequested"
+	EventTypeChallengeIssued     = "WithdrawalChallengeIssued"
+	EventTypeApprovalRequested   = "WithdrawalApprovalRequested"
+	EventTypeWithdrawalCompleted = "WithdrawalCompleted"
 )
 
 const (
 	RouteKeyWithdrawalCmd = "cmd.withdrawal"
 	RouteKeyWithdrawalEvt = "evt.withdrawal"
+	// RouteKeyNotificationEvt carries messages for the user, such as one-time codes. Only the
+	// notification sender should be allowed to read it.
+	RouteKeyNotificationEvt = "evt.notification"
 )
@@ -17,3 +17,87 @@ func (p *WithdrawalRequestPayload) Validate() error {
 
 	return nil
 }
+
+// WithdrawalEventPayload is published on evt.withdrawal when a saga is parked, approved or
+// failed. Step is the saga step whose outcome the event reports.
+type WithdrawalEventPayload struct {
+	WithdrawalID string  `json:"withdrawal_id"`
+	UserID       string  `json:"user_id"`
+	Step         string  `json:"step"`
+	Reason       *string `json:"reason,omitempty"`
+}
+
+func (p *WithdrawalEventPayload) Validate() error {
+	if p.WithdrawalID == "" {
+		return errors.New("withdrawal_id is empty")
+	}
+	if p.UserID == "" {
+		return errors.New("user_id is empty")
+	}
+	if p.Step == "" {
+		return errors.New("step is empty")
+	}
+
+	return nil
+}
+
+// WithdrawalCompletedPayload is published on evt.withdrawal once the withdrawal's transaction
+// is final.
+type WithdrawalCompletedPayload struct {
+	WithdrawalID  string `json:"withdrawal_id"`
+	UserID        string `json:"user_id"`
+	Asset         string `json:"asset"`
+	Network       string `json:"network"`
+	TxHash        string `json:"tx_hash"`
+	BlockHeight   int64  `json:"block_height"`
+	BlockHash     string `json:"block_hash"`
+	Confirmations int64  `json:"confirmations"`
+	CompletedAt   string `json:"completed_at"`
+}
+
+func (p *WithdrawalCompletedPayload) Validate() error {
+	if p.WithdrawalID == "" {
+		return errors.New("withdrawal_id is empty")
+	}
+	if p.UserID == "" {
+		return errors.New("user_id is empty")
+	}
+	if p.TxHash == "" {
+		return errors.New("tx_hash is empty")
+	}
+	if p.BlockHeight <= 0 || p.BlockHash == "" {
+		return errors.New("block is missing")
+	}
+	if p.CompletedAt == "" {
+		return errors.New("completed_at is empty")
+	}
+
+	return nil
+}
+
+// WithdrawalChallengePayload is published on evt.notification when a withdrawal needs the user
+// to confirm it with a one-time code. The event passes through the outbox table, so the code
+// travels sealed with the key shared with the notification sender; see OpenChallengeCode.
+type WithdrawalChallengePayload struct {
+	WithdrawalID string `json:"withdrawal_id"`
+	UserID       string `json:"user_id"`
+	SealedCode   string `json:"sealed_code"`
+	ExpiresAt    string `json:"expires_at"`
+}
+
+func (p *WithdrawalChallengePayload) Validate() error {
+	if p.WithdrawalID == "" {
+		return errors.New("withdrawal_id is empty")
+	}
+	if p.UserID == "" {
+		return errors.New("user_id is empty")
+	}
+	if p.SealedCode == "" {
+		return errors.New("sealed_code is empty")
+	}
+	if p.ExpiresAt == "" {
+		return errors.New("expires_at is empty")
+	}
+
+	return nil
+}
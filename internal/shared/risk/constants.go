@@ -7,12 +7,14 @@ const (
 const (
 	RiskStatusApproved = "APPROVED"
 	RiskStatusRejected = "REJECTED"
+	RiskStatusReview   = "REVIEW"
 )
 
 const (
 	EventTypeRiskCheckRequested = "RiskCheckRequested"
 	EventTypeRiskCheckApproved  = "RiskCheckApproved"
 	EventTypeRiskCheckRejected  = "RiskCheckRejected"
+	EventTypeRiskCheckReview    = "RiskCheckReview"
 )
 
 const (
@@ -29,3 +29,37 @@ func (p *RiskCheckRequestPayload) Validate() error {
 
 	return nil
 }
+
+type RiskCheckResultPayload struct {
+	WithdrawalID   string   `json:"withdrawal_id"`
+	UserID         string   `json:"user_id"`
+	Decision       string   `json:"decision"`
+	Score          int      `json:"score"`
+	TriggeredRules []string `json:"triggered_rules"`
+	RulesVersion   string   `json:"rules_version"`
+	// SanctionsVersion identifies the denylists the request was screened against.
+	SanctionsVersion string  `json:"sanctions_version"`
+	Reason           *string `json:"reason,omitempty"`
+}
+
+func (p *RiskCheckResultPayload) Validate() error {
+	if p.WithdrawalID == "" {
+		return errors.New("withdrawal_id is empty")
+	}
+	if p.UserID == "" {
+		return errors.New("user_id is empty")
+	}
+	if p.Decision != RiskStatusApproved &&
+		p.Decision != RiskStatusRejected &&
+		p.Decision != RiskStatusReview {
+		return errors.New("decision is invalid")
+	}
+	if p.RulesVersion == "" {
+		return errors.New("rules_version is empty")
+	}
+	if p.SanctionsVersion == "" {
+		return errors.New("sanctions_version is empty")
+	}
+
+	return nil
+}
@@ -26,4 +26,24 @@ if ! docker exec -i cbsaga-identity-postgres \
     "CREATE DATABASE identity;"
 fi
 
+if ! docker exec -i cbsaga-risk-postgres \
+  psql -q -tAc "SELECT 1 FROM pg_database WHERE datname='risk'" \
+  -U postgres -d postgres | grep -q 1; then
+
+  docker exec -i cbsaga-risk-postgres \
+    psql -q -v ON_ERROR_STOP=1 \
+    -U postgres -d postgres -c \
+    "CREATE DATABASE risk;"
+fi
+
+if ! docker exec -i cbsaga-execution-postgres \
+  psql -q -tAc "SELECT 1 FROM pg_database WHERE datname='execution'" \
+  -U postgres -d postgres | grep -q 1; then
+
+  docker exec -i cbsaga-execution-postgres \
+    psql -q -v ON_ERROR_STOP=1 \
+    -U postgres -d postgres -c \
+    "CREATE DATABASE execution;"
+fi
+
 echo "✅ databases ensured"
@@ -0,0 +1,17 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+# Generates the dev hot wallet key (a hex ed25519 seed) on first use. The key is gitignored and
+# never shared between checkouts; other environments provide their own CBSAGA_EXECUTION_KEY_FILE.
+KEY_FILE="${CBSAGA_EXECUTION_KEY_FILE:-./deployments/execution/hot-wallet.key}"
+
+if [[ -s "$KEY_FILE" ]]; then
+  echo "Hot wallet key exists: $KEY_FILE"
+  exit 0
+fi
+
+mkdir -p "$(dirname "$KEY_FILE")"
+umask 077
+od -An -tx1 -N32 /dev/urandom | tr -d ' \n' > "$KEY_FILE"
+echo >> "$KEY_FILE"
+echo "Generated hot wallet key: $KEY_FILE"
@@ -9,6 +9,8 @@ echo "Registering/updating Debezium connectors..."
 
 orch_name="orchestrator-connector"
 ident_name="identity-connector"
+risk_name="risk-connector"
+execution_name="execution-connector"
 
 curl -fsS -X PUT \
   -H "Content-Type: application/json" \
@@ -20,4 +22,14 @@ curl -fsS -X PUT \
   --data @"deployments/debezium/${ident_name}.json" \
   "${DEBEZIUM_URL}/connectors/${ident_name}/config" >/dev/null
 
+curl -fsS -X PUT \
+  -H "Content-Type: application/json" \
+  --data @"deployments/debezium/${risk_name}.json" \
+  "${DEBEZIUM_URL}/connectors/${risk_name}/config" >/dev/null
+
+curl -fsS -X PUT \
+  -H "Content-Type: application/json" \
+  --data @"deployments/debezium/${execution_name}.json" \
+  "${DEBEZIUM_URL}/connectors/${execution_name}/config" >/dev/null
+
 echo "✅ connectors registered"
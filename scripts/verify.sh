@@ -40,6 +40,8 @@ echo "Verifying Postgres connectivity..."
 
 check_db_connection "orchestrator" "cbsaga-postgres" 
 check_db_connection "identity" "cbsaga-identity-postgres"
+check_db_connection "risk" "cbsaga-risk-postgres"
+check_db_connection "execution" "cbsaga-execution-postgres"
 
 echo "✅ database connectivity OK"
 
@@ -47,6 +49,8 @@ echo "Verifying Debezium connectors..."
 
 check_connector "orchestrator-connector"
 check_connector "identity-connector"
+check_connector "risk-connector"
+check_connector "execution-connector"
 
 echo "✅ Debezium connectors OK"
 